@@ -0,0 +1,400 @@
+package ibapi
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Account value tags of the balances exposed by AccountState.
+const (
+	TagNetLiquidation      = "NetLiquidation"
+	TagTotalCashValue      = "TotalCashValue"
+	TagBuyingPower         = "BuyingPower"
+	TagExcessLiquidity     = "ExcessLiquidity"
+	TagAvailableFunds      = "AvailableFunds"
+	TagEquityWithLoanValue = "EquityWithLoanValue"
+	TagGrossPositionValue  = "GrossPositionValue"
+	TagInitMarginReq       = "InitMarginReq"
+	TagMaintMarginReq      = "MaintMarginReq"
+)
+
+// AccountValueKey identifies an account value: the same tag comes in several currencies.
+type AccountValueKey struct {
+	Tag      string
+	Currency string // "BASE" for the values converted to the base currency, empty for the non monetary values
+}
+
+// AccountValue is a tag/value pair of an account.
+type AccountValue struct {
+	Value   string
+	Updated time.Time
+}
+
+// Float parses the value.
+func (v AccountValue) Float() (float64, bool) {
+	f, err := strconv.ParseFloat(v.Value, 64)
+	return f, err == nil
+}
+
+// Money is an amount in a currency.
+type Money struct {
+	Amount   float64
+	Currency string
+}
+
+// PortfolioItem is a position of an account valued by TWS.
+type PortfolioItem struct {
+	Contract      *Contract
+	Position      Decimal
+	MarketPrice   float64
+	MarketValue   float64
+	AverageCost   float64
+	UnrealizedPNL float64
+	RealizedPNL   float64
+	Updated       time.Time
+}
+
+// AccountState is the state of an account, or of a model of an account.
+type AccountState struct {
+	Account    string
+	ModelCode  string
+	Values     map[AccountValueKey]AccountValue
+	Portfolio  map[int64]PortfolioItem // by conID, from ReqAccountUpdates
+	Positions  map[int64]Position      // by conID, from ReqPositions and ReqPositionsMulti
+	UpdateTime string                  // last UpdateAccountTime
+	Downloaded bool                    // AccountDownloadEnd received
+	Updated    time.Time
+}
+
+// Balance returns a monetary value of the account, BASE values excluded.
+// Tags reported in several currencies, like CashBalance, need Value.
+func (a AccountState) Balance(tag string) (Money, bool) {
+	for _, key := range slices.SortedFunc(maps.Keys(a.Values), compareAccountValueKeys) {
+		if key.Tag != tag || key.Currency == "" || key.Currency == "BASE" {
+			continue
+		}
+		if f, ok := a.Values[key].Float(); ok {
+			return Money{f, key.Currency}, true
+		}
+	}
+	return Money{}, false
+}
+
+// Value returns the value of tag in currency.
+func (a AccountState) Value(tag string, currency string) (AccountValue, bool) {
+	v, ok := a.Values[AccountValueKey{tag, currency}]
+	return v, ok
+}
+
+// NetLiquidation returns the NetLiquidation balance.
+func (a AccountState) NetLiquidation() (Money, bool) {
+	return a.Balance(TagNetLiquidation)
+}
+
+// TotalCashValue returns the TotalCashValue balance.
+func (a AccountState) TotalCashValue() (Money, bool) {
+	return a.Balance(TagTotalCashValue)
+}
+
+// BuyingPower returns the BuyingPower balance.
+func (a AccountState) BuyingPower() (Money, bool) {
+	return a.Balance(TagBuyingPower)
+}
+
+// ExcessLiquidity returns the ExcessLiquidity balance.
+func (a AccountState) ExcessLiquidity() (Money, bool) {
+	return a.Balance(TagExcessLiquidity)
+}
+
+// AvailableFunds returns the AvailableFunds balance.
+func (a AccountState) AvailableFunds() (Money, bool) {
+	return a.Balance(TagAvailableFunds)
+}
+
+// EquityWithLoanValue returns the EquityWithLoanValue balance.
+func (a AccountState) EquityWithLoanValue() (Money, bool) {
+	return a.Balance(TagEquityWithLoanValue)
+}
+
+// GrossPositionValue returns the GrossPositionValue balance.
+func (a AccountState) GrossPositionValue() (Money, bool) {
+	return a.Balance(TagGrossPositionValue)
+}
+
+// InitMarginReq returns the InitMarginReq balance.
+func (a AccountState) InitMarginReq() (Money, bool) {
+	return a.Balance(TagInitMarginReq)
+}
+
+// MaintMarginReq returns the MaintMarginReq balance.
+func (a AccountState) MaintMarginReq() (Money, bool) {
+	return a.Balance(TagMaintMarginReq)
+}
+
+// UnrealizedPNL returns the unrealized PnL of the portfolio.
+func (a AccountState) UnrealizedPNL() float64 {
+	var total float64
+	for _, item := range a.Portfolio {
+		total += item.UnrealizedPNL
+	}
+	return total
+}
+
+func compareAccountValueKeys(a, b AccountValueKey) int {
+	return cmp.Or(cmp.Compare(a.Tag, b.Tag), cmp.Compare(a.Currency, b.Currency))
+}
+
+func (a *AccountState) clone() AccountState {
+	c := *a
+	c.Values = maps.Clone(a.Values)
+	c.Portfolio = maps.Clone(a.Portfolio)
+	c.Positions = maps.Clone(a.Positions)
+	return c
+}
+
+// AccountEventKind tells what changed in an account.
+type AccountEventKind int
+
+const (
+	AccountValueChanged AccountEventKind = iota
+	AccountPortfolioChanged
+	AccountPositionChanged
+	AccountDownloaded
+	AccountsManaged // ManagedAccounts received
+)
+
+func (k AccountEventKind) String() string {
+	switch k {
+	case AccountValueChanged:
+		return "ValueChanged"
+	case AccountPortfolioChanged:
+		return "PortfolioChanged"
+	case AccountPositionChanged:
+		return "PositionChanged"
+	case AccountDownloaded:
+		return "Downloaded"
+	case AccountsManaged:
+		return "Managed"
+	default:
+		return "unknown account event kind"
+	}
+}
+
+// AccountEvent is a change of an account.
+type AccountEvent struct {
+	Kind      AccountEventKind
+	Account   string
+	ModelCode string
+	Key       AccountValueKey // for AccountValueChanged
+	Value     AccountValue    // for AccountValueChanged
+	ConID     int64           // for AccountPortfolioChanged and AccountPositionChanged
+}
+
+type accountKey struct {
+	account   string
+	modelCode string
+}
+
+// AccountCache maintains the accounts from the account updates, account summaries, positions and their multi variants.
+// It only caches what the client subscribed to: ReqAccountUpdates, ReqAccountSummary, ReqPositions,
+// ReqAccountUpdatesMulti or ReqPositionsMulti.
+type AccountCache struct {
+	mu       sync.Mutex
+	accounts map[accountKey]*AccountState
+	managed  []string
+	current  string // account of ReqAccountUpdates, UpdateAccountTime does not tell it
+
+	observers observerList[AccountEvent]
+}
+
+func newAccountCache() *AccountCache {
+	return &AccountCache{accounts: make(map[accountKey]*AccountState)}
+}
+
+// SetAccountTracking enables or disables the account cache.
+// It must be called before Connect.
+func (c *EClient) SetAccountTracking(enabled bool) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	switch {
+	case enabled && c.accounts == nil:
+		c.accounts = newAccountCache()
+	case !enabled:
+		c.accounts = nil
+	}
+}
+
+// Accounts returns the account cache, nil when disabled.
+func (c *EClient) Accounts() *AccountCache {
+	return c.accounts
+}
+
+// AddObserver registers fn to be called on every change of an account, in order.
+// fn runs synchronously in the decoder goroutine: it must return quickly.
+// The returned function unregisters fn.
+func (ac *AccountCache) AddObserver(fn func(AccountEvent)) (remove func()) {
+	return ac.observers.add(fn)
+}
+
+// ManagedAccounts returns the accounts reported by ManagedAccounts: the account, or the advisor's sub-accounts.
+func (ac *AccountCache) ManagedAccounts() []string {
+	if ac == nil {
+		return nil
+	}
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return slices.Clone(ac.managed)
+}
+
+// Account returns the account-level state of account.
+func (ac *AccountCache) Account(account string) (AccountState, bool) {
+	return ac.Model(account, "")
+}
+
+// Model returns the state of a model of account.
+func (ac *AccountCache) Model(account string, modelCode string) (AccountState, bool) {
+	if ac == nil {
+		return AccountState{}, false
+	}
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	if a := ac.accounts[accountKey{account, modelCode}]; a != nil {
+		return a.clone(), true
+	}
+	return AccountState{}, false
+}
+
+// AllAccounts returns every account and model, ordered by account then model code.
+func (ac *AccountCache) AllAccounts() []AccountState {
+	if ac == nil {
+		return nil
+	}
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	accounts := make([]AccountState, 0, len(ac.accounts))
+	for _, a := range ac.accounts {
+		accounts = append(accounts, a.clone())
+	}
+	slices.SortFunc(accounts, func(a, b AccountState) int {
+		return cmp.Or(cmp.Compare(a.Account, b.Account), cmp.Compare(a.ModelCode, b.ModelCode))
+	})
+	return accounts
+}
+
+// get finds or creates an account. ac.mu must be held.
+func (ac *AccountCache) get(account string, modelCode string) *AccountState {
+	key := accountKey{account, modelCode}
+	a := ac.accounts[key]
+	if a == nil {
+		a = &AccountState{
+			Account:   account,
+			ModelCode: modelCode,
+			Values:    make(map[AccountValueKey]AccountValue),
+			Portfolio: make(map[int64]PortfolioItem),
+			Positions: make(map[int64]Position),
+		}
+		ac.accounts[key] = a
+	}
+	a.Updated = time.Now()
+	return a
+}
+
+func (ac *AccountCache) setValue(account string, modelCode string, tag string, value string, currency string) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	a := ac.get(account, modelCode)
+	key := AccountValueKey{tag, currency}
+	v := AccountValue{Value: value, Updated: a.Updated}
+	changed := a.Values[key].Value != value
+	a.Values[key] = v
+	ac.mu.Unlock()
+	if changed {
+		ac.observers.notify(AccountEvent{Kind: AccountValueChanged, Account: account, ModelCode: modelCode, Key: key, Value: v})
+	}
+}
+
+// updateAccountValue feeds ReqAccountUpdates, the values of the other requests go through setValue directly.
+func (ac *AccountCache) updateAccountValue(tag string, value string, currency string, account string) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	ac.current = account
+	ac.mu.Unlock()
+	ac.setValue(account, "", tag, value, currency)
+}
+
+func (ac *AccountCache) updatePortfolio(contract *Contract, position Decimal, marketPrice float64, marketValue float64, averageCost float64, unrealizedPNL float64, realizedPNL float64, account string) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	a := ac.get(account, "")
+	ac.current = account
+	if position == ZERO {
+		delete(a.Portfolio, contract.ConID)
+	} else {
+		a.Portfolio[contract.ConID] = PortfolioItem{contract, position, marketPrice, marketValue, averageCost, unrealizedPNL, realizedPNL, a.Updated}
+	}
+	ac.mu.Unlock()
+	ac.observers.notify(AccountEvent{Kind: AccountPortfolioChanged, Account: account, ConID: contract.ConID})
+}
+
+func (ac *AccountCache) updateAccountTime(timeStamp string) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	if ac.current != "" {
+		ac.get(ac.current, "").UpdateTime = timeStamp
+	}
+	ac.mu.Unlock()
+}
+
+func (ac *AccountCache) accountDownloadEnd(account string) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	ac.get(account, "").Downloaded = true
+	ac.mu.Unlock()
+	ac.observers.notify(AccountEvent{Kind: AccountDownloaded, Account: account})
+}
+
+func (ac *AccountCache) position(account string, modelCode string, contract *Contract, position Decimal, avgCost float64) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	a := ac.get(account, modelCode)
+	if position == ZERO {
+		delete(a.Positions, contract.ConID)
+	} else {
+		a.Positions[contract.ConID] = Position{account, contract, position, avgCost}
+	}
+	ac.mu.Unlock()
+	ac.observers.notify(AccountEvent{Kind: AccountPositionChanged, Account: account, ModelCode: modelCode, ConID: contract.ConID})
+}
+
+func (ac *AccountCache) managedAccounts(accounts []string) {
+	if ac == nil {
+		return
+	}
+	ac.mu.Lock()
+	ac.managed = slices.Clone(accounts)
+	for _, account := range accounts {
+		ac.get(account, "")
+	}
+	ac.mu.Unlock()
+	ac.observers.notify(AccountEvent{Kind: AccountsManaged})
+}
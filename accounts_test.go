@@ -0,0 +1,98 @@
+package ibapi
+
+import "testing"
+
+func TestAccountCache(t *testing.T) {
+	ib := NewEClient(nil)
+	ib.SetAccountTracking(true)
+	var events []AccountEventKind
+	ib.Accounts().AddObserver(func(ev AccountEvent) { events = append(events, ev.Kind) })
+
+	w := ib.wrapper
+	w.ManagedAccounts([]string{"DU1", "DU2"})
+	w.UpdateAccountValue("NetLiquidation", "100000.5", "USD", "DU1")
+	w.UpdateAccountValue("NetLiquidation", "100000.5", "USD", "DU1")
+	w.UpdateAccountValue("CashBalance", "5000", "EUR", "DU1")
+	w.UpdateAccountValue("CashBalance", "80000", "BASE", "DU1")
+	w.UpdateAccountValue("AccountType", "INDIVIDUAL", "", "DU1")
+	w.UpdatePortfolio(&Contract{ConID: 8314, Symbol: "IBM"}, StringToDecimal("100"), 150, 15000, 140, 1000, 0, "DU1")
+	w.UpdatePortfolio(&Contract{ConID: 265598, Symbol: "AAPL"}, StringToDecimal("10"), 200, 2000, 210, -100, 0, "DU1")
+	w.UpdatePortfolio(&Contract{ConID: 265598, Symbol: "AAPL"}, ZERO, 200, 0, 0, 0, -100, "DU1")
+	w.UpdateAccountTime("10:15")
+	w.AccountDownloadEnd("DU1")
+	w.AccountSummary(1, "DU2", "BuyingPower", "400000", "USD")
+	w.Position("DU2", &Contract{ConID: 8314}, StringToDecimal("-5"), 150)
+	w.AccountUpdateMulti(2, "DU1", "MODEL1", "ExcessLiquidity", "2500", "USD")
+
+	accounts := ib.Accounts()
+	if managed := accounts.ManagedAccounts(); len(managed) != 2 || managed[1] != "DU2" {
+		t.Errorf("unexpected managed accounts %v", managed)
+	}
+
+	du1, ok := accounts.Account("DU1")
+	if !ok {
+		t.Fatal("DU1 not found")
+	}
+	if nl, ok := du1.NetLiquidation(); !ok || nl != (Money{100000.5, "USD"}) {
+		t.Errorf("unexpected net liquidation %+v", nl)
+	}
+	if cash, ok := du1.Value("CashBalance", "BASE"); !ok || cash.Value != "80000" {
+		t.Errorf("unexpected cash balance %+v", cash)
+	}
+	if v, _ := du1.Value("AccountType", ""); v.Value != "INDIVIDUAL" {
+		t.Errorf("unexpected account type %+v", v)
+	}
+	if _, ok := du1.Value("AccountType", ""); !ok {
+		t.Error("non monetary value missing")
+	}
+	if len(du1.Portfolio) != 1 || du1.Portfolio[8314].MarketValue != 15000 || du1.UnrealizedPNL() != 1000 {
+		t.Errorf("unexpected portfolio %+v", du1.Portfolio)
+	}
+	if du1.UpdateTime != "10:15" || !du1.Downloaded {
+		t.Errorf("unexpected account %+v", du1)
+	}
+
+	du2, _ := accounts.Account("DU2")
+	if bp, ok := du2.BuyingPower(); !ok || bp.Amount != 400000 {
+		t.Errorf("unexpected buying power %+v", bp)
+	}
+	if pos := du2.Positions[8314]; pos.Position != StringToDecimal("-5") {
+		t.Errorf("unexpected positions %+v", du2.Positions)
+	}
+
+	model, ok := accounts.Model("DU1", "MODEL1")
+	if el, _ := model.ExcessLiquidity(); !ok || el.Amount != 2500 {
+		t.Errorf("unexpected model %+v", model)
+	}
+	if all := accounts.AllAccounts(); len(all) != 3 || all[1].ModelCode != "MODEL1" {
+		t.Errorf("unexpected accounts %+v", all)
+	}
+
+	// Snapshots are isolated from later updates
+	w.UpdateAccountValue("NetLiquidation", "1", "USD", "DU1")
+	if nl, _ := du1.NetLiquidation(); nl.Amount != 100000.5 {
+		t.Errorf("snapshot changed %+v", nl)
+	}
+
+	want := []AccountEventKind{
+		AccountsManaged, AccountValueChanged, AccountValueChanged, AccountValueChanged, AccountValueChanged,
+		AccountPortfolioChanged, AccountPortfolioChanged, AccountPortfolioChanged, AccountDownloaded,
+		AccountValueChanged, AccountPositionChanged, AccountValueChanged, AccountValueChanged,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got events %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("got events %v, want %v", events, want)
+		}
+	}
+}
+
+func TestAccountCacheDisabled(t *testing.T) {
+	ib := NewEClient(nil)
+	ib.wrapper.UpdateAccountValue("NetLiquidation", "1", "USD", "DU1")
+	if _, ok := ib.Accounts().Account("DU1"); ok {
+		t.Error("disabled cache holds an account")
+	}
+}
@@ -0,0 +1,197 @@
+package ibapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RejectKind classifies the reason of an advanced order reject.
+type RejectKind int
+
+const (
+	RejectUnknown    RejectKind = iota
+	RejectPrecaution            // breach of a precautionary setting of the order presets
+	RejectPriceCap              // price outside of the allowed price cap
+	RejectSizeLimit             // size or value above the allowed limit
+	RejectPermission            // missing trading permission
+)
+
+func (k RejectKind) String() string {
+	switch k {
+	case RejectPrecaution:
+		return "precaution"
+	case RejectPriceCap:
+		return "price cap"
+	case RejectSizeLimit:
+		return "size limit"
+	case RejectPermission:
+		return "permission"
+	default:
+		return "unknown"
+	}
+}
+
+// RejectReason is one reason of an advanced order reject.
+type RejectReason struct {
+	ID          string
+	Text        string
+	OverrideTag string // tag to list in Order.AdvancedErrorOverride to bypass the reason, empty if it cannot be overridden
+	Kind        RejectKind
+	Fields      map[string]any // the raw fields of the reason
+}
+
+// AdvancedOrderReject is the structured content of the advancedOrderRejectJson passed to EWrapper.Error.
+type AdvancedOrderReject struct {
+	Reasons []RejectReason
+	Raw     string
+}
+
+// rejectJSON is the schema of advancedOrderRejectJson: a list of reasons, or a single reason at the root.
+type rejectJSON struct {
+	RejectReasons []rejectReasonJSON `json:"rejectReasons"`
+	rejectReasonJSON
+}
+
+type rejectReasonJSON struct {
+	ID          rejectJSONString `json:"rejectReasonId"`
+	Text        string           `json:"rejectReasonText"`
+	OverrideTag rejectJSONString `json:"rejectReasonOverrideTag"`
+}
+
+// rejectJSONString is a reject field TWS sends either as a string or as a number.
+type rejectJSONString string
+
+func (s *rejectJSONString) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		*s = rejectJSONString(v)
+	case float64:
+		*s = rejectJSONString(strconv.FormatFloat(v, 'f', -1, 64))
+	case nil:
+		*s = ""
+	default:
+		return fmt.Errorf("unexpected %T", v)
+	}
+	return nil
+}
+
+// ParseAdvancedOrderReject parses the advancedOrderRejectJson passed to EWrapper.Error.
+// The JSON holds a list of reasons under rejectReasons, or a single reason at its root.
+// It returns nil without error for an empty string.
+func ParseAdvancedOrderReject(advancedOrderRejectJson string) (*AdvancedOrderReject, error) {
+	if strings.TrimSpace(advancedOrderRejectJson) == "" {
+		return nil, nil
+	}
+
+	var root rejectJSON
+	if err := json.Unmarshal([]byte(advancedOrderRejectJson), &root); err != nil {
+		return nil, fmt.Errorf("invalid advancedOrderRejectJson: %w", err)
+	}
+	// The raw fields of each reason, for the ones the schema does not name
+	var raw struct {
+		RejectReasons []map[string]any `json:"rejectReasons"`
+	}
+	var rootFields map[string]any
+	_ = json.Unmarshal([]byte(advancedOrderRejectJson), &raw)
+	_ = json.Unmarshal([]byte(advancedOrderRejectJson), &rootFields)
+
+	reasons, fields := root.RejectReasons, raw.RejectReasons
+	if len(reasons) == 0 && (root.ID != "" || root.Text != "") {
+		reasons, fields = []rejectReasonJSON{root.rejectReasonJSON}, []map[string]any{rootFields}
+	}
+
+	reject := &AdvancedOrderReject{Raw: advancedOrderRejectJson}
+	for i, r := range reasons {
+		reason := RejectReason{
+			ID:          string(r.ID),
+			Text:        r.Text,
+			OverrideTag: string(r.OverrideTag),
+			Kind:        rejectKind(r.Text),
+		}
+		if i < len(fields) {
+			reason.Fields = fields[i]
+		}
+		reject.Reasons = append(reject.Reasons, reason)
+	}
+	return reject, nil
+}
+
+// rejectKindKeywords classifies the reasons from their text, the first kind with a keyword in the text wins.
+// A precaution names the checked quantity, e.g. "size" or "price": it comes first.
+var rejectKindKeywords = []struct {
+	kind     RejectKind
+	keywords []string
+}{
+	{RejectPrecaution, []string{"precaution"}},
+	{RejectPermission, []string{"permission"}},
+	{RejectPriceCap, []string{"price cap", "price constraint", "percentage"}},
+	{RejectSizeLimit, []string{"size", "quantity", "value limit"}},
+}
+
+// rejectKind classifies a reason from its text.
+func rejectKind(text string) RejectKind {
+	text = strings.ToLower(text)
+	for _, k := range rejectKindKeywords {
+		if slices.ContainsFunc(k.keywords, func(keyword string) bool { return strings.Contains(text, keyword) }) {
+			return k.kind
+		}
+	}
+	return RejectUnknown
+}
+
+// HasKind tells whether one of the reasons is of the given kind.
+func (r *AdvancedOrderReject) HasKind(kind RejectKind) bool {
+	return slices.ContainsFunc(r.Reasons, func(reason RejectReason) bool { return reason.Kind == kind })
+}
+
+// OverrideTags returns the override tags of the reasons, without duplicates.
+func (r *AdvancedOrderReject) OverrideTags() []string {
+	var tags []string
+	for _, reason := range r.Reasons {
+		if reason.OverrideTag != "" && !slices.Contains(tags, reason.OverrideTag) {
+			tags = append(tags, reason.OverrideTag)
+		}
+	}
+	return tags
+}
+
+// Overridable tells whether every reason can be overridden, i.e. whether the order may be resubmitted with the override tags.
+func (r *AdvancedOrderReject) Overridable() bool {
+	if len(r.Reasons) == 0 {
+		return false
+	}
+	return !slices.ContainsFunc(r.Reasons, func(reason RejectReason) bool { return reason.OverrideTag == "" })
+}
+
+// WithOverrides returns a copy of order with the override tags of reject added to AdvancedErrorOverride.
+// The copy shares nothing mutable with order and keeps the order id, so that placing it modifies the rejected order.
+func WithOverrides(order *Order, reject *AdvancedOrderReject) *Order {
+	o := order.clone()
+	var tags []string
+	for tag := range strings.SplitSeq(o.AdvancedErrorOverride, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if reject != nil {
+		for _, tag := range reject.OverrideTags() {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	o.AdvancedErrorOverride = strings.Join(tags, ",")
+	return o
+}
+
+// AdvancedOrderReject parses the advancedOrderRejectJson of the error, nil if there is none.
+func (e *IBError) AdvancedOrderReject() (*AdvancedOrderReject, error) {
+	return ParseAdvancedOrderReject(e.AdvancedOrderRejectJson)
+}
@@ -0,0 +1,84 @@
+package ibapi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseAdvancedOrderReject(t *testing.T) {
+	json := `{"rejectReasons":[
+		{"rejectReasonId":"383","rejectReasonText":"The size specified violates the size constraint specified in the default order settings","rejectReasonOverrideTag":"8"},
+		{"rejectReasonId":"109","rejectReasonText":"Price is out of the range defined by the Percentage setting","rejectReasonOverrideTag":"9"},
+		{"rejectReasonId":"203","rejectReasonText":"No trading permissions for this security"}
+	]}`
+
+	reject, err := ParseAdvancedOrderReject(json)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(reject.Reasons) != 3 {
+		t.Fatalf("expected 3 reasons, got %d", len(reject.Reasons))
+	}
+	kinds := []RejectKind{RejectSizeLimit, RejectPriceCap, RejectPermission}
+	for i, kind := range kinds {
+		if reject.Reasons[i].Kind != kind {
+			t.Errorf("reason %d: expected %v, got %v", i, kind, reject.Reasons[i].Kind)
+		}
+	}
+	if reject.Reasons[0].ID != "383" {
+		t.Errorf("unexpected reason id %q", reject.Reasons[0].ID)
+	}
+	if tags := reject.OverrideTags(); !slices.Equal(tags, []string{"8", "9"}) {
+		t.Errorf("unexpected override tags %v", tags)
+	}
+	if reject.Overridable() {
+		t.Error("a permission reject cannot be overridden")
+	}
+
+	order := NewOrder()
+	order.OrderID = 12
+	order.AdvancedErrorOverride = "9"
+	resubmit := WithOverrides(order, reject)
+	if resubmit.AdvancedErrorOverride != "9,8" || resubmit.OrderID != 12 {
+		t.Errorf("unexpected resubmitted order %d %q", resubmit.OrderID, resubmit.AdvancedErrorOverride)
+	}
+	if order.AdvancedErrorOverride != "9" {
+		t.Error("the original order was modified")
+	}
+}
+
+func TestParseAdvancedOrderRejectSingle(t *testing.T) {
+	reject, err := NewIBError(5, 0, 201, "Order rejected", `{"rejectReasonId":10,"rejectReasonText":"Order size exceeds the precautionary setting","rejectReasonOverrideTag":10}`).AdvancedOrderReject()
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(reject.Reasons) != 1 || reject.Reasons[0].ID != "10" || !reject.HasKind(RejectPrecaution) || !reject.Overridable() || reject.OverrideTags()[0] != "10" {
+		t.Errorf("unexpected reject %+v", reject)
+	}
+
+	if reject, err := ParseAdvancedOrderReject(""); reject != nil || err != nil {
+		t.Errorf("expected nothing for an empty json, got %v %v", reject, err)
+	}
+	if _, err := ParseAdvancedOrderReject("{"); err == nil {
+		t.Error("expected an error for an invalid json")
+	}
+	// Fields outside of the schema do not make reasons
+	if reject, err := ParseAdvancedOrderReject(`{"code":1,"msg":"Order size is too large"}`); err != nil || len(reject.Reasons) != 0 {
+		t.Errorf("unexpected reject %+v %v", reject, err)
+	}
+}
+
+func TestWithOverridesCopiesOrder(t *testing.T) {
+	order := NewOrder()
+	order.AlgoParams = []TagValue{{Tag: "maxPctVol", Value: "0.1"}}
+	order.OrderMiscOptions = []TagValue{{Tag: "a", Value: "b"}}
+	order.Conditions = []OrderCondition{NewPriceCondition(756733, "SMART", 100, LastTriggerMethod, false, true)}
+
+	resubmit := WithOverrides(order, &AdvancedOrderReject{Reasons: []RejectReason{{OverrideTag: "8"}}})
+	resubmit.AlgoParams[0].Value = "0.2"
+	resubmit.OrderMiscOptions[0].Value = "c"
+	resubmit.Conditions[0].(*PriceCondition).Price = 10
+	if order.AlgoParams[0].Value != "0.1" || order.OrderMiscOptions[0].Value != "b" || order.Conditions[0].(*PriceCondition).Price == 10 {
+		t.Error("the original order shares its fields with the copy")
+	}
+}
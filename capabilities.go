@@ -0,0 +1,211 @@
+package ibapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Feature is a server capability gated by a minimum server version.
+type Feature int
+
+const (
+	FeatureFractionalPositions Feature = iota
+	FeatureSecDefOptParams
+	FeatureMatchingSymbols
+	FeatureHeadTimestamp
+	FeaturePnL
+	FeatureHistoricalTicks
+	FeatureTickByTick
+	FeatureCompletedOrders
+	FeatureWshCalendar
+	FeatureFractionalSize
+	FeatureHistoricalSchedule
+	FeatureAdvancedOrderReject
+	FeatureUserInfo
+	FeaturePegBestPegMidOffsets
+	FeatureWshEventDataFilters
+	FeatureWshEventDataFiltersDate
+	FeatureIncludeOvernight
+	FeatureCMETaggingFields
+	FeatureErrorTime
+	FeatureFullOrderPreviewFields
+	FeatureHistoricalDataEnd
+	FeatureCurrentTimeInMillis
+	FeatureSubmitter
+	FeatureImbalanceOnly
+	FeatureParametrizedDaysOfExecutions
+	FeatureProtoBuf
+	FeatureZeroStrikePrice
+	FeatureProtoBufPlaceOrder
+	FeatureProtoBufCompletedOrder
+	FeatureProtoBufContractData
+	FeatureProtoBufMarketData
+	FeatureProtoBufAccountsPositions
+	FeatureProtoBufHistoricalData
+	FeatureCancelContractData
+	FeatureAdditionalOrderParams1
+	FeatureAdditionalOrderParams2
+	FeatureAttachedOrders
+	FeatureConfig
+	FeatureUpdateConfig
+	FeatureHedgeMaxSize
+	FeatureOddLotBidAskQuotes
+	numFeatures
+)
+
+var features = [numFeatures]struct {
+	name       string
+	minVersion Version
+}{
+	FeatureFractionalPositions:          {"fractional positions", MIN_SERVER_VER_FRACTIONAL_POSITIONS},
+	FeatureSecDefOptParams:              {"option chain parameters", MIN_SERVER_VER_SEC_DEF_OPT_PARAMS_REQ},
+	FeatureMatchingSymbols:              {"matching symbols", MIN_SERVER_VER_REQ_MATCHING_SYMBOLS},
+	FeatureHeadTimestamp:                {"head timestamp", MIN_SERVER_VER_REQ_HEAD_TIMESTAMP},
+	FeaturePnL:                          {"PnL", MIN_SERVER_VER_PNL},
+	FeatureHistoricalTicks:              {"historical ticks", MIN_SERVER_VER_HISTORICAL_TICKS},
+	FeatureTickByTick:                   {"tick by tick data", MIN_SERVER_VER_TICK_BY_TICK},
+	FeatureCompletedOrders:              {"completed orders", MIN_SERVER_VER_COMPLETED_ORDERS},
+	FeatureWshCalendar:                  {"WSH calendar", MIN_SERVER_VER_WSHE_CALENDAR},
+	FeatureFractionalSize:               {"fractional sizes", MIN_SERVER_VER_FRACTIONAL_SIZE_SUPPORT},
+	FeatureHistoricalSchedule:           {"historical schedule", MIN_SERVER_VER_HISTORICAL_SCHEDULE},
+	FeatureAdvancedOrderReject:          {"advanced order reject", MIN_SERVER_VER_ADVANCED_ORDER_REJECT},
+	FeatureUserInfo:                     {"user info", MIN_SERVER_VER_USER_INFO},
+	FeaturePegBestPegMidOffsets:         {"PEG BEST / PEG MID offsets", MIN_SERVER_VER_PEGBEST_PEGMID_OFFSETS},
+	FeatureWshEventDataFilters:          {"WSH event data filters", MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS},
+	FeatureWshEventDataFiltersDate:      {"WSH event data date filters", MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS_DATE},
+	FeatureIncludeOvernight:             {"include overnight", MIN_SERVER_VER_INCLUDE_OVERNIGHT},
+	FeatureCMETaggingFields:             {"CME tagging fields", MIN_SERVER_VER_CME_TAGGING_FIELDS},
+	FeatureErrorTime:                    {"error time", MIN_SERVER_VER_ERROR_TIME},
+	FeatureFullOrderPreviewFields:       {"full order preview fields", MIN_SERVER_VER_FULL_ORDER_PREVIEW_FIELDS},
+	FeatureHistoricalDataEnd:            {"historical data end", MIN_SERVER_VER_HISTORICAL_DATA_END},
+	FeatureCurrentTimeInMillis:          {"current time in millis", MIN_SERVER_VER_CURRENT_TIME_IN_MILLIS},
+	FeatureSubmitter:                    {"submitter", MIN_SERVER_VER_SUBMITTER},
+	FeatureImbalanceOnly:                {"imbalance only", MIN_SERVER_VER_IMBALANCE_ONLY},
+	FeatureParametrizedDaysOfExecutions: {"parametrized days of executions", MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS},
+	FeatureProtoBuf:                     {"protobuf", MIN_SERVER_VER_PROTOBUF},
+	FeatureZeroStrikePrice:              {"zero strike price", MIN_SERVER_VER_ZERO_STRIKE_PRICE},
+	FeatureProtoBufPlaceOrder:           {"protobuf place order", MIN_SERVER_VER_PROTOBUF_PLACE_ORDER},
+	FeatureProtoBufCompletedOrder:       {"protobuf completed order", MIN_SERVER_VER_PROTOBUF_COMPLETED_ORDER},
+	FeatureProtoBufContractData:         {"protobuf contract data", MIN_SERVER_VER_PROTOBUF_CONTRACT_DATA},
+	FeatureProtoBufMarketData:           {"protobuf market data", MIN_SERVER_VER_PROTOBUF_MARKET_DATA},
+	FeatureProtoBufAccountsPositions:    {"protobuf accounts and positions", MIN_SERVER_VER_PROTOBUF_ACCOUNTS_POSITIONS},
+	FeatureProtoBufHistoricalData:       {"protobuf historical data", MIN_SERVER_VER_PROTOBUF_HISTORICAL_DATA},
+	FeatureCancelContractData:           {"cancel contract data", MIN_SERVER_VER_CANCEL_CONTRACT_DATA},
+	FeatureAdditionalOrderParams1:       {"additional order parameters 1", MIN_SERVER_VER_ADDITIONAL_ORDER_PARAMS_1},
+	FeatureAdditionalOrderParams2:       {"additional order parameters 2", MIN_SERVER_VER_ADDITIONAL_ORDER_PARAMS_2},
+	FeatureAttachedOrders:               {"attached orders", MIN_SERVER_VER_ATTACHED_ORDERS},
+	FeatureConfig:                       {"config requests", MIN_SERVER_VER_CONFIG},
+	FeatureUpdateConfig:                 {"update config requests", MIN_SERVER_VER_UPDATE_CONFIG},
+	FeatureHedgeMaxSize:                 {"hedge max size", MIN_SERVER_VER_HEDGE_MAX_SIZE},
+	FeatureOddLotBidAskQuotes:           {"odd lot bid/ask quotes", MIN_SERVER_VER_ODD_LOT_BID_ASK_QUOTES},
+}
+
+func (f Feature) String() string {
+	if f < 0 || f >= numFeatures {
+		return fmt.Sprintf("feature(%d)", int(f))
+	}
+	return features[f].name
+}
+
+// MinServerVersion returns the server version introducing the feature.
+func (f Feature) MinServerVersion() Version {
+	if f < 0 || f >= numFeatures {
+		return MAX_CLIENT_VER + 1
+	}
+	return features[f].minVersion
+}
+
+// Capabilities is the feature set of a server, computed from its version.
+type Capabilities struct {
+	ServerVersion Version
+}
+
+// Capabilities returns the capabilities of the connected server.
+// They are only meaningful once connected.
+func (c *EClient) Capabilities() Capabilities {
+	return Capabilities{ServerVersion: c.ServerVersion()}
+}
+
+// Has tells whether the server supports the feature.
+func (c Capabilities) Has(f Feature) bool {
+	return c.ServerVersion >= f.MinServerVersion()
+}
+
+// Features returns the supported features.
+func (c Capabilities) Features() []Feature {
+	var fs []Feature
+	for f := range numFeatures {
+		if c.Has(f) {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
+// Missing returns the features not supported by the server.
+func (c Capabilities) Missing(fs ...Feature) []Feature {
+	var missing []Feature
+	for _, f := range fs {
+		if !c.Has(f) {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// Require returns an UPDATE_TWS error listing the features not supported by the server, nil if all are.
+func (c Capabilities) Require(fs ...Feature) error {
+	missing := c.Missing(fs...)
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, len(missing))
+	for i, f := range missing {
+		names[i] = fmt.Sprintf("%s (%d)", f, f.MinServerVersion())
+	}
+	return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code,
+		fmt.Sprintf("%s Server version %d does not support: %s.", UPDATE_TWS.Msg, c.ServerVersion, strings.Join(names, ", ")), "")
+}
+
+// ProtoBuf tells whether the outgoing message travels as protobuf.
+func (c Capabilities) ProtoBuf(msgID OUT) bool {
+	version, exists := PROTOBUF_MSG_IDS[msgID]
+	return exists && version <= c.ServerVersion
+}
+
+// ProtoBufMessages returns the outgoing messages travelling as protobuf, sorted by id.
+func (c Capabilities) ProtoBufMessages() []OUT {
+	var msgIDs []OUT
+	for _, msgID := range slices.Sorted(maps.Keys(PROTOBUF_MSG_IDS)) {
+		if c.ProtoBuf(msgID) {
+			msgIDs = append(msgIDs, msgID)
+		}
+	}
+	return msgIDs
+}
+
+// Report returns a human-readable report of the capabilities.
+func (c Capabilities) Report() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Server version %d (client supports %d to %d)\n", c.ServerVersion, MIN_SERVER_VER_SUPPORTED, MAX_CLIENT_VER)
+	sb.WriteString("Features:\n")
+	for f := range numFeatures {
+		mark := " "
+		if c.Has(f) {
+			mark = "x"
+		}
+		fmt.Fprintf(&sb, "  [%s] %s (%d)\n", mark, f, f.MinServerVersion())
+	}
+	sb.WriteString("ProtoBuf messages:")
+	msgIDs := c.ProtoBufMessages()
+	if len(msgIDs) == 0 {
+		sb.WriteString(" none")
+	}
+	for _, msgID := range msgIDs {
+		fmt.Fprintf(&sb, "\n  %s", outMsgNames[msgID])
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
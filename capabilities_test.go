@@ -0,0 +1,57 @@
+package ibapi
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	caps := Capabilities{ServerVersion: MIN_SERVER_VER_PROTOBUF_PLACE_ORDER}
+
+	if !caps.Has(FeatureIncludeOvernight) || !caps.Has(FeatureProtoBufPlaceOrder) {
+		t.Error("expected include overnight and protobuf place order")
+	}
+	if caps.Has(FeatureConfig) {
+		t.Error("config requests need a newer server")
+	}
+	if missing := caps.Missing(FeatureWshEventDataFilters, FeatureConfig, FeatureAttachedOrders); !slices.Equal(missing, []Feature{FeatureConfig, FeatureAttachedOrders}) {
+		t.Errorf("unexpected missing features %v", missing)
+	}
+
+	if err := caps.Require(FeatureIncludeOvernight); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	err := caps.Require(FeatureIncludeOvernight, FeatureConfig)
+	if !errors.Is(err, UPDATE_TWS) || !strings.Contains(err.Error(), "config requests (219)") {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if !caps.ProtoBuf(PLACE_ORDER) || !caps.ProtoBuf(REQ_EXECUTIONS) || caps.ProtoBuf(REQ_MKT_DATA) {
+		t.Error("unexpected protobuf messages")
+	}
+	if msgIDs := caps.ProtoBufMessages(); !slices.Equal(msgIDs, []OUT{PLACE_ORDER, CANCEL_ORDER, REQ_EXECUTIONS, REQ_GLOBAL_CANCEL}) {
+		t.Errorf("unexpected protobuf messages %v", msgIDs)
+	}
+
+	report := caps.Report()
+	for _, want := range []string{"Server version 203", "[x] include overnight (189)", "[ ] config requests (219)", "\n  PLACE_ORDER"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report misses %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestCapabilitiesFeatureTable(t *testing.T) {
+	for f := range numFeatures {
+		if f.String() == "" || f.MinServerVersion() < MIN_SERVER_VER_SUPPORTED || f.MinServerVersion() > MAX_CLIENT_VER {
+			t.Errorf("feature %d is not described", f)
+		}
+	}
+	for msgID := range PROTOBUF_MSG_IDS {
+		if outMsgNames[msgID] == "" {
+			t.Errorf("message %d has no name", msgID)
+		}
+	}
+}
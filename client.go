@@ -16,12 +16,15 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"os/signal"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/scmhub/ibapi/protobuf"
 	"google.golang.org/protobuf/proto"
@@ -33,6 +36,9 @@ const (
 	DISCONNECTED ConnState = iota
 	CONNECTING
 	CONNECTED
+	RECONNECTING
+	FAILING_OVER
+	CONNECTIVITY_LOST
 )
 
 func (cs ConnState) String() string {
@@ -43,6 +49,12 @@ func (cs ConnState) String() string {
 		return "connecting"
 	case CONNECTED:
 		return "connected"
+	case RECONNECTING:
+		return "reconnecting"
+	case FAILING_OVER:
+		return "failing over"
+	case CONNECTIVITY_LOST:
+		return "connectivity lost"
 	default:
 		return "unknown connection state"
 	}
@@ -68,7 +80,7 @@ func NewMsgEncoder(nFields int, eClient *EClient) *MsgEncoder {
 
 // encodeMsgID encodes a message ID with appropriate format based on server version
 func (me *MsgEncoder) encodeMsgID(msgID int64) *MsgEncoder {
-	if me.eClient.serverVersion >= MIN_SERVER_VER_PROTOBUF {
+	if me.eClient.ServerVersion() >= MIN_SERVER_VER_PROTOBUF {
 		// Encode as raw int (4 bytes, byte-swapped)
 		me.encodeRawInt64(msgID)
 	} else {
@@ -248,6 +260,16 @@ func (me *MsgEncoder) encodeFloatMax(v float64) *MsgEncoder {
 
 // Bytes finalizes the message by writing the size header and returning the complete message
 func (me *MsgEncoder) Bytes() []byte {
+	result, err := me.frame()
+	if err != nil {
+		me.eClient.wrapper.Error(NO_VALID_ID, currentTimeMillis(), BAD_LENGTH.Code, BAD_LENGTH.Msg, "")
+		return nil
+	}
+	return result
+}
+
+// frame returns the framed message, or an error if it exceeds MAX_MSG_LEN.
+func (me *MsgEncoder) frame() ([]byte, error) {
 	// Get the final buffer bytes
 	result := me.buf.Bytes()
 
@@ -256,14 +278,13 @@ func (me *MsgEncoder) Bytes() []byte {
 
 	if msgSize > MAX_MSG_LEN {
 		log.Error().Int("msgSize", msgSize).Msg("Message size exceeds maximum allowed size")
-		me.eClient.wrapper.Error(NO_VALID_ID, currentTimeMillis(), BAD_LENGTH.Code, BAD_LENGTH.Msg, "")
-		return nil
+		return nil, NewIBError(NO_VALID_ID, currentTimeMillis(), BAD_LENGTH.Code, BAD_LENGTH.Msg, "")
 	}
 
 	// Write the size back into the header
 	binary.BigEndian.PutUint32(result[:4], uint32(msgSize))
 
-	return result
+	return result, nil
 }
 
 // Reset resets the buffer for reuse while maintaining its capacity
@@ -293,8 +314,31 @@ type EClient struct {
 	cancel               context.CancelFunc
 	extraAuth            bool
 	wg                   sync.WaitGroup
-	watchOnce            sync.Once
 	err                  error
+	reconnectPolicy      *ReconnectPolicy
+	reconnectAbort       context.Context
+	abortReconnectFunc   context.CancelFunc
+	reconnectDone        chan struct{}
+	disconnectMu         sync.Mutex   // serializes the session teardowns of the user and of the supervisor
+	sessionMu            sync.RWMutex // guards the per-session state swapped on every connect, session rebuild and failback
+	ready                *readiness
+	dialer               Dialer
+	failover             *FailoverPolicy
+	watchdog             *watchdog
+	sessionErr           atomic.Pointer[error]
+	observers            observerList[ConnStateChange]
+	strictDecoding       bool
+	decodeErrorHandler   func(*DecodeError)
+	endpointIndex        int
+	subscriptions        *subscriptionRegistry
+	calls                *pendingCalls
+	streams              *streamRegistry
+	streamConfig         StreamConfig
+	orderIDs             *OrderIDs
+	trades               *TradeTracker
+	accounts             *AccountCache
+	pacer                *pacer
+	historicalPacer      *historicalPacer
 }
 
 // NewEClient returns a new Eclient.
@@ -302,7 +346,13 @@ func NewEClient(wrapper EWrapper) *EClient {
 	if wrapper == nil {
 		wrapper = &Wrapper{}
 	}
-	c := &EClient{wrapper: wrapper}
+	c := &EClient{}
+	c.wrapper = &clientWrapper{EWrapper: wrapper, c: c}
+	c.calls = newPendingCalls()
+	c.streams = newStreamRegistry()
+	c.streamConfig = *DefaultStreamConfig()
+	c.orderIDs = newOrderIDs()
+	c.reqChan = make(chan []byte, 10)
 	c.reset()
 
 	return c
@@ -310,13 +360,32 @@ func NewEClient(wrapper EWrapper) *EClient {
 
 func (c *EClient) reset() {
 
-	c.host = ""
-	c.port = -1
-	c.clientID = -1
+	c.setEndpoint(Endpoint{Host: "", Port: -1, ClientID: -1}, -1)
 	c.connectOptions = ""
 	c.optionalCapabilities = ""
 	c.extraAuth = false
-	c.conn = &Connection{wrapper: c.wrapper}
+
+	c.initSession()
+
+	c.subscriptions.clear()
+
+	c.dropRequests()
+
+	c.err = nil
+
+	c.setConnState(DISCONNECTED, CauseDisconnectRequested, nil)
+	c.connectOptions = ""
+}
+
+// initSession prepares a fresh connection, buffers and context for a new session with TWS/IBGW.
+func (c *EClient) initSession() {
+	conn := &Connection{wrapper: c.wrapper, dialer: c.dialer, sessionManaged: c.reconnectPolicy != nil}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	c.conn = conn
 	c.serverVersion = -1
 	c.connTime = ""
 
@@ -327,23 +396,64 @@ func (c *EClient) reset() {
 	c.scanner.Split(scanFields)
 	c.scanner.Buffer(make([]byte, 4096), MAX_MSG_LEN)
 
-	c.reqChan = make(chan []byte, 10)
-
-	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.ctx, c.cancel = ctx, cancel
+	c.sessionErr.Store(nil)
 
 	c.wg = sync.WaitGroup{}
-	c.err = nil
 
-	c.watchOnce = sync.Once{}
+	c.ready = newReadiness()
+}
+
+// setEndpoint records the endpoint of the session, index is its position in the failover policy.
+func (c *EClient) setEndpoint(ep Endpoint, index int) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.host, c.port, c.clientID = ep.Host, ep.Port, ep.ClientID
+	c.endpointIndex = index
+}
 
-	c.setConnState(DISCONNECTED)
-	c.connectOptions = ""
+// setServerInfo records the server version and the connection time answered to the handshake.
+func (c *EClient) setServerInfo(serverVersion Version, connTime string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	c.serverVersion, c.connTime = serverVersion, connTime
+}
+
+// session returns the connection and the readiness of the current session, with the context ending with it.
+func (c *EClient) session() (*Connection, *readiness, context.Context) {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+	return c.conn, c.ready, c.ctx
+}
+
+// dropRequests discards the requests not sent yet.
+func (c *EClient) dropRequests() {
+	for {
+		select {
+		case <-c.reqChan:
+		default:
+			return
+		}
+	}
+}
+
+func (c *EClient) setConnState(state ConnState, cause ConnStateCause, err error) {
+	cs := ConnState(atomic.SwapInt32(&c.connState, int32(state)))
+	c.stateChanged(cs, state, cause, err)
+}
+
+// compareAndSetConnState moves the connection state from one value to another only if it still holds the expected value.
+func (c *EClient) compareAndSetConnState(from, to ConnState, cause ConnStateCause, err error) bool {
+	if !atomic.CompareAndSwapInt32(&c.connState, int32(from), int32(to)) {
+		return false
+	}
+	c.stateChanged(from, to, cause, err)
+	return true
 }
 
-func (c *EClient) setConnState(state ConnState) {
-	cs := ConnState(atomic.LoadInt32(&c.connState))
-	atomic.StoreInt32(&c.connState, int32(state))
-	log.Debug().Stringer("from", cs).Stringer("to", state).Msg("connection state changed")
+// leaveConnected moves the connection state from CONNECTED or CONNECTIVITY_LOST to another value.
+func (c *EClient) leaveConnected(to ConnState, cause ConnStateCause, err error) bool {
+	return c.compareAndSetConnState(CONNECTED, to, cause, err) || c.compareAndSetConnState(CONNECTIVITY_LOST, to, cause, err)
 }
 
 // request is a goroutine that will get the req from reqChan and send it to TWS.
@@ -351,41 +461,77 @@ func (c *EClient) request() {
 	log.Debug().Msg("requester started")
 	defer log.Debug().Msg("requester ended")
 
-	c.wg.Add(1)
 	defer c.wg.Done()
 
+	if c.pacer != nil {
+		c.pacedRequest()
+		return
+	}
+
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
 		case req := <-c.reqChan:
-			log.Trace().Bytes("req", req).Msg("sending request")
-			if !c.IsConnected() {
-				c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-				c.cancel()
-				return
-			}
-			nn, err := c.writer.Write(req)
-			if err != nil {
-				log.Error().Err(err).Int("nbytes", nn).Bytes("reqMsg", req).Msg("requester write error")
-				// Disconnect the client
-				log.Info().Msg("Disconnecting client due to write error.")
-				if disconnectErr := c.Disconnect(); disconnectErr != nil {
-					log.Error().Err(disconnectErr).Msg("Error during disconnect.")
-				}
-				c.cancel()
-				return
-			}
-			err = c.writer.Flush()
-			if err != nil {
-				log.Error().Err(err).Bytes("reqMsg", req).Msg("requester flush error")
-				c.cancel()
+			if !c.send(req) {
 				return
 			}
 		}
 	}
 }
 
+// send writes a request to TWS. It returns false when the session has to end.
+func (c *EClient) send(req []byte) bool {
+	log.Trace().Bytes("req", req).Msg("sending request")
+	if !c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
+		c.endSession(NOT_CONNECTED)
+		return false
+	}
+	nn, err := c.writer.Write(req)
+	if err != nil {
+		log.Error().Err(err).Int("nbytes", nn).Bytes("reqMsg", req).Msg("requester write error")
+		// End the session, the supervisor will disconnect or reconnect the client
+		log.Info().Msg("Ending session due to write error.")
+		c.endSession(err)
+		return false
+	}
+	err = c.writer.Flush()
+	if err != nil {
+		log.Error().Err(err).Bytes("reqMsg", req).Msg("requester flush error")
+		c.endSession(err)
+		return false
+	}
+	return true
+}
+
+// enqueue hands the request over to the requester, giving up when ctx is done first.
+func (c *EClient) enqueue(ctx context.Context, me *MsgEncoder) error {
+	req, err := me.frame()
+	if err != nil {
+		return err
+	}
+	select {
+	case c.reqChan <- req:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reportError reports the error of a request refused client side to EWrapper.Error.
+func (c *EClient) reportError(err error) {
+	if err == nil {
+		return
+	}
+	var ibErr *IBError
+	if !errors.As(err, &ibErr) {
+		log.Error().Err(err).Msg("request not sent")
+		return
+	}
+	c.wrapper.Error(ibErr.ReqID, ibErr.Time.UnixMilli(), ibErr.Code, ibErr.Msg, ibErr.AdvancedOrderRejectJson)
+}
+
 func (c *EClient) validateInvalidSymbols(host string) error {
 	if host != "" && !isASCIIPrintable(host) {
 		return errors.New(host)
@@ -400,10 +546,7 @@ func (c *EClient) validateInvalidSymbols(host string) error {
 }
 
 func (c *EClient) useProtoBuf(msgID int64) bool {
-	if version, exists := PROTOBUF_MSG_IDS[OUT(msgID)]; exists {
-		return version <= c.serverVersion
-	}
-	return false
+	return c.Capabilities().ProtoBuf(OUT(msgID))
 }
 
 // startAPI initiates the message exchange between the client application and the TWS/IB Gateway.
@@ -422,11 +565,11 @@ func (c *EClient) startAPI() error {
 
 	msg := makeField(VERSION) + makeField(c.clientID)
 
-	if c.serverVersion >= MIN_SERVER_VER_OPTIONAL_CAPABILITIES {
+	if c.ServerVersion() >= MIN_SERVER_VER_OPTIONAL_CAPABILITIES {
 		msg += makeField(c.optionalCapabilities)
 	}
 	var payload []byte
-	if c.serverVersion >= MIN_SERVER_VER_PROTOBUF {
+	if c.ServerVersion() >= MIN_SERVER_VER_PROTOBUF {
 		idBytes := make([]byte, 4)
 		binary.BigEndian.PutUint32(idBytes, uint32(START_API))
 		payload = append(idBytes, []byte(msg)...)
@@ -486,6 +629,36 @@ func (c *EClient) startApiProtoBuf(startApiRequestProto *protobuf.StartApiReques
 // There is no feedback for a successful connection, but a subsequent attempt to connect will return the message "Already connected.".
 // You should wait for the connection to be established and NextValidID to be returned before calling any other function. If you don't wait, you will get a broken pipe error.
 func (c *EClient) Connect(host string, port int, clientID int64) error {
+	return c.ConnectContext(context.Background(), host, port, clientID)
+}
+
+// ConnectContext is Connect bounded by ctx: the dial and the handshake give up as soon as ctx is done.
+// On failure the socket is closed and the client is left disconnected, ready for another attempt.
+// Use ConnectAndWait to also wait for the first NextValidID and ManagedAccounts.
+func (c *EClient) ConnectContext(ctx context.Context, host string, port int, clientID int64) error {
+	return c.connect(ctx, host, port, clientID, func() error {
+		return c.conn.connectContext(ctx, host, port)
+	})
+}
+
+// ConnectConn is ConnectContext over an already established connection to TWS/IBGW, e.g. one end of a net.Pipe.
+// The client takes ownership of conn and closes it on Disconnect.
+// As conn cannot be dialed again, a session-level reconnect requires a Dialer, see SetDialer.
+func (c *EClient) ConnectConn(ctx context.Context, conn net.Conn, clientID int64) error {
+	host, port := conn.RemoteAddr().String(), -1
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		host = h
+		port, _ = strconv.Atoi(p)
+	}
+	return c.connect(ctx, host, port, clientID, func() error {
+		c.conn.reset()
+		c.conn.attach(conn)
+		return nil
+	})
+}
+
+// connect opens the transport with open, then starts the session.
+func (c *EClient) connect(ctx context.Context, host string, port int, clientID int64, open func() error) error {
 
 	if c.IsConnected() {
 		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
@@ -497,24 +670,64 @@ func (c *EClient) Connect(host string, port int, clientID int64) error {
 		return err
 	}
 
-	c.host = host
-	c.port = port
-	c.clientID = clientID
+	ep := Endpoint{Host: host, Port: port, ClientID: clientID}
+	c.setEndpoint(ep, c.failover.index(ep))
 
-	c.setConnState(CONNECTING)
+	c.setConnState(CONNECTING, CauseConnectRequested, nil)
 
 	// Connecting to IB server
 	log.Info().Str("host", host).Int("port", port).Int64("clientID", clientID).Msg("Connecting to IB server")
-	if err := c.conn.connect(c.host, c.port); err != nil {
+	if err := open(); err != nil {
 		log.Error().Err(CONNECT_FAIL).Msg("Connection fail")
 		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), CONNECT_FAIL.Code, CONNECT_FAIL.Msg, "")
+		c.setConnState(DISCONNECTED, CauseConnectFailed, err)
 		c.reset()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		return CONNECT_FAIL
 	}
 
-	// HandShake with the TWS or GateWay to ensure the version,
-	log.Debug().Msg("Handshake with TWS or GateWay")
+	if err := c.startSessionContext(ctx); err != nil {
+		log.Error().Err(err).Msg("Handshake fail")
+		c.stopSession()
+		c.setConnState(DISCONNECTED, CauseConnectFailed, err)
+		c.reset()
+		return err
+	}
+
+	c.setConnState(CONNECTED, CauseSessionStarted, nil)
+	c.wrapper.ConnectAck()
+
+	// Launch the session supervisor, it lives until the client is disconnected
+	c.reconnectAbort, c.abortReconnectFunc = context.WithCancel(context.Background())
+	c.reconnectDone = make(chan struct{})
+	go c.supervise(c.ctx, c.reconnectAbort, c.reconnectDone)
 
+	log.Debug().Msg("IB Client Connected!")
+
+	return nil
+}
+
+// startSessionContext runs startSession on the dialed connection, ctx bounds the handshake.
+func (c *EClient) startSessionContext(ctx context.Context) error {
+	// Unblock the handshake reads and writes as soon as ctx is done
+	conn := c.conn
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.setDeadline(time.Unix(1, 0))
+	})
+
+	err := c.startSession()
+
+	if !stop() {
+		// ctx is done, the socket deadline may already be gone
+		return ctx.Err()
+	}
+	return err
+}
+
+// handshakeHeader returns the API prefix followed by the supported client versions and the connection options.
+func (c *EClient) handshakeHeader() []byte {
 	head := []byte("API\x00")
 
 	connectOptions := ""
@@ -531,9 +744,20 @@ func (c *EClient) Connect(host string, port int, clientID int64) error {
 	msg.Write(sizeofCV)
 	msg.Write(clientVersion)
 
-	log.Debug().Bytes("header", msg.Bytes()).Msg("Sending handshake header")
+	return msg.Bytes()
+}
+
+// startSession performs the handshake on a freshly dialed connection, then starts the reader, the requester and the API.
+func (c *EClient) startSession() error {
+
+	// HandShake with the TWS or GateWay to ensure the version,
+	log.Debug().Msg("Handshake with TWS or GateWay")
 
-	if _, err := c.writer.Write(msg.Bytes()); err != nil {
+	header := c.handshakeHeader()
+
+	log.Debug().Bytes("header", header).Msg("Sending handshake header")
+
+	if _, err := c.writer.Write(header); err != nil {
 		return err
 	}
 
@@ -545,52 +769,49 @@ func (c *EClient) Connect(host string, port int, clientID int64) error {
 
 	// scan once to get server info
 	if !c.scanner.Scan() {
-		return c.scanner.Err()
+		if err := c.scanner.Err(); err != nil {
+			return err
+		}
+		return io.ErrUnexpectedEOF
 	}
 
 	// Init server info
 	msgBytes := c.scanner.Bytes()
 	serverInfo := splitMsgBytes(msgBytes)
 	v, _ := strconv.Atoi(string(serverInfo[0]))
-	c.serverVersion = Version(v)
-	if c.serverVersion < MIN_SERVER_VER_SUPPORTED {
+	connTime := string(serverInfo[1])
+	c.setServerInfo(Version(v), connTime)
+	if Version(v) < MIN_SERVER_VER_SUPPORTED {
 		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UNSUPPORTED_VERSION.Code, UNSUPPORTED_VERSION.Msg, "")
 		return UNSUPPORTED_VERSION
 	}
 
-	c.connTime = string(serverInfo[1])
-	log.Info().Int("serverVersion", v).Str("connectionTime", c.connTime).Msg("Handshake completed")
+	log.Info().Int("serverVersion", v).Str("connectionTime", connTime).Msg("Handshake completed")
+
+	return c.startServices()
+}
+
+// startServices starts the decoder, the reader, the requester and the API of a session past the handshake.
+func (c *EClient) startServices() error {
 
 	// init decoder
-	c.decoder = &EDecoder{wrapper: c.wrapper, serverVersion: c.serverVersion}
+	c.decoder = &EDecoder{wrapper: c.wrapper, serverVersion: c.ServerVersion(), strict: c.strictDecoding, onError: c.decodeErrorHandler}
 
 	//start Ereader
-	go EReader(c.ctx, c.cancel, c.scanner, c.decoder, &c.wg)
+	EReader(c.ctx, c.cancel, c.scanner, c.decoder, &c.wg)
 
 	// start requester
+	c.wg.Add(1)
 	go c.request()
 
-	// startAPI
-	if err := c.startAPI(); err != nil {
-		return err
+	// start watchdog
+	if c.watchdog != nil {
+		c.wg.Add(1)
+		go c.watchdog.run(c.ctx, c)
 	}
 
-	c.setConnState(CONNECTED)
-	c.wrapper.ConnectAck()
-
-	// 4) Launch the shutdown watcher exactly once
-	c.watchOnce.Do(func() {
-		go func() {
-			<-c.ctx.Done() // waits for c.cancel()
-			if err := c.Disconnect(); err != nil {
-				log.Error().Err(err).Msg("Disconnect error in watcher")
-			}
-		}()
-	})
-
-	log.Debug().Msg("IB Client Connected!")
-
-	return nil
+	// startAPI
+	return c.startAPI()
 }
 
 // ConnectWithGracefulShutdown connects and sets up signal handling for graceful shutdown.
@@ -614,12 +835,28 @@ func (c *EClient) ConnectWithGracefulShutdown(host string, port int, clientID in
 // Disconnect terminates the connections with TWS.
 // Calling this function does not cancel orders that have already been sent.
 func (c *EClient) Disconnect() error {
-	if !c.IsConnected() {
+	return c.disconnect(CauseDisconnectRequested, nil)
+}
+
+// disconnect terminates the connections with TWS, cause and err are reported to the connection state observers.
+func (c *EClient) disconnect(cause ConnStateCause, err error) error {
+	// Abort a session rebuild in progress, the supervisor cleans up after itself
+	if c.compareAndSetConnState(RECONNECTING, DISCONNECTED, cause, err) || c.compareAndSetConnState(FAILING_OVER, DISCONNECTED, cause, err) {
+		c.abortReconnect()
+		<-c.reconnectDone
 		return nil
 	}
 
+	c.disconnectMu.Lock()
+	defer c.disconnectMu.Unlock()
+
 	// Set Disconnected state realy so that new calls to Disconnect() will not block
-	c.setConnState(DISCONNECTED)
+	if conn, _, _ := c.session(); !conn.IsConnected() || !c.leaveConnected(DISCONNECTED, cause, err) {
+		return nil
+	}
+
+	// Tell the supervisor this is a requested disconnection
+	c.abortReconnect()
 
 	// 1) Cancel to unblock request Loop
 	c.cancel()
@@ -642,12 +879,23 @@ func (c *EClient) Disconnect() error {
 }
 
 func (c *EClient) Ctx() context.Context {
-	return c.ctx
+	_, _, ctx := c.session()
+	return ctx
 }
 
 // IsConnected checks connection to TWS or GateWay.
 func (c *EClient) IsConnected() bool {
-	return c.conn.IsConnected() && ConnState(atomic.LoadInt32(&c.connState)) == CONNECTED
+	if conn, _, _ := c.session(); !conn.IsConnected() {
+		return false
+	}
+	// Requests still reach TWS/IBGW while its connectivity with IB is lost
+	state := c.ConnState()
+	return state == CONNECTED || state == CONNECTIVITY_LOST
+}
+
+// ConnState returns the current connection state.
+func (c *EClient) ConnState() ConnState {
+	return ConnState(atomic.LoadInt32(&c.connState))
 }
 
 // OptionalCapabilities returns the Optional Capabilities.
@@ -660,6 +908,21 @@ func (c *EClient) SetOptionalCapabilities(optCapts string) {
 	c.optionalCapabilities = optCapts
 }
 
+// SetDialer sets the Dialer used to open the transport to TWS/IBGW, for every connect and session rebuild.
+// Custom dialers are called with the "tcp" network and a host:port address, nil restores the default tcp4 dialer.
+// It must be called before Connect.
+func (c *EClient) SetDialer(dialer Dialer) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	c.dialer = dialer
+	conn, _, _ := c.session()
+	conn.dialer = dialer
+}
+
 // SetConnectionOptions setup the Connection Options.
 func (c *EClient) SetConnectionOptions(connectOptions string) {
 
@@ -673,15 +936,26 @@ func (c *EClient) SetConnectionOptions(connectOptions string) {
 
 // ReqCurrentTime asks the current system time on the server side.
 func (c *EClient) ReqCurrentTime() {
+	c.reportError(c.ReqCurrentTimeContext(context.Background()))
+}
+
+// ReqCurrentTimeContext is ReqCurrentTime returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqCurrentTimeContext(ctx context.Context) (err error) {
+
+	withdraw := c.watchdog.ask(false)
+	defer func() {
+		if err != nil {
+			withdraw()
+		}
+	}()
 
 	if c.useProtoBuf(REQ_CURRENT_TIME) {
-		c.reqCurrentTimeProtoBuf(createCurrentTimeRequestProto())
-		return
+		return c.reqCurrentTimeProtoBuf(ctx, createCurrentTimeRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -690,14 +964,13 @@ func (c *EClient) ReqCurrentTime() {
 
 	me.encodeMsgID(REQ_CURRENT_TIME).encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqCurrentTimeProtoBuf(currentTimeRequestProto *protobuf.CurrentTimeRequest) {
+func (c *EClient) reqCurrentTimeProtoBuf(ctx context.Context, currentTimeRequestProto *protobuf.CurrentTimeRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -706,17 +979,18 @@ func (c *EClient) reqCurrentTimeProtoBuf(currentTimeRequestProto *protobuf.Curre
 
 	msg, err := proto.Marshal(currentTimeRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ServerVersion returns the version of the TWS instance to which the API application is connected.
 func (c *EClient) ServerVersion() Version {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
 	return c.serverVersion
 }
 
@@ -728,15 +1002,19 @@ func (c *EClient) ServerVersion() Version {
 // 4 = INFORMATION
 // 5 = DETAIL
 func (c *EClient) SetServerLogLevel(logLevel int64) {
+	c.reportError(c.SetServerLogLevelContext(context.Background(), logLevel))
+}
+
+// SetServerLogLevelContext is SetServerLogLevel returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) SetServerLogLevelContext(ctx context.Context, logLevel int64) error {
 
 	if c.useProtoBuf(SET_SERVER_LOGLEVEL) {
-		c.setServerLogLevelProtoBuf(createSetServerLogLevelRequestProto(logLevel))
-		return
+		return c.setServerLogLevelProtoBuf(ctx, createSetServerLogLevelRequestProto(logLevel))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -745,14 +1023,13 @@ func (c *EClient) SetServerLogLevel(logLevel int64) {
 
 	me.encodeMsgID(SET_SERVER_LOGLEVEL).encodeInt(VERSION).encodeInt64(logLevel)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) setServerLogLevelProtoBuf(SetServerLogLevelRequest *protobuf.SetServerLogLevelRequest) {
+func (c *EClient) setServerLogLevelProtoBuf(ctx context.Context, SetServerLogLevelRequest *protobuf.SetServerLogLevelRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -761,17 +1038,18 @@ func (c *EClient) setServerLogLevelProtoBuf(SetServerLogLevelRequest *protobuf.S
 
 	msg, err := proto.Marshal(SetServerLogLevelRequest)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ConnectionTime is the time the API application made a connection to TWS.
 func (c *EClient) TWSConnectionTime() string {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
 	return c.connTime
 }
 
@@ -790,28 +1068,41 @@ func (c *EClient) TWSConnectionTime() string {
 // regulatorySnapshot: With the US Value Snapshot Bundle for stocks, regulatory snapshots are available for 0.01 USD each.
 // mktDataOptions is for internal use only.Use default value XYZ.
 func (c *EClient) ReqMktData(reqID int64, contract *Contract, genericTickList string, snapshot bool, regulatorySnapshot bool, mktDataOptions []TagValue) {
+	c.reportError(c.ReqMktDataContext(context.Background(), reqID, contract, genericTickList, snapshot, regulatorySnapshot, mktDataOptions))
+}
+
+// ReqMktDataContext is ReqMktData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqMktDataContext(ctx context.Context, reqID int64, contract *Contract, genericTickList string, snapshot bool, regulatorySnapshot bool, mktDataOptions []TagValue) (err error) {
+
+	if !snapshot && !regulatorySnapshot {
+		defer func() {
+			if err != nil {
+				return
+			}
+			contract := contract.clone()
+			c.subscriptions.record(SubscriptionMktData, reqID, contract, func(c *EClient) {
+				c.ReqMktData(reqID, contract, genericTickList, snapshot, regulatorySnapshot, mktDataOptions)
+			}, genericTickList, mktDataOptions)
+		}()
+	}
 
 	if c.useProtoBuf(REQ_MKT_DATA) {
-		c.reqMarketDataProtoBuf(createMarketDataRequestProto(reqID, contract, genericTickList, snapshot, regulatorySnapshot, mktDataOptions))
-		return
+		return c.reqMarketDataProtoBuf(ctx, createMarketDataRequestProto(reqID, contract, genericTickList, snapshot, regulatorySnapshot, mktDataOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_DELTA_NEUTRAL && contract.DeltaNeutralContract != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support delta-neutral orders.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_DELTA_NEUTRAL && contract.DeltaNeutralContract != nil {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support delta-neutral orders.", "")
 	}
-	if c.serverVersion < MIN_SERVER_VER_REQ_MKT_DATA_CONID && contract.ConID > 0 {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_MKT_DATA_CONID && contract.ConID > 0 {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId parameter.", "")
 	}
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in reqMktData.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in reqMktData.", "")
 	}
 
 	const VERSION = 11
@@ -820,7 +1111,7 @@ func (c *EClient) ReqMktData(reqID int64, contract *Contract, genericTickList st
 
 	me.encodeMsgID(REQ_MKT_DATA).encodeInt(VERSION).encodeInt64(reqID)
 
-	if c.serverVersion >= MIN_SERVER_VER_REQ_MKT_DATA_CONID {
+	if c.ServerVersion() >= MIN_SERVER_VER_REQ_MKT_DATA_CONID {
 		me.encodeInt64(contract.ConID)
 	}
 
@@ -835,7 +1126,7 @@ func (c *EClient) ReqMktData(reqID int64, contract *Contract, genericTickList st
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
@@ -851,7 +1142,7 @@ func (c *EClient) ReqMktData(reqID int64, contract *Contract, genericTickList st
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_DELTA_NEUTRAL {
+	if c.ServerVersion() >= MIN_SERVER_VER_DELTA_NEUTRAL {
 		if contract.DeltaNeutralContract != nil {
 			me.encodeBool(true)
 			me.encodeInt64(contract.DeltaNeutralContract.ConID)
@@ -865,19 +1156,19 @@ func (c *EClient) ReqMktData(reqID int64, contract *Contract, genericTickList st
 	me.encodeString(genericTickList)
 	me.encodeBool(snapshot)
 
-	if c.serverVersion >= MIN_SERVER_VER_REQ_SMART_COMPONENTS {
+	if c.ServerVersion() >= MIN_SERVER_VER_REQ_SMART_COMPONENTS {
 		me.encodeBool(regulatorySnapshot)
 	}
 
 	// send mktDataOptions parameter
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(mktDataOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqMarketDataProtoBuf(marketDataRequestProto *protobuf.MarketDataRequest) {
+func (c *EClient) reqMarketDataProtoBuf(ctx context.Context, marketDataRequestProto *protobuf.MarketDataRequest) error {
 
 	reqID := NO_VALID_ID
 	if marketDataRequestProto.ReqId != nil {
@@ -885,8 +1176,7 @@ func (c *EClient) reqMarketDataProtoBuf(marketDataRequestProto *protobuf.MarketD
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(30, c)
@@ -895,26 +1185,31 @@ func (c *EClient) reqMarketDataProtoBuf(marketDataRequestProto *protobuf.MarketD
 
 	msg, err := proto.Marshal(marketDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelMktData stops the market data flow for the specified TickerId.
 func (c *EClient) CancelMktData(reqID int64) {
+	c.reportError(c.CancelMktDataContext(context.Background(), reqID))
+}
+
+// CancelMktDataContext is CancelMktData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelMktDataContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionMktData, reqID)
 
 	if c.useProtoBuf(CANCEL_MKT_DATA) {
-		c.cancelMarketDataProtoBuf(createCancelMarketDataProto(reqID))
-		return
+		return c.cancelMarketDataProtoBuf(ctx, createCancelMarketDataProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 2
@@ -923,10 +1218,10 @@ func (c *EClient) CancelMktData(reqID int64) {
 
 	me.encodeMsgID(CANCEL_MKT_DATA).encodeInt(VERSION).encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelMarketDataProtoBuf(cancelMarketDataProto *protobuf.CancelMarketData) {
+func (c *EClient) cancelMarketDataProtoBuf(ctx context.Context, cancelMarketDataProto *protobuf.CancelMarketData) error {
 
 	reqID := NO_VALID_ID
 	if cancelMarketDataProto.ReqId != nil {
@@ -934,8 +1229,7 @@ func (c *EClient) cancelMarketDataProtoBuf(cancelMarketDataProto *protobuf.Cance
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -944,13 +1238,12 @@ func (c *EClient) cancelMarketDataProtoBuf(cancelMarketDataProto *protobuf.Cance
 
 	msg, err := proto.Marshal(cancelMarketDataProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqMarketDataType changes the market data type.
@@ -966,25 +1259,27 @@ func (c *EClient) cancelMarketDataProtoBuf(cancelMarketDataProto *protobuf.Cance
 //	3 -> delayed market data
 //	4 -> delayed frozen market data
 func (c *EClient) ReqMarketDataType(marketDataType int64) {
+	c.reportError(c.ReqMarketDataTypeContext(context.Background(), marketDataType))
+}
+
+// ReqMarketDataTypeContext is ReqMarketDataType returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqMarketDataTypeContext(ctx context.Context, marketDataType int64) error {
 
 	if c.useProtoBuf(REQ_MARKET_DATA_TYPE) {
-		c.reqMarketDataTypeProtoBuf(createMarketDataTypeRequestProto(marketDataType))
-		return
+		return c.reqMarketDataTypeProtoBuf(ctx, createMarketDataTypeRequestProto(marketDataType))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_MARKET_DATA_TYPE {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support market data type requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_MARKET_DATA_TYPE {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support market data type requests.", "")
 	}
 
 	const VERSION = 1
@@ -993,14 +1288,13 @@ func (c *EClient) ReqMarketDataType(marketDataType int64) {
 
 	me.encodeMsgID(REQ_MARKET_DATA_TYPE).encodeInt(VERSION).encodeInt64(marketDataType)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqMarketDataTypeProtoBuf(marketDataTypeRequestProto *protobuf.MarketDataTypeRequest) {
+func (c *EClient) reqMarketDataTypeProtoBuf(ctx context.Context, marketDataTypeRequestProto *protobuf.MarketDataTypeRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -1009,41 +1303,43 @@ func (c *EClient) reqMarketDataTypeProtoBuf(marketDataTypeRequestProto *protobuf
 
 	msg, err := proto.Marshal(marketDataTypeRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqSmartComponents request the smartComponents.
 func (c *EClient) ReqSmartComponents(reqID int64, bboExchange string) {
+	c.reportError(c.ReqSmartComponentsContext(context.Background(), reqID, bboExchange))
+}
+
+// ReqSmartComponentsContext is ReqSmartComponents returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqSmartComponentsContext(ctx context.Context, reqID int64, bboExchange string) error {
 
 	if c.useProtoBuf(REQ_SMART_COMPONENTS) {
-		c.reqSmartComponentsProtoBuf(createSmartComponentsRequestProto(reqID, bboExchange))
-		return
+		return c.reqSmartComponentsProtoBuf(ctx, createSmartComponentsRequestProto(reqID, bboExchange))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_SMART_COMPONENTS {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support smart components request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_SMART_COMPONENTS {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support smart components request.", "")
 	}
 
 	me := NewMsgEncoder(3, c)
 
 	me.encodeMsgID(REQ_SMART_COMPONENTS).encodeInt64(reqID).encodeString(bboExchange)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqSmartComponentsProtoBuf(smartComponentsRequestProto *protobuf.SmartComponentsRequest) {
+func (c *EClient) reqSmartComponentsProtoBuf(ctx context.Context, smartComponentsRequestProto *protobuf.SmartComponentsRequest) error {
 
 	reqID := NO_VALID_ID
 	if smartComponentsRequestProto.ReqId != nil {
@@ -1051,8 +1347,7 @@ func (c *EClient) reqSmartComponentsProtoBuf(smartComponentsRequestProto *protob
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -1061,45 +1356,46 @@ func (c *EClient) reqSmartComponentsProtoBuf(smartComponentsRequestProto *protob
 
 	msg, err := proto.Marshal(smartComponentsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqMarketRule requests the market rule.
 func (c *EClient) ReqMarketRule(marketRuleID int64) {
+	c.reportError(c.ReqMarketRuleContext(context.Background(), marketRuleID))
+}
+
+// ReqMarketRuleContext is ReqMarketRule returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqMarketRuleContext(ctx context.Context, marketRuleID int64) error {
 
 	if c.useProtoBuf(REQ_MARKET_RULE) {
-		c.reqMarketRuleProtoBuf(createMarketRuleRequestProto(marketRuleID))
-		return
+		return c.reqMarketRuleProtoBuf(ctx, createMarketRuleRequestProto(marketRuleID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MARKET_RULES {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support market rule requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MARKET_RULES {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support market rule requests.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
 
 	me.encodeMsgID(REQ_MARKET_RULE).encodeInt64(marketRuleID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqMarketRuleProtoBuf(marketRuleRequestProto *protobuf.MarketRuleRequest) {
+func (c *EClient) reqMarketRuleProtoBuf(ctx context.Context, marketRuleRequestProto *protobuf.MarketRuleRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -1108,13 +1404,12 @@ func (c *EClient) reqMarketRuleProtoBuf(marketRuleRequestProto *protobuf.MarketR
 
 	msg, err := proto.Marshal(marketRuleRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqTickByTickData request the tick-by-tick data.
@@ -1123,25 +1418,37 @@ func (c *EClient) reqMarketRuleProtoBuf(marketRuleRequestProto *protobuf.MarketR
 // ignoreSize will ignore bid/ask ticks that only update the size if true.
 // Result will be delivered via wrapper.TickByTickAllLast() wrapper.TickByTickBidAsk() wrapper.TickByTickMidPoint().
 func (c *EClient) ReqTickByTickData(reqID int64, contract *Contract, tickType string, numberOfTicks int64, ignoreSize bool) {
+	c.reportError(c.ReqTickByTickDataContext(context.Background(), reqID, contract, tickType, numberOfTicks, ignoreSize))
+}
+
+// ReqTickByTickDataContext is ReqTickByTickData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqTickByTickDataContext(ctx context.Context, reqID int64, contract *Contract, tickType string, numberOfTicks int64, ignoreSize bool) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		contract := contract.clone()
+		c.subscriptions.record(SubscriptionTickByTick, reqID, contract, func(c *EClient) {
+			c.ReqTickByTickData(reqID, contract, tickType, numberOfTicks, ignoreSize)
+		}, tickType, numberOfTicks, ignoreSize)
+	}()
 
 	if c.useProtoBuf(REQ_TICK_BY_TICK_DATA) {
-		c.reqTickByTickDataProtoBuf(createTickByTickRequestProto(reqID, contract, tickType, numberOfTicks, ignoreSize))
-		return
+		return c.reqTickByTickDataProtoBuf(ctx, createTickByTickRequestProto(reqID, contract, tickType, numberOfTicks, ignoreSize))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TICK_BY_TICK {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tick-by-tick data requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TICK_BY_TICK {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tick-by-tick data requests.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TICK_BY_TICK_IGNORE_SIZE {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ignoreSize and numberOfTicks parameters in tick-by-tick data requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TICK_BY_TICK_IGNORE_SIZE {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ignoreSize and numberOfTicks parameters in tick-by-tick data requests.", "")
 	}
 
 	me := NewMsgEncoder(17, c)
@@ -1163,14 +1470,14 @@ func (c *EClient) ReqTickByTickData(reqID int64, contract *Contract, tickType st
 	me.encodeString(contract.TradingClass)
 	me.encodeString(tickType)
 
-	if c.serverVersion >= MIN_SERVER_VER_TICK_BY_TICK_IGNORE_SIZE {
+	if c.ServerVersion() >= MIN_SERVER_VER_TICK_BY_TICK_IGNORE_SIZE {
 		me.encodeInt64(numberOfTicks).encodeBool(ignoreSize)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqTickByTickDataProtoBuf(tickByTickRequestProto *protobuf.TickByTickRequest) {
+func (c *EClient) reqTickByTickDataProtoBuf(ctx context.Context, tickByTickRequestProto *protobuf.TickByTickRequest) error {
 
 	reqID := NO_VALID_ID
 	if tickByTickRequestProto.ReqId != nil {
@@ -1178,8 +1485,7 @@ func (c *EClient) reqTickByTickDataProtoBuf(tickByTickRequestProto *protobuf.Tic
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(17, c)
@@ -1188,41 +1494,45 @@ func (c *EClient) reqTickByTickDataProtoBuf(tickByTickRequestProto *protobuf.Tic
 
 	msg, err := proto.Marshal(tickByTickRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelTickByTickData cancel the tick-by-tick data
 func (c *EClient) CancelTickByTickData(reqID int64) {
+	c.reportError(c.CancelTickByTickDataContext(context.Background(), reqID))
+}
+
+// CancelTickByTickDataContext is CancelTickByTickData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelTickByTickDataContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionTickByTick, reqID)
 
 	if c.useProtoBuf(CANCEL_TICK_BY_TICK_DATA) {
-		c.cancelTickByTickDataProtoBuf(createCancelTickByTickProto(reqID))
-		return
+		return c.cancelTickByTickDataProtoBuf(ctx, createCancelTickByTickProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TICK_BY_TICK {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tick-by-tick data requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TICK_BY_TICK {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tick-by-tick data requests.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
 
 	me.encodeMsgID(CANCEL_TICK_BY_TICK_DATA).encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelTickByTickDataProtoBuf(cancelTickByTickProto *protobuf.CancelTickByTick) {
+func (c *EClient) cancelTickByTickDataProtoBuf(ctx context.Context, cancelTickByTickProto *protobuf.CancelTickByTick) error {
 
 	reqID := NO_VALID_ID
 	if cancelTickByTickProto.ReqId != nil {
@@ -1230,8 +1540,7 @@ func (c *EClient) cancelTickByTickDataProtoBuf(cancelTickByTickProto *protobuf.C
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -1240,13 +1549,12 @@ func (c *EClient) cancelTickByTickDataProtoBuf(cancelTickByTickProto *protobuf.C
 
 	msg, err := proto.Marshal(cancelTickByTickProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -1256,25 +1564,27 @@ func (c *EClient) cancelTickByTickDataProtoBuf(cancelTickByTickProto *protobuf.C
 // CalculateImpliedVolatility calculates the implied volatility of the option.
 // Result will be delivered via wrapper.TickOptionComputation().
 func (c *EClient) CalculateImpliedVolatility(reqID int64, contract *Contract, optionPrice float64, underPrice float64, miscOptions []TagValue) {
+	c.reportError(c.CalculateImpliedVolatilityContext(context.Background(), reqID, contract, optionPrice, underPrice, miscOptions))
+}
+
+// CalculateImpliedVolatilityContext is CalculateImpliedVolatility returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CalculateImpliedVolatilityContext(ctx context.Context, reqID int64, contract *Contract, optionPrice float64, underPrice float64, miscOptions []TagValue) error {
 
 	if c.useProtoBuf(REQ_CALC_IMPLIED_VOLAT) {
-		c.calculateImpliedVolatilityProtoBuf(createCalculateImpliedVolatilityRequestProto(reqID, contract, optionPrice, underPrice, miscOptions))
-		return
+		return c.calculateImpliedVolatilityProtoBuf(ctx, createCalculateImpliedVolatilityRequestProto(reqID, contract, optionPrice, underPrice, miscOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in calculateImpliedVolatility.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in calculateImpliedVolatility.", "")
 	}
 
 	const VERSION = 3
@@ -1298,21 +1608,21 @@ func (c *EClient) CalculateImpliedVolatility(reqID int64, contract *Contract, op
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
 	me.encodeFloat64(optionPrice)
 	me.encodeFloat64(underPrice)
 
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(miscOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) calculateImpliedVolatilityProtoBuf(calculateImpliedVolatilityRequestProto *protobuf.CalculateImpliedVolatilityRequest) {
+func (c *EClient) calculateImpliedVolatilityProtoBuf(ctx context.Context, calculateImpliedVolatilityRequestProto *protobuf.CalculateImpliedVolatilityRequest) error {
 
 	reqID := NO_VALID_ID
 	if calculateImpliedVolatilityRequestProto.ReqId != nil {
@@ -1320,8 +1630,7 @@ func (c *EClient) calculateImpliedVolatilityProtoBuf(calculateImpliedVolatilityR
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(19, c)
@@ -1330,31 +1639,33 @@ func (c *EClient) calculateImpliedVolatilityProtoBuf(calculateImpliedVolatilityR
 
 	msg, err := proto.Marshal(calculateImpliedVolatilityRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelCalculateImpliedVolatility cancels a request to calculate volatility for a supplied option price and underlying price.
 func (c *EClient) CancelCalculateImpliedVolatility(reqID int64) {
+	c.reportError(c.CancelCalculateImpliedVolatilityContext(context.Background(), reqID))
+}
+
+// CancelCalculateImpliedVolatilityContext is CancelCalculateImpliedVolatility returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelCalculateImpliedVolatilityContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_CALC_IMPLIED_VOLAT) {
-		c.cancelCalculateImpliedVolatilityProtoBuf(createCancelCalculateImpliedVolatilityProto(reqID))
-		return
+		return c.cancelCalculateImpliedVolatilityProtoBuf(ctx, createCancelCalculateImpliedVolatilityProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
 	}
 
 	const VERSION = 1
@@ -1366,10 +1677,10 @@ func (c *EClient) CancelCalculateImpliedVolatility(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelCalculateImpliedVolatilityProtoBuf(cancelCalculateImpliedVolatilityProto *protobuf.CancelCalculateImpliedVolatility) {
+func (c *EClient) cancelCalculateImpliedVolatilityProtoBuf(ctx context.Context, cancelCalculateImpliedVolatilityProto *protobuf.CancelCalculateImpliedVolatility) error {
 
 	reqID := NO_VALID_ID
 	if cancelCalculateImpliedVolatilityProto.ReqId != nil {
@@ -1377,8 +1688,7 @@ func (c *EClient) cancelCalculateImpliedVolatilityProtoBuf(cancelCalculateImplie
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -1387,38 +1697,39 @@ func (c *EClient) cancelCalculateImpliedVolatilityProtoBuf(cancelCalculateImplie
 
 	msg, err := proto.Marshal(cancelCalculateImpliedVolatilityProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CalculateOptionPrice calculate the price of the option
 // Call this function to calculate price for a supplied option volatility and underlying price.
 // Result will be delivered via wrapper.TickOptionComputation().
 func (c *EClient) CalculateOptionPrice(reqID int64, contract *Contract, volatility float64, underPrice float64, miscOptions []TagValue) {
+	c.reportError(c.CalculateOptionPriceContext(context.Background(), reqID, contract, volatility, underPrice, miscOptions))
+}
+
+// CalculateOptionPriceContext is CalculateOptionPrice returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CalculateOptionPriceContext(ctx context.Context, reqID int64, contract *Contract, volatility float64, underPrice float64, miscOptions []TagValue) error {
 
 	if c.useProtoBuf(REQ_CALC_OPTION_PRICE) {
-		c.calculateOptionPriceProtoBuf(createCalculateOptionPriceRequestProto(reqID, contract, volatility, underPrice, miscOptions))
-		return
+		return c.calculateOptionPriceProtoBuf(ctx, createCalculateOptionPriceRequestProto(reqID, contract, volatility, underPrice, miscOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in calculateImpliedVolatility.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in calculateImpliedVolatility.", "")
 	}
 
 	const VERSION = 2
@@ -1442,21 +1753,21 @@ func (c *EClient) CalculateOptionPrice(reqID int64, contract *Contract, volatili
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
 	me.encodeFloat64(volatility)
 	me.encodeFloat64(underPrice)
 
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(miscOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) calculateOptionPriceProtoBuf(calculateOptionPriceRequestProto *protobuf.CalculateOptionPriceRequest) {
+func (c *EClient) calculateOptionPriceProtoBuf(ctx context.Context, calculateOptionPriceRequestProto *protobuf.CalculateOptionPriceRequest) error {
 
 	reqID := NO_VALID_ID
 	if calculateOptionPriceRequestProto.ReqId != nil {
@@ -1464,8 +1775,7 @@ func (c *EClient) calculateOptionPriceProtoBuf(calculateOptionPriceRequestProto
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(19, c)
@@ -1474,31 +1784,33 @@ func (c *EClient) calculateOptionPriceProtoBuf(calculateOptionPriceRequestProto
 
 	msg, err := proto.Marshal(calculateOptionPriceRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelCalculateOptionPrice cancels the calculation of option price.
 func (c *EClient) CancelCalculateOptionPrice(reqID int64) {
+	c.reportError(c.CancelCalculateOptionPriceContext(context.Background(), reqID))
+}
+
+// CancelCalculateOptionPriceContext is CancelCalculateOptionPrice returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelCalculateOptionPriceContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_CALC_OPTION_PRICE) {
-		c.cancelCalculateOptionPriceProtoBuf(createCancelCalculateOptionPriceProto(reqID))
-		return
+		return c.cancelCalculateOptionPriceProtoBuf(ctx, createCancelCalculateOptionPriceProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_CALC_IMPLIED_VOLAT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support calculateImpliedVolatility req.", "")
 	}
 
 	const VERSION = 1
@@ -1510,10 +1822,10 @@ func (c *EClient) CancelCalculateOptionPrice(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelCalculateOptionPriceProtoBuf(cancelCalculateOptionPriceProto *protobuf.CancelCalculateOptionPrice) {
+func (c *EClient) cancelCalculateOptionPriceProtoBuf(ctx context.Context, cancelCalculateOptionPriceProto *protobuf.CancelCalculateOptionPrice) error {
 
 	reqID := NO_VALID_ID
 	if cancelCalculateOptionPriceProto.ReqId != nil {
@@ -1521,8 +1833,7 @@ func (c *EClient) cancelCalculateOptionPriceProtoBuf(cancelCalculateOptionPriceP
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -1531,13 +1842,12 @@ func (c *EClient) cancelCalculateOptionPriceProtoBuf(cancelCalculateOptionPriceP
 
 	msg, err := proto.Marshal(cancelCalculateOptionPriceProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ExerciseOptions exercises the option defined by the contract.
@@ -1557,35 +1867,35 @@ func (c *EClient) cancelCalculateOptionPriceProtoBuf(cancelCalculateOptionPriceP
 // customerAccount is the customer account.
 // professionalCustomer:bool - professional customer.
 func (c *EClient) ExerciseOptions(reqID int64, contract *Contract, exerciseAction int, exerciseQuantity int, account string, override int, manualOrderTime string, customerAccount string, professionalCustomer bool) {
+	c.reportError(c.ExerciseOptionsContext(context.Background(), reqID, contract, exerciseAction, exerciseQuantity, account, override, manualOrderTime, customerAccount, professionalCustomer))
+}
+
+// ExerciseOptionsContext is ExerciseOptions returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ExerciseOptionsContext(ctx context.Context, reqID int64, contract *Contract, exerciseAction int, exerciseQuantity int, account string, override int, manualOrderTime string, customerAccount string, professionalCustomer bool) error {
 
 	if c.useProtoBuf(EXERCISE_OPTIONS) {
-		c.exerciseOptionsProtoBuf(createExerciseOptionsRequestProto(reqID, contract, int64(exerciseAction), int64(exerciseQuantity), account, override != 0, manualOrderTime, customerAccount, professionalCustomer))
-		return
+		return c.exerciseOptionsProtoBuf(ctx, createExerciseOptionsRequestProto(reqID, contract, int64(exerciseAction), int64(exerciseQuantity), account, override != 0, manualOrderTime, customerAccount, professionalCustomer))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS && (contract.TradingClass != "" || contract.ConID > 0) {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId, multiplier, tradingClass parameter in exerciseOptions.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS && (contract.TradingClass != "" || contract.ConID > 0) {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId, multiplier, tradingClass parameter in exerciseOptions.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MANUAL_ORDER_TIME_EXERCISE_OPTIONS && manualOrderTime != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support manual order time parameter in exerciseOptions.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MANUAL_ORDER_TIME_EXERCISE_OPTIONS && manualOrderTime != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support manual order time parameter in exerciseOptions.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CUSTOMER_ACCOUNT && customerAccount != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support customer account parameter in exerciseOptions.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CUSTOMER_ACCOUNT && customerAccount != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support customer account parameter in exerciseOptions.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PROFESSIONAL_CUSTOMER && professionalCustomer {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support professional customer parameter in exerciseOptions.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PROFESSIONAL_CUSTOMER && professionalCustomer {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support professional customer parameter in exerciseOptions.", "")
 	}
 
 	const VERSION = 2
@@ -1598,7 +1908,7 @@ func (c *EClient) ExerciseOptions(reqID int64, contract *Contract, exerciseActio
 	me.encodeInt64(reqID)
 
 	// send contract fields
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeInt64(contract.ConID)
 	}
 
@@ -1612,7 +1922,7 @@ func (c *EClient) ExerciseOptions(reqID int64, contract *Contract, exerciseActio
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
@@ -1621,22 +1931,22 @@ func (c *EClient) ExerciseOptions(reqID int64, contract *Contract, exerciseActio
 	me.encodeString(account)
 	me.encodeInt(override)
 
-	if c.serverVersion >= MIN_SERVER_VER_MANUAL_ORDER_TIME_EXERCISE_OPTIONS {
+	if c.ServerVersion() >= MIN_SERVER_VER_MANUAL_ORDER_TIME_EXERCISE_OPTIONS {
 		me.encodeString(manualOrderTime)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_CUSTOMER_ACCOUNT {
+	if c.ServerVersion() >= MIN_SERVER_VER_CUSTOMER_ACCOUNT {
 		me.encodeString(customerAccount)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_PROFESSIONAL_CUSTOMER {
+	if c.ServerVersion() >= MIN_SERVER_VER_PROFESSIONAL_CUSTOMER {
 		me.encodeBool(professionalCustomer)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) exerciseOptionsProtoBuf(exerciseOptionsRequestProto *protobuf.ExerciseOptionsRequest) {
+func (c *EClient) exerciseOptionsProtoBuf(ctx context.Context, exerciseOptionsRequestProto *protobuf.ExerciseOptionsRequest) error {
 
 	orderID := NO_VALID_ID
 	if exerciseOptionsRequestProto.OrderId != nil {
@@ -1644,8 +1954,7 @@ func (c *EClient) exerciseOptionsProtoBuf(exerciseOptionsRequestProto *protobuf.
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(17, c)
@@ -1654,13 +1963,12 @@ func (c *EClient) exerciseOptionsProtoBuf(exerciseOptionsRequestProto *protobuf.
 
 	msg, err := proto.Marshal(exerciseOptionsRequestProto)
 	if err != nil {
-		c.wrapper.Error(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -1674,93 +1982,92 @@ func (c *EClient) exerciseOptionsProtoBuf(exerciseOptionsRequestProto *protobuf.
 // contract contains a description of the contract which is being traded.
 // order contains the details of the traded order.
 func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
+	c.reportError(c.PlaceOrderContext(context.Background(), orderID, contract, order))
+}
+
+// PlaceOrderContext is PlaceOrder returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) PlaceOrderContext(ctx context.Context, orderID int64, contract *Contract, order *Order) (err error) {
+
+	c.trades.placing(orderID, contract, order)
+	defer func() {
+		if err != nil {
+			c.trades.placeFailed(orderID, err)
+		}
+	}()
 
 	if c.useProtoBuf(PLACE_ORDER) {
 		placeOrderRequestProto, err := createPlaceOrderRequestProto(orderID, contract, order)
 		if err != nil {
-			c.wrapper.Error(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+			return NewIBError(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 		}
-		c.placeOrderProtoBuf(placeOrderRequestProto)
-		return
+		return c.placeOrderProtoBuf(ctx, placeOrderRequestProto)
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_DELTA_NEUTRAL && contract.DeltaNeutralContract != nil {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support delta-neutral orders.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_DELTA_NEUTRAL && contract.DeltaNeutralContract != nil {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support delta-neutral orders.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SCALE_ORDERS2 && order.ScaleSubsLevelSize != UNSET_INT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support Subsequent Level Size for Scale orders.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SCALE_ORDERS2 && order.ScaleSubsLevelSize != UNSET_INT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support Subsequent Level Size for Scale orders.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ALGO_ORDERS && order.AlgoStrategy != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support algo orders.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_ALGO_ORDERS && order.AlgoStrategy != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support algo orders.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_NOT_HELD && order.NotHeld {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support notHeld parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_NOT_HELD && order.NotHeld {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support notHeld parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SEC_ID_TYPE && (contract.SecType != "" || contract.SecID != "") {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support secIdType and secId parameters.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SEC_ID_TYPE && (contract.SecType != "" || contract.SecID != "") {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support secIdType and secId parameters.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PLACE_ORDER_CONID && contract.ConID != UNSET_INT && contract.ConID > 0 {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PLACE_ORDER_CONID && contract.ConID != UNSET_INT && contract.ConID > 0 {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SSHORTX && order.ExemptCode != -1 {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support exemptCode parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SSHORTX && order.ExemptCode != -1 {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support exemptCode parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SSHORTX {
+	if c.ServerVersion() < MIN_SERVER_VER_SSHORTX {
 		for _, comboLeg := range contract.ComboLegs {
 			if comboLeg.ExemptCode != -1 {
-				c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support exemptCode parameter.", "")
-				return
+				return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support exemptCode parameter.", "")
 			}
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_HEDGE_ORDERS && order.HedgeType != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support hedge orders.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_HEDGE_ORDERS && order.HedgeType != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support hedge orders.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_OPT_OUT_SMART_ROUTING && order.OptOutSmartRouting {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support optOutSmartRouting parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_OPT_OUT_SMART_ROUTING && order.OptOutSmartRouting {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support optOutSmartRouting parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_DELTA_NEUTRAL_CONID {
+	if c.ServerVersion() < MIN_SERVER_VER_DELTA_NEUTRAL_CONID {
 		if order.DeltaNeutralConID > 0 || order.DeltaNeutralSettlingFirm != "" || order.DeltaNeutralClearingAccount != "" || order.DeltaNeutralClearingIntent != "" {
-			c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support deltaNeutral parameters: ConId, SettlingFirm, ClearingAccount, ClearingIntent.", "")
-			return
+			return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support deltaNeutral parameters: ConId, SettlingFirm, ClearingAccount, ClearingIntent.", "")
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_DELTA_NEUTRAL_OPEN_CLOSE {
+	if c.ServerVersion() < MIN_SERVER_VER_DELTA_NEUTRAL_OPEN_CLOSE {
 		if order.DeltaNeutralOpenClose != "" ||
 			order.DeltaNeutralShortSale ||
 			order.DeltaNeutralShortSaleSlot > 0 ||
 			order.DeltaNeutralDesignatedLocation != "" {
-			c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support deltaNeutral parameters: OpenClose, ShortSale, ShortSaleSlot, DesignatedLocation.", "")
-			return
+			return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support deltaNeutral parameters: OpenClose, ShortSale, ShortSaleSlot, DesignatedLocation.", "")
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SCALE_ORDERS3 {
+	if c.ServerVersion() < MIN_SERVER_VER_SCALE_ORDERS3 {
 		if (order.ScalePriceIncrement > 0 && order.ScalePriceIncrement != UNSET_FLOAT) &&
 			(order.ScalePriceAdjustValue != UNSET_FLOAT ||
 				order.ScalePriceAdjustInterval != UNSET_INT ||
@@ -1769,153 +2076,127 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 				order.ScaleInitPosition != UNSET_INT ||
 				order.ScaleInitFillQty != UNSET_INT ||
 				order.ScaleRandomPercent) {
-			c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+
+			return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+
 				" It does not support Scale order parameters: PriceAdjustValue, PriceAdjustInterval, "+
 				"ProfitOffset, AutoReset, InitPosition, InitFillQty and RandomPercent.", "")
-			return
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ORDER_COMBO_LEGS_PRICE && contract.SecType == "BAG" {
+	if c.ServerVersion() < MIN_SERVER_VER_ORDER_COMBO_LEGS_PRICE && contract.SecType == "BAG" {
 		for _, orderComboLeg := range order.OrderComboLegs {
 			if orderComboLeg.Price != UNSET_FLOAT {
-				c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support per-leg prices for order combo legs.", "")
-				return
+				return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support per-leg prices for order combo legs.", "")
 			}
 
 		}
 	}
-	if c.serverVersion < MIN_SERVER_VER_TRAILING_PERCENT && order.TrailingPercent != UNSET_FLOAT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support trailing percent parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRAILING_PERCENT && order.TrailingPercent != UNSET_FLOAT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support trailing percent parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in placeOrder.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in placeOrder.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SCALE_TABLE &&
+	if c.ServerVersion() < MIN_SERVER_VER_SCALE_TABLE &&
 		(order.ScaleTable != "" || order.ActiveStartTime != "" || order.ActiveStopTime != "") {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support scaleTable, activeStartTime and activeStopTime parameters.", "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support scaleTable, activeStartTime and activeStopTime parameters.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ALGO_ID && order.AlgoID != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support algoId parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_ALGO_ID && order.AlgoID != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support algoId parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ORDER_SOLICITED && order.Solicited {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support order solicited parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_ORDER_SOLICITED && order.Solicited {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support order solicited parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MODELS_SUPPORT && order.ModelCode != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support model code parameter.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MODELS_SUPPORT && order.ModelCode != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support model code parameter.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_EXT_OPERATOR && order.ExtOperator != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ext operator parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_EXT_OPERATOR && order.ExtOperator != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ext operator parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SOFT_DOLLAR_TIER && (order.SoftDollarTier.Name != "" || order.SoftDollarTier.Value != "") {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support soft dollar tier", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SOFT_DOLLAR_TIER && (order.SoftDollarTier.Name != "" || order.SoftDollarTier.Value != "") {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support soft dollar tier", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CASH_QTY && order.CashQty != UNSET_FLOAT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support cash quantity parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CASH_QTY && order.CashQty != UNSET_FLOAT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support cash quantity parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_DECISION_MAKER && (order.Mifid2DecisionMaker != "" || order.Mifid2DecisionAlgo != "") {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support MIFID II decision maker parameters", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_DECISION_MAKER && (order.Mifid2DecisionMaker != "" || order.Mifid2DecisionAlgo != "") {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support MIFID II decision maker parameters", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MIFID_EXECUTION && (order.Mifid2ExecutionTrader != "" || order.Mifid2ExecutionAlgo != "") {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support MIFID II execution parameters", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MIFID_EXECUTION && (order.Mifid2ExecutionTrader != "" || order.Mifid2ExecutionAlgo != "") {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support MIFID II execution parameters", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_AUTO_PRICE_FOR_HEDGE && order.DontUseAutoPriceForHedge {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support dontUseAutoPriceForHedge parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_AUTO_PRICE_FOR_HEDGE && order.DontUseAutoPriceForHedge {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support dontUseAutoPriceForHedge parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ORDER_CONTAINER && order.IsOmsContainer {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support oms container parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_ORDER_CONTAINER && order.IsOmsContainer {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support oms container parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PRICE_MGMT_ALGO && order.UsePriceMgmtAlgo != USE_PRICE_MGMT_ALGO_DEFAULT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support Use price management algo requests", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PRICE_MGMT_ALGO && order.UsePriceMgmtAlgo != USE_PRICE_MGMT_ALGO_DEFAULT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support Use price management algo requests", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_DURATION && order.Duration != UNSET_INT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support duration attribute", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_DURATION && order.Duration != UNSET_INT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support duration attribute", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_POST_TO_ATS && order.PostToAts != UNSET_INT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support postToAts attribute", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_POST_TO_ATS && order.PostToAts != UNSET_INT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support postToAts attribute", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_AUTO_CANCEL_PARENT && order.AutoCancelParent {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support autoCancelParent attribute", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_AUTO_CANCEL_PARENT && order.AutoCancelParent {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support autoCancelParent attribute", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ADVANCED_ORDER_REJECT && order.AdvancedErrorOverride != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support advanced error override attribute", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_ADVANCED_ORDER_REJECT && order.AdvancedErrorOverride != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support advanced error override attribute", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PEGBEST_PEGMID_OFFSETS {
+	if c.ServerVersion() < MIN_SERVER_VER_PEGBEST_PEGMID_OFFSETS {
 		if order.MinTradeQty != UNSET_INT ||
 			order.MinCompeteSize != UNSET_INT ||
 			order.CompeteAgainstBestOffset != UNSET_FLOAT ||
 			order.MidOffsetAtWhole != UNSET_FLOAT ||
 			order.MidOffsetAtHalf != UNSET_FLOAT {
-			c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+
+			return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+
 				" It does not support PEG BEST / PEG MID order parameters: minTradeQty, minCompeteSize, "+
 				"competeAgainstBestOffset, midOffsetAtWhole and midOffsetAtHalf.", "")
-			return
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CUSTOMER_ACCOUNT && order.CustomerAccount != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support customer account parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CUSTOMER_ACCOUNT && order.CustomerAccount != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support customer account parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PROFESSIONAL_CUSTOMER && order.ProfessionalCustomer {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support professional customer parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PROFESSIONAL_CUSTOMER && order.ProfessionalCustomer {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support professional customer parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_INCLUDE_OVERNIGHT && order.IncludeOvernight {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support include overnight parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_INCLUDE_OVERNIGHT && order.IncludeOvernight {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support include overnight parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CME_TAGGING_FIELDS && order.ManualOrderIndicator != UNSET_INT {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support manual indicator parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CME_TAGGING_FIELDS && order.ManualOrderIndicator != UNSET_INT {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support manual indicator parameter", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_IMBALANCE_ONLY && order.ImbalanceOnly {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support imbalance only parameter", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_IMBALANCE_ONLY && order.ImbalanceOnly {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support imbalance only parameter", "")
 	}
 
 	var VERSION int
-	if c.serverVersion < MIN_SERVER_VER_NOT_HELD {
+	if c.ServerVersion() < MIN_SERVER_VER_NOT_HELD {
 		VERSION = 27
 	} else {
 		VERSION = 45
@@ -1926,14 +2207,14 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 
 	me.encodeMsgID(PLACE_ORDER)
 
-	if c.serverVersion < MIN_SERVER_VER_ORDER_CONTAINER {
+	if c.ServerVersion() < MIN_SERVER_VER_ORDER_CONTAINER {
 		me.encodeInt(VERSION)
 	}
 
 	me.encodeInt64(orderID)
 
 	// send contract fields
-	if c.serverVersion >= MIN_SERVER_VER_PLACE_ORDER_CONID {
+	if c.ServerVersion() >= MIN_SERVER_VER_PLACE_ORDER_CONID {
 		me.encodeInt64(contract.ConID)
 	}
 	me.encodeString(contract.Symbol)
@@ -1947,11 +2228,11 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol) // srv v2 and above
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_SEC_ID_TYPE {
+	if c.ServerVersion() >= MIN_SERVER_VER_SEC_ID_TYPE {
 		me.encodeString(contract.SecIDType)
 		me.encodeString(contract.SecID)
 	}
@@ -1959,7 +2240,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 	// send main order fields
 	me.encodeString(order.Action)
 
-	if c.serverVersion >= MIN_SERVER_VER_FRACTIONAL_POSITIONS {
+	if c.ServerVersion() >= MIN_SERVER_VER_FRACTIONAL_POSITIONS {
 		me.encodeDecimal(order.TotalQuantity)
 	} else {
 		me.encodeDecimal(order.TotalQuantity)
@@ -1967,7 +2248,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 
 	me.encodeString(order.OrderType)
 
-	if c.serverVersion < MIN_SERVER_VER_ORDER_COMBO_LEGS_PRICE {
+	if c.ServerVersion() < MIN_SERVER_VER_ORDER_COMBO_LEGS_PRICE {
 		if order.LmtPrice != UNSET_FLOAT {
 			me.encodeFloat64(order.LmtPrice)
 		} else {
@@ -1977,7 +2258,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		me.encodeFloatMax(order.LmtPrice)
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRAILING_PERCENT {
+	if c.ServerVersion() < MIN_SERVER_VER_TRAILING_PERCENT {
 		if order.AuxPrice != UNSET_FLOAT {
 			me.encodeFloat64(order.AuxPrice)
 		} else {
@@ -2019,14 +2300,14 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 
 			me.encodeInt64(comboLeg.ShortSaleSlot)       // srv v35 and above
 			me.encodeString(comboLeg.DesignatedLocation) // srv v35 and above
-			if c.serverVersion >= MIN_SERVER_VER_SSHORTX_OLD {
+			if c.ServerVersion() >= MIN_SERVER_VER_SSHORTX_OLD {
 				me.encodeInt64(comboLeg.ExemptCode)
 			}
 		}
 	}
 
 	// Send order combo legs for BAG requests
-	if c.serverVersion >= MIN_SERVER_VER_ORDER_COMBO_LEGS_PRICE && contract.SecType == "BAG" {
+	if c.ServerVersion() >= MIN_SERVER_VER_ORDER_COMBO_LEGS_PRICE && contract.SecType == "BAG" {
 		orderComboLegsCount := len(order.OrderComboLegs)
 		me.encodeInt(orderComboLegsCount)
 		for _, orderComboLeg := range order.OrderComboLegs {
@@ -2034,7 +2315,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_SMART_COMBO_ROUTING_PARAMS && contract.SecType == "BAG" {
+	if c.ServerVersion() >= MIN_SERVER_VER_SMART_COMBO_ROUTING_PARAMS && contract.SecType == "BAG" {
 		smartComboRoutingParamsCount := len(order.SmartComboRoutingParams)
 		me.encodeInt(smartComboRoutingParamsCount)
 		for _, tv := range order.SmartComboRoutingParams {
@@ -2065,11 +2346,11 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 	me.encodeString(order.FAMethod)     //srv v13 and above
 	me.encodeString(order.FAPercentage) //srv v13 and above
 
-	if c.serverVersion < MIN_SERVER_VER_FA_PROFILE_DESUPPORT {
+	if c.ServerVersion() < MIN_SERVER_VER_FA_PROFILE_DESUPPORT {
 		me.encodeString("") // send deprecated faProfile field
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_MODELS_SUPPORT {
+	if c.ServerVersion() >= MIN_SERVER_VER_MODELS_SUPPORT {
 		me.encodeString(order.ModelCode)
 	}
 
@@ -2077,7 +2358,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 	me.encodeInt64(order.ShortSaleSlot)       // 0 for retail, 1 or 2 for institutions
 	me.encodeString(order.DesignatedLocation) // populate only when shortSaleSlot = 2.
 
-	if c.serverVersion >= MIN_SERVER_VER_SSHORTX_OLD {
+	if c.ServerVersion() >= MIN_SERVER_VER_SSHORTX_OLD {
 		me.encodeInt64(order.ExemptCode)
 	}
 
@@ -2107,14 +2388,14 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 	me.encodeString(order.DeltaNeutralOrderType)  // srv v28 and above
 	me.encodeFloatMax(order.DeltaNeutralAuxPrice) // srv v28 and above
 
-	if c.serverVersion >= MIN_SERVER_VER_DELTA_NEUTRAL_CONID && order.DeltaNeutralOrderType != "" {
+	if c.ServerVersion() >= MIN_SERVER_VER_DELTA_NEUTRAL_CONID && order.DeltaNeutralOrderType != "" {
 		me.encodeInt64(order.DeltaNeutralConID)
 		me.encodeString(order.DeltaNeutralSettlingFirm)
 		me.encodeString(order.DeltaNeutralClearingAccount)
 		me.encodeString(order.DeltaNeutralClearingIntent)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_DELTA_NEUTRAL_OPEN_CLOSE && order.DeltaNeutralOrderType != "" {
+	if c.ServerVersion() >= MIN_SERVER_VER_DELTA_NEUTRAL_OPEN_CLOSE && order.DeltaNeutralOrderType != "" {
 		me.encodeString(order.DeltaNeutralOpenClose)
 		me.encodeBool(order.DeltaNeutralShortSale)
 		me.encodeInt64(order.DeltaNeutralShortSaleSlot)
@@ -2127,12 +2408,12 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 
 	me.encodeFloatMax(order.TrailStopPrice) // srv v30 and above
 
-	if c.serverVersion >= MIN_SERVER_VER_TRAILING_PERCENT {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRAILING_PERCENT {
 		me.encodeFloatMax(order.TrailingPercent)
 	}
 
 	// scale orders
-	if c.serverVersion >= MIN_SERVER_VER_SCALE_ORDERS2 {
+	if c.ServerVersion() >= MIN_SERVER_VER_SCALE_ORDERS2 {
 		me.encodeIntMax(order.ScaleInitLevelSize)
 		me.encodeIntMax(order.ScaleSubsLevelSize)
 	} else {
@@ -2143,7 +2424,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 
 	me.encodeFloatMax(order.ScalePriceIncrement)
 
-	if c.serverVersion >= MIN_SERVER_VER_SCALE_ORDERS3 && order.ScalePriceIncrement != UNSET_FLOAT && order.ScalePriceIncrement > 0.0 {
+	if c.ServerVersion() >= MIN_SERVER_VER_SCALE_ORDERS3 && order.ScalePriceIncrement != UNSET_FLOAT && order.ScalePriceIncrement > 0.0 {
 		me.encodeFloatMax(order.ScalePriceAdjustValue)
 		me.encodeIntMax(order.ScalePriceAdjustInterval)
 		me.encodeFloatMax(order.ScaleProfitOffset)
@@ -2153,34 +2434,34 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		me.encodeBool(order.ScaleRandomPercent)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_SCALE_TABLE {
+	if c.ServerVersion() >= MIN_SERVER_VER_SCALE_TABLE {
 		me.encodeString(order.ScaleTable)
 		me.encodeString(order.ActiveStartTime)
 		me.encodeString(order.ActiveStopTime)
 	}
 
 	// hedge orders
-	if c.serverVersion >= MIN_SERVER_VER_HEDGE_ORDERS {
+	if c.ServerVersion() >= MIN_SERVER_VER_HEDGE_ORDERS {
 		me.encodeString(order.HedgeType)
 		if order.HedgeType != "" {
 			me.encodeString(order.HedgeParam)
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_OPT_OUT_SMART_ROUTING {
+	if c.ServerVersion() >= MIN_SERVER_VER_OPT_OUT_SMART_ROUTING {
 		me.encodeBool(order.OptOutSmartRouting)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_PTA_ORDERS {
+	if c.ServerVersion() >= MIN_SERVER_VER_PTA_ORDERS {
 		me.encodeString(order.ClearingAccount)
 		me.encodeString(order.ClearingIntent)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_NOT_HELD {
+	if c.ServerVersion() >= MIN_SERVER_VER_NOT_HELD {
 		me.encodeBool(order.NotHeld)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_DELTA_NEUTRAL {
+	if c.ServerVersion() >= MIN_SERVER_VER_DELTA_NEUTRAL {
 		if contract.DeltaNeutralContract != nil {
 			me.encodeBool(true)
 			me.encodeInt64(contract.DeltaNeutralContract.ConID)
@@ -2191,7 +2472,7 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_ALGO_ORDERS {
+	if c.ServerVersion() >= MIN_SERVER_VER_ALGO_ORDERS {
 		me.encodeString(order.AlgoStrategy)
 
 		if order.AlgoStrategy != "" {
@@ -2204,27 +2485,27 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_ALGO_ID {
+	if c.ServerVersion() >= MIN_SERVER_VER_ALGO_ID {
 		me.encodeString(order.AlgoID)
 	}
 
 	me.encodeBool(order.WhatIf) // srv v36 and above
 
 	// send miscOptions parameter
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(order.OrderMiscOptions)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_ORDER_SOLICITED {
+	if c.ServerVersion() >= MIN_SERVER_VER_ORDER_SOLICITED {
 		me.encodeBool(order.Solicited)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_RANDOMIZE_SIZE_AND_PRICE {
+	if c.ServerVersion() >= MIN_SERVER_VER_RANDOMIZE_SIZE_AND_PRICE {
 		me.encodeBool(order.RandomizeSize)
 		me.encodeBool(order.RandomizePrice)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_PEGGED_TO_BENCHMARK {
+	if c.ServerVersion() >= MIN_SERVER_VER_PEGGED_TO_BENCHMARK {
 		if order.OrderType == "PEG BENCH" {
 			me.encodeInt64(order.ReferenceContractID)
 			me.encodeBool(order.IsPeggedChangeAmountDecrease)
@@ -2253,66 +2534,66 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		me.encodeFloat64(order.AdjustedTrailingAmount)
 		me.encodeInt64(order.AdjustableTrailingUnit)
 	}
-	if c.serverVersion >= MIN_SERVER_VER_EXT_OPERATOR {
+	if c.ServerVersion() >= MIN_SERVER_VER_EXT_OPERATOR {
 		me.encodeString(order.ExtOperator)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_SOFT_DOLLAR_TIER {
+	if c.ServerVersion() >= MIN_SERVER_VER_SOFT_DOLLAR_TIER {
 		me.encodeString(order.SoftDollarTier.Name)
 		me.encodeString(order.SoftDollarTier.Value)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_CASH_QTY {
+	if c.ServerVersion() >= MIN_SERVER_VER_CASH_QTY {
 		me.encodeFloatMax(order.CashQty)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_DECISION_MAKER {
+	if c.ServerVersion() >= MIN_SERVER_VER_DECISION_MAKER {
 		me.encodeString(order.Mifid2DecisionMaker)
 		me.encodeString(order.Mifid2DecisionAlgo)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_MIFID_EXECUTION {
+	if c.ServerVersion() >= MIN_SERVER_VER_MIFID_EXECUTION {
 		me.encodeString(order.Mifid2ExecutionTrader)
 		me.encodeString(order.Mifid2ExecutionAlgo)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_AUTO_PRICE_FOR_HEDGE {
+	if c.ServerVersion() >= MIN_SERVER_VER_AUTO_PRICE_FOR_HEDGE {
 		me.encodeBool(order.DontUseAutoPriceForHedge)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_ORDER_CONTAINER {
+	if c.ServerVersion() >= MIN_SERVER_VER_ORDER_CONTAINER {
 		me.encodeBool(order.IsOmsContainer)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_D_PEG_ORDERS {
+	if c.ServerVersion() >= MIN_SERVER_VER_D_PEG_ORDERS {
 		me.encodeBool(order.DiscretionaryUpToLimitPrice)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_PRICE_MGMT_ALGO {
+	if c.ServerVersion() >= MIN_SERVER_VER_PRICE_MGMT_ALGO {
 		me.encodeIntMax(order.UsePriceMgmtAlgo)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_DURATION {
+	if c.ServerVersion() >= MIN_SERVER_VER_DURATION {
 		me.encodeInt64(order.Duration)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_POST_TO_ATS {
+	if c.ServerVersion() >= MIN_SERVER_VER_POST_TO_ATS {
 		me.encodeInt64(order.PostToAts)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_AUTO_CANCEL_PARENT {
+	if c.ServerVersion() >= MIN_SERVER_VER_AUTO_CANCEL_PARENT {
 		me.encodeBool(order.AutoCancelParent)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_ADVANCED_ORDER_REJECT {
+	if c.ServerVersion() >= MIN_SERVER_VER_ADVANCED_ORDER_REJECT {
 		me.encodeString(order.AdvancedErrorOverride)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_MANUAL_ORDER_TIME {
+	if c.ServerVersion() >= MIN_SERVER_VER_MANUAL_ORDER_TIME {
 		me.encodeString(order.ManualOrderTime)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_PEGBEST_PEGMID_OFFSETS {
+	if c.ServerVersion() >= MIN_SERVER_VER_PEGBEST_PEGMID_OFFSETS {
 		var sendMidOffsets bool
 		if contract.Exchange == "IBKRATS" {
 			me.encodeIntMax(order.MinTradeQty)
@@ -2333,35 +2614,35 @@ func (c *EClient) PlaceOrder(orderID int64, contract *Contract, order *Order) {
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_CUSTOMER_ACCOUNT {
+	if c.ServerVersion() >= MIN_SERVER_VER_CUSTOMER_ACCOUNT {
 		me.encodeString(order.CustomerAccount)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_PROFESSIONAL_CUSTOMER {
+	if c.ServerVersion() >= MIN_SERVER_VER_PROFESSIONAL_CUSTOMER {
 		me.encodeBool(order.ProfessionalCustomer)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_RFQ_FIELDS && c.serverVersion < MIN_SERVER_VER_UNDO_RFQ_FIELDS {
+	if c.ServerVersion() >= MIN_SERVER_VER_RFQ_FIELDS && c.ServerVersion() < MIN_SERVER_VER_UNDO_RFQ_FIELDS {
 		me.encodeString("")
 		me.encodeInt64(UNSET_INT)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_INCLUDE_OVERNIGHT {
+	if c.ServerVersion() >= MIN_SERVER_VER_INCLUDE_OVERNIGHT {
 		me.encodeBool(order.IncludeOvernight)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_CME_TAGGING_FIELDS {
+	if c.ServerVersion() >= MIN_SERVER_VER_CME_TAGGING_FIELDS {
 		me.encodeInt64(order.ManualOrderIndicator)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_IMBALANCE_ONLY {
+	if c.ServerVersion() >= MIN_SERVER_VER_IMBALANCE_ONLY {
 		me.encodeBool(order.ImbalanceOnly)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) placeOrderProtoBuf(placeOrderRequestProto *protobuf.PlaceOrderRequest) {
+func (c *EClient) placeOrderProtoBuf(ctx context.Context, placeOrderRequestProto *protobuf.PlaceOrderRequest) error {
 
 	orderID := NO_VALID_ID
 	if placeOrderRequestProto.OrderId != nil {
@@ -2369,25 +2650,22 @@ func (c *EClient) placeOrderProtoBuf(placeOrderRequestProto *protobuf.PlaceOrder
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	if placeOrderRequestProto.Order != nil {
 		wrongParam := c.validateOrderParameters(placeOrderRequestProto.Order)
 		if wrongParam != "" {
-			c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code,
+			return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code,
 				UPDATE_TWS.Msg+" The following order parameter is not supported by your TWS version - "+wrongParam, "")
-			return
 		}
 	}
 
 	if placeOrderRequestProto.AttachedOrders != nil {
 		wrongParam := c.validateAttachedOrdersParameters(placeOrderRequestProto.AttachedOrders)
 		if wrongParam != "" {
-			c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code,
+			return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code,
 				UPDATE_TWS.Msg+" The following attached orders parameter is not supported by your TWS version - "+wrongParam, "")
-			return
 		}
 	}
 
@@ -2397,16 +2675,16 @@ func (c *EClient) placeOrderProtoBuf(placeOrderRequestProto *protobuf.PlaceOrder
 
 	msg, err := proto.Marshal(placeOrderRequestProto)
 	if err != nil {
-		c.wrapper.Error(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 func (c *EClient) validateOrderParameters(order *protobuf.Order) string {
-	if c.serverVersion < MIN_SERVER_VER_ADDITIONAL_ORDER_PARAMS_1 {
+	if c.ServerVersion() < MIN_SERVER_VER_ADDITIONAL_ORDER_PARAMS_1 {
 		if order.Deactivate != nil {
 			return "deactivate"
 		}
@@ -2424,7 +2702,7 @@ func (c *EClient) validateOrderParameters(order *protobuf.Order) string {
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_ADDITIONAL_ORDER_PARAMS_2 {
+	if c.ServerVersion() < MIN_SERVER_VER_ADDITIONAL_ORDER_PARAMS_2 {
 		if order.RouteMarketableToBbo != nil {
 			return "routeMarketableToBbo"
 		}
@@ -2438,7 +2716,7 @@ func (c *EClient) validateOrderParameters(order *protobuf.Order) string {
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_HEDGE_MAX_SIZE {
+	if c.ServerVersion() < MIN_SERVER_VER_HEDGE_MAX_SIZE {
 		if order.HedgeMaxSize != nil {
 			return "hedgeMaxSize"
 		}
@@ -2447,7 +2725,7 @@ func (c *EClient) validateOrderParameters(order *protobuf.Order) string {
 }
 
 func (c *EClient) validateAttachedOrdersParameters(attachedOrders *protobuf.AttachedOrders) string {
-	if c.serverVersion < MIN_SERVER_VER_ATTACHED_ORDERS {
+	if c.ServerVersion() < MIN_SERVER_VER_ATTACHED_ORDERS {
 		if attachedOrders.SlOrderId != nil {
 			return "slOrderId"
 		}
@@ -2467,24 +2745,33 @@ func (c *EClient) validateAttachedOrdersParameters(attachedOrders *protobuf.Atta
 // CancelOrder cancel an order by orderId.
 // It can only be used to cancel an order that was placed originally by a client with the same client ID
 func (c *EClient) CancelOrder(orderID int64, orderCancel OrderCancel) {
+	c.reportError(c.CancelOrderContext(context.Background(), orderID, orderCancel))
+}
+
+// CancelOrderContext is CancelOrder returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelOrderContext(ctx context.Context, orderID int64, orderCancel OrderCancel) (err error) {
+
+	defer func() {
+		if err == nil {
+			c.trades.cancelling(orderID)
+		}
+	}()
 
 	if c.useProtoBuf(CANCEL_ORDER) {
-		c.cancelOrderProtoBuf(createCancelOrderRequestProto(orderID, &orderCancel))
-		return
+		return c.cancelOrderProtoBuf(ctx, createCancelOrderRequestProto(orderID, &orderCancel))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MANUAL_ORDER_TIME && orderCancel.ManualOrderCancelTime != "" {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support manual order cancel time attribute.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MANUAL_ORDER_TIME && orderCancel.ManualOrderCancelTime != "" {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support manual order cancel time attribute.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CME_TAGGING_FIELDS && (orderCancel.ExtOperator != "" || orderCancel.ManualOrderIndicator != UNSET_INT) {
-		c.wrapper.Error(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ext operator and manual order indicator parameters.", "")
+	if c.ServerVersion() < MIN_SERVER_VER_CME_TAGGING_FIELDS && (orderCancel.ExtOperator != "" || orderCancel.ManualOrderIndicator != UNSET_INT) {
+		return NewIBError(orderID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ext operator and manual order indicator parameters.", "")
 	}
 
 	const VERSION = 1
@@ -2493,31 +2780,31 @@ func (c *EClient) CancelOrder(orderID int64, orderCancel OrderCancel) {
 
 	me.encodeMsgID(CANCEL_ORDER)
 
-	if c.serverVersion < MIN_SERVER_VER_CME_TAGGING_FIELDS {
+	if c.ServerVersion() < MIN_SERVER_VER_CME_TAGGING_FIELDS {
 		me.encodeInt(VERSION)
 	}
 
 	me.encodeInt64(orderID)
 
-	if c.serverVersion >= MIN_SERVER_VER_MANUAL_ORDER_TIME {
+	if c.ServerVersion() >= MIN_SERVER_VER_MANUAL_ORDER_TIME {
 		me.encodeString(orderCancel.ManualOrderCancelTime)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_RFQ_FIELDS && c.serverVersion < MIN_SERVER_VER_UNDO_RFQ_FIELDS {
+	if c.ServerVersion() >= MIN_SERVER_VER_RFQ_FIELDS && c.ServerVersion() < MIN_SERVER_VER_UNDO_RFQ_FIELDS {
 		me.encodeString("")
 		me.encodeString("")
 		me.encodeInt64(UNSET_INT)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_CME_TAGGING_FIELDS {
+	if c.ServerVersion() >= MIN_SERVER_VER_CME_TAGGING_FIELDS {
 		me.encodeString(orderCancel.ExtOperator)
 		me.encodeInt64(orderCancel.ManualOrderIndicator)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelOrderProtoBuf(cancelOrderRequestProto *protobuf.CancelOrderRequest) {
+func (c *EClient) cancelOrderProtoBuf(ctx context.Context, cancelOrderRequestProto *protobuf.CancelOrderRequest) error {
 
 	orderID := NO_VALID_ID
 	if cancelOrderRequestProto.OrderId != nil {
@@ -2525,8 +2812,7 @@ func (c *EClient) cancelOrderProtoBuf(cancelOrderRequestProto *protobuf.CancelOr
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(9, c)
@@ -2535,13 +2821,12 @@ func (c *EClient) cancelOrderProtoBuf(cancelOrderRequestProto *protobuf.CancelOr
 
 	msg, err := proto.Marshal(cancelOrderRequestProto)
 	if err != nil {
-		c.wrapper.Error(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(orderID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelOrderAsync cancel an order by orderId.
@@ -2551,15 +2836,19 @@ func (c *EClient) cancelOrderProtoBuf(cancelOrderRequestProto *protobuf.CancelOr
 // These orders will be associated with the client and a new orderId will be generated.
 // This association will persist over multiple API and TWS sessions.
 func (c *EClient) ReqOpenOrders() {
+	c.reportError(c.ReqOpenOrdersContext(context.Background()))
+}
+
+// ReqOpenOrdersContext is ReqOpenOrders returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqOpenOrdersContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_OPEN_ORDERS) {
-		c.reqOpenOrdersProtoBuf(createOpenOrdersRequestProto())
-		return
+		return c.reqOpenOrdersProtoBuf(ctx, createOpenOrdersRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -2569,14 +2858,13 @@ func (c *EClient) ReqOpenOrders() {
 	me.encodeMsgID(REQ_OPEN_ORDERS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqOpenOrdersProtoBuf(openOrdersRequestProto *protobuf.OpenOrdersRequest) {
+func (c *EClient) reqOpenOrdersProtoBuf(ctx context.Context, openOrdersRequestProto *protobuf.OpenOrdersRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -2585,13 +2873,12 @@ func (c *EClient) reqOpenOrdersProtoBuf(openOrdersRequestProto *protobuf.OpenOrd
 
 	msg, err := proto.Marshal(openOrdersRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqAutoOpenOrders requests that newly created TWS orders be implicitly associated with the client.
@@ -2600,15 +2887,19 @@ func (c *EClient) reqOpenOrdersProtoBuf(openOrdersRequestProto *protobuf.OpenOrd
 // if autoBind is set to TRUE, newly created TWS orders will be implicitly associated with the client.
 // If set to FALSE, no association will be made.
 func (c *EClient) ReqAutoOpenOrders(autoBind bool) {
+	c.reportError(c.ReqAutoOpenOrdersContext(context.Background(), autoBind))
+}
+
+// ReqAutoOpenOrdersContext is ReqAutoOpenOrders returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqAutoOpenOrdersContext(ctx context.Context, autoBind bool) error {
 
 	if c.useProtoBuf(REQ_AUTO_OPEN_ORDERS) {
-		c.reqAutoOpenOrdersProtoBuf(createAutoOpenOrdersRequestProto(autoBind))
-		return
+		return c.reqAutoOpenOrdersProtoBuf(ctx, createAutoOpenOrdersRequestProto(autoBind))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -2619,14 +2910,13 @@ func (c *EClient) ReqAutoOpenOrders(autoBind bool) {
 	me.encodeInt(VERSION)
 	me.encodeBool(autoBind)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqAutoOpenOrdersProtoBuf(autoOpenOrdersRequestProto *protobuf.AutoOpenOrdersRequest) {
+func (c *EClient) reqAutoOpenOrdersProtoBuf(ctx context.Context, autoOpenOrdersRequestProto *protobuf.AutoOpenOrdersRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -2635,28 +2925,31 @@ func (c *EClient) reqAutoOpenOrdersProtoBuf(autoOpenOrdersRequestProto *protobuf
 
 	msg, err := proto.Marshal(autoOpenOrdersRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqAllOpenOrders request the open orders placed from all clients and also from TWS.
 // Each open order will be fed back through the openOrder() and orderStatus() functions on the EWrapper.
 // No association is made between the returned orders and the requesting client.
 func (c *EClient) ReqAllOpenOrders() {
+	c.reportError(c.ReqAllOpenOrdersContext(context.Background()))
+}
+
+// ReqAllOpenOrdersContext is ReqAllOpenOrders returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqAllOpenOrdersContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_ALL_OPEN_ORDERS) {
-		c.reqAllOpenOrdersProtoBuf(createAllOpenOrdersRequestProto())
-		return
+		return c.reqAllOpenOrdersProtoBuf(ctx, createAllOpenOrdersRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -2666,14 +2959,13 @@ func (c *EClient) ReqAllOpenOrders() {
 	me.encodeMsgID(REQ_ALL_OPEN_ORDERS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqAllOpenOrdersProtoBuf(allOpenOrdersRequestProto *protobuf.AllOpenOrdersRequest) {
+func (c *EClient) reqAllOpenOrdersProtoBuf(ctx context.Context, allOpenOrdersRequestProto *protobuf.AllOpenOrdersRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -2682,30 +2974,39 @@ func (c *EClient) reqAllOpenOrdersProtoBuf(allOpenOrdersRequestProto *protobuf.A
 
 	msg, err := proto.Marshal(allOpenOrdersRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqGlobalCancel cancels all open orders globally. It cancels both API and TWS open orders.
 func (c *EClient) ReqGlobalCancel(orderCancel OrderCancel) {
+	c.reportError(c.ReqGlobalCancelContext(context.Background(), orderCancel))
+}
+
+// ReqGlobalCancelContext is ReqGlobalCancel returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqGlobalCancelContext(ctx context.Context, orderCancel OrderCancel) (err error) {
+
+	defer func() {
+		if err == nil {
+			c.trades.cancellingAll()
+		}
+	}()
 
 	if c.useProtoBuf(REQ_GLOBAL_CANCEL) {
-		c.reqGlobalCancelProtoBuf(createGlobalCancelRequestProto(&orderCancel))
-		return
+		return c.reqGlobalCancelProtoBuf(ctx, createGlobalCancelRequestProto(&orderCancel))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CME_TAGGING_FIELDS && (orderCancel.ExtOperator != "" || orderCancel.ManualOrderIndicator != UNSET_INT) {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ext operator and manual order indicator parameters.", "")
+	if c.ServerVersion() < MIN_SERVER_VER_CME_TAGGING_FIELDS && (orderCancel.ExtOperator != "" || orderCancel.ManualOrderIndicator != UNSET_INT) {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support ext operator and manual order indicator parameters.", "")
 	}
 
 	const VERSION = 1
@@ -2714,23 +3015,22 @@ func (c *EClient) ReqGlobalCancel(orderCancel OrderCancel) {
 
 	me.encodeMsgID(REQ_GLOBAL_CANCEL)
 
-	if c.serverVersion < MIN_SERVER_VER_CME_TAGGING_FIELDS {
+	if c.ServerVersion() < MIN_SERVER_VER_CME_TAGGING_FIELDS {
 		me.encodeInt(VERSION)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_CME_TAGGING_FIELDS {
+	if c.ServerVersion() >= MIN_SERVER_VER_CME_TAGGING_FIELDS {
 		me.encodeString(orderCancel.ExtOperator)
 		me.encodeInt64(orderCancel.ManualOrderIndicator)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqGlobalCancelProtoBuf(globalCancelRequestProto *protobuf.GlobalCancelRequest) {
+func (c *EClient) reqGlobalCancelProtoBuf(ctx context.Context, globalCancelRequestProto *protobuf.GlobalCancelRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -2739,13 +3039,12 @@ func (c *EClient) reqGlobalCancelProtoBuf(globalCancelRequestProto *protobuf.Glo
 
 	msg, err := proto.Marshal(globalCancelRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqIDs request from TWS the next valid ID that can be used when placing an order.
@@ -2753,15 +3052,19 @@ func (c *EClient) reqGlobalCancelProtoBuf(globalCancelRequestProto *protobuf.Glo
 // That ID will reflect any autobinding that has occurred (which generates new IDs and increments the next valid ID therein).
 // numIds is depreceted
 func (c *EClient) ReqIDs(numIds int64) {
+	c.reportError(c.ReqIDsContext(context.Background(), numIds))
+}
+
+// ReqIDsContext is ReqIDs returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqIDsContext(ctx context.Context, numIds int64) error {
 
 	if c.useProtoBuf(REQ_IDS) {
-		c.reqIdsProtoBuf(createIdsRequestProto(numIds))
-		return
+		return c.reqIdsProtoBuf(ctx, createIdsRequestProto(numIds))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -2772,14 +3075,13 @@ func (c *EClient) ReqIDs(numIds int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(numIds)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqIdsProtoBuf(idsRequestProto *protobuf.IdsRequest) {
+func (c *EClient) reqIdsProtoBuf(ctx context.Context, idsRequestProto *protobuf.IdsRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -2788,13 +3090,12 @@ func (c *EClient) reqIdsProtoBuf(idsRequestProto *protobuf.IdsRequest) {
 
 	msg, err := proto.Marshal(idsRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -2804,15 +3105,19 @@ func (c *EClient) reqIdsProtoBuf(idsRequestProto *protobuf.IdsRequest) {
 // ReqAccountUpdates will start getting account values, portfolio, and last update time information.
 // it is returned via EWrapper.updateAccountValue(), EWrapperi.updatePortfolio() and Wrapper.updateAccountTime().
 func (c *EClient) ReqAccountUpdates(subscribe bool, accountName string) {
+	c.reportError(c.ReqAccountUpdatesContext(context.Background(), subscribe, accountName))
+}
+
+// ReqAccountUpdatesContext is ReqAccountUpdates returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqAccountUpdatesContext(ctx context.Context, subscribe bool, accountName string) error {
 
 	if c.useProtoBuf(REQ_ACCT_DATA) {
-		c.reqAccountUpdatesProtoBuf(createAccountDataRequestProto(subscribe, accountName))
-		return
+		return c.reqAccountUpdatesProtoBuf(ctx, createAccountDataRequestProto(subscribe, accountName))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 2
@@ -2826,14 +3131,13 @@ func (c *EClient) ReqAccountUpdates(subscribe bool, accountName string) {
 	// Send the account code. This will only be used for FA clients
 	me.encodeString(accountName) // srv v9 and above
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqAccountUpdatesProtoBuf(accountDataRequestProto *protobuf.AccountDataRequest) {
+func (c *EClient) reqAccountUpdatesProtoBuf(ctx context.Context, accountDataRequestProto *protobuf.AccountDataRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -2842,12 +3146,12 @@ func (c *EClient) reqAccountUpdatesProtoBuf(accountDataRequestProto *protobuf.Ac
 
 	msg, err := proto.Marshal(accountDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqAccountSummary request and keep up to date the data that appears.
@@ -2893,15 +3197,28 @@ func (c *EClient) reqAccountUpdatesProtoBuf(accountDataRequestProto *protobuf.Ac
 //	$LEDGER:CURRENCY - Single flag to relay all cash balance tags*, only in	the specified currency.
 //	$LEDGER:ALL - Single flag to relay all cash balance tags* in all currencies.
 func (c *EClient) ReqAccountSummary(reqID int64, groupName string, tags string) {
+	c.reportError(c.ReqAccountSummaryContext(context.Background(), reqID, groupName, tags))
+}
+
+// ReqAccountSummaryContext is ReqAccountSummary returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqAccountSummaryContext(ctx context.Context, reqID int64, groupName string, tags string) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		c.subscriptions.record(SubscriptionAccountSummary, reqID, nil, func(c *EClient) {
+			c.ReqAccountSummary(reqID, groupName, tags)
+		}, groupName, tags)
+	}()
 
 	if c.useProtoBuf(REQ_ACCOUNT_SUMMARY) {
-		c.reqAccountSummaryProtoBuf(createAccountSummaryRequestProto(reqID, groupName, tags))
-		return
+		return c.reqAccountSummaryProtoBuf(ctx, createAccountSummaryRequestProto(reqID, groupName, tags))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -2914,10 +3231,10 @@ func (c *EClient) ReqAccountSummary(reqID int64, groupName string, tags string)
 	me.encodeString(groupName)
 	me.encodeString(tags)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqAccountSummaryProtoBuf(accountSummaryRequestProto *protobuf.AccountSummaryRequest) {
+func (c *EClient) reqAccountSummaryProtoBuf(ctx context.Context, accountSummaryRequestProto *protobuf.AccountSummaryRequest) error {
 
 	reqID := NO_VALID_ID
 	if accountSummaryRequestProto.ReqId != nil {
@@ -2925,8 +3242,7 @@ func (c *EClient) reqAccountSummaryProtoBuf(accountSummaryRequestProto *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -2935,27 +3251,32 @@ func (c *EClient) reqAccountSummaryProtoBuf(accountSummaryRequestProto *protobuf
 
 	msg, err := proto.Marshal(accountSummaryRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelAccountSummary cancels the request for Account Window Summary tab data.
 // reqId is the ID of the data request being canceled.
 func (c *EClient) CancelAccountSummary(reqID int64) {
+	c.reportError(c.CancelAccountSummaryContext(context.Background(), reqID))
+}
+
+// CancelAccountSummaryContext is CancelAccountSummary returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelAccountSummaryContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionAccountSummary, reqID)
 
 	if c.useProtoBuf(CANCEL_ACCOUNT_SUMMARY) {
-		c.cancelAccountSummaryProtoBuf(createCancelAccountSummaryRequestProto(reqID))
-		return
+		return c.cancelAccountSummaryProtoBuf(ctx, createCancelAccountSummaryRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -2966,10 +3287,10 @@ func (c *EClient) CancelAccountSummary(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelAccountSummaryProtoBuf(cancelAccountSummaryRequestProto *protobuf.CancelAccountSummary) {
+func (c *EClient) cancelAccountSummaryProtoBuf(ctx context.Context, cancelAccountSummaryRequestProto *protobuf.CancelAccountSummary) error {
 
 	reqID := NO_VALID_ID
 	if cancelAccountSummaryRequestProto.ReqId != nil {
@@ -2977,8 +3298,7 @@ func (c *EClient) cancelAccountSummaryProtoBuf(cancelAccountSummaryRequestProto
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -2987,31 +3307,41 @@ func (c *EClient) cancelAccountSummaryProtoBuf(cancelAccountSummaryRequestProto
 
 	msg, err := proto.Marshal(cancelAccountSummaryRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqPositions requests real-time position data for all accounts.
 func (c *EClient) ReqPositions() {
+	c.reportError(c.ReqPositionsContext(context.Background()))
+}
+
+// ReqPositionsContext is ReqPositions returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqPositionsContext(ctx context.Context) error {
+	if err := c.reqPositions(ctx); err != nil {
+		return err
+	}
+	c.calls.positionsSubscribed.Store(true)
+	return nil
+}
+
+func (c *EClient) reqPositions(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_POSITIONS) {
-		c.reqPositionsProtoBuf(createPositionsRequestProto())
-		return
+		return c.reqPositionsProtoBuf(ctx, createPositionsRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_POSITIONS {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support positions request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_POSITIONS {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support positions request.", "")
 	}
 
 	const VERSION = 1
@@ -3021,14 +3351,13 @@ func (c *EClient) ReqPositions() {
 	me.encodeMsgID(REQ_POSITIONS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqPositionsProtoBuf(positionsRequestProto *protobuf.PositionsRequest) {
+func (c *EClient) reqPositionsProtoBuf(ctx context.Context, positionsRequestProto *protobuf.PositionsRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -3037,32 +3366,42 @@ func (c *EClient) reqPositionsProtoBuf(positionsRequestProto *protobuf.Positions
 
 	msg, err := proto.Marshal(positionsRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 
 }
 
 // CancelPositions cancels real-time position updates.
 func (c *EClient) CancelPositions() {
+	c.reportError(c.CancelPositionsContext(context.Background()))
+}
+
+// CancelPositionsContext is CancelPositions returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelPositionsContext(ctx context.Context) error {
+	if err := c.cancelPositions(ctx); err != nil {
+		return err
+	}
+	c.calls.positionsSubscribed.Store(false)
+	return nil
+}
+
+func (c *EClient) cancelPositions(ctx context.Context) error {
 
 	if c.useProtoBuf(CANCEL_POSITIONS) {
-		c.cancelPositionsProtoBuf(createCancelPositionsRequestProto())
-		return
+		return c.cancelPositionsProtoBuf(ctx, createCancelPositionsRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_POSITIONS {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support positions request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_POSITIONS {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support positions request.", "")
 	}
 
 	const VERSION = 1
@@ -3072,14 +3411,13 @@ func (c *EClient) CancelPositions() {
 	me.encodeMsgID(CANCEL_POSITIONS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelPositionsProtoBuf(cancelPositionsRequestProto *protobuf.CancelPositions) {
+func (c *EClient) cancelPositionsProtoBuf(ctx context.Context, cancelPositionsRequestProto *protobuf.CancelPositions) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 	me := NewMsgEncoder(2, c)
 
@@ -3087,32 +3425,43 @@ func (c *EClient) cancelPositionsProtoBuf(cancelPositionsRequestProto *protobuf.
 
 	msg, err := proto.Marshal(cancelPositionsRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqPositionsMulti requests the positions for account and/or model.
 // Results are delivered via EWrapper.positionMulti() and EWrapper.positionMultiEnd().
 func (c *EClient) ReqPositionsMulti(reqID int64, account string, modelCode string) {
+	c.reportError(c.ReqPositionsMultiContext(context.Background(), reqID, account, modelCode))
+}
+
+// ReqPositionsMultiContext is ReqPositionsMulti returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqPositionsMultiContext(ctx context.Context, reqID int64, account string, modelCode string) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		c.subscriptions.record(SubscriptionPositionsMulti, reqID, nil, func(c *EClient) {
+			c.ReqPositionsMulti(reqID, account, modelCode)
+		}, account, modelCode)
+	}()
 
 	if c.useProtoBuf(REQ_POSITIONS_MULTI) {
-		c.reqPositionsMultiProtoBuf(createPositionsMultiRequestProto(reqID, account, modelCode))
-		return
+		return c.reqPositionsMultiProtoBuf(ctx, createPositionsMultiRequestProto(reqID, account, modelCode))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MODELS_SUPPORT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support positions multi request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MODELS_SUPPORT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support positions multi request.", "")
 	}
 
 	const VERSION = 1
@@ -3125,10 +3474,10 @@ func (c *EClient) ReqPositionsMulti(reqID int64, account string, modelCode strin
 	me.encodeString(account)
 	me.encodeString(modelCode)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqPositionsMultiProtoBuf(positionsMultiRequestProto *protobuf.PositionsMultiRequest) {
+func (c *EClient) reqPositionsMultiProtoBuf(ctx context.Context, positionsMultiRequestProto *protobuf.PositionsMultiRequest) error {
 
 	reqID := NO_VALID_ID
 	if positionsMultiRequestProto.ReqId != nil {
@@ -3136,8 +3485,7 @@ func (c *EClient) reqPositionsMultiProtoBuf(positionsMultiRequestProto *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -3146,31 +3494,35 @@ func (c *EClient) reqPositionsMultiProtoBuf(positionsMultiRequestProto *protobuf
 
 	msg, err := proto.Marshal(positionsMultiRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelPositionsMulti cancels the positions update of assigned account.
 func (c *EClient) CancelPositionsMulti(reqID int64) {
+	c.reportError(c.CancelPositionsMultiContext(context.Background(), reqID))
+}
+
+// CancelPositionsMultiContext is CancelPositionsMulti returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelPositionsMultiContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionPositionsMulti, reqID)
 
 	if c.useProtoBuf(CANCEL_POSITIONS_MULTI) {
-		c.cancelPositionsMultiProtoBuf(createCancelPositionsMultiRequestProto(reqID))
-		return
+		return c.cancelPositionsMultiProtoBuf(ctx, createCancelPositionsMultiRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MODELS_SUPPORT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support cancel positions multi request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MODELS_SUPPORT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support cancel positions multi request.", "")
 	}
 
 	const VERSION = 1
@@ -3181,10 +3533,10 @@ func (c *EClient) CancelPositionsMulti(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelPositionsMultiProtoBuf(cancelPositionsMultiRequestProto *protobuf.CancelPositionsMulti) {
+func (c *EClient) cancelPositionsMultiProtoBuf(ctx context.Context, cancelPositionsMultiRequestProto *protobuf.CancelPositionsMulti) error {
 
 	reqID := NO_VALID_ID
 	if cancelPositionsMultiRequestProto.ReqId != nil {
@@ -3192,8 +3544,7 @@ func (c *EClient) cancelPositionsMultiProtoBuf(cancelPositionsMultiRequestProto
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -3202,31 +3553,33 @@ func (c *EClient) cancelPositionsMultiProtoBuf(cancelPositionsMultiRequestProto
 
 	msg, err := proto.Marshal(cancelPositionsMultiRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqAccountUpdatesMulti requests account updates for account and/or model.
 func (c *EClient) ReqAccountUpdatesMulti(reqID int64, account string, modelCode string, ledgerAndNLV bool) {
+	c.reportError(c.ReqAccountUpdatesMultiContext(context.Background(), reqID, account, modelCode, ledgerAndNLV))
+}
+
+// ReqAccountUpdatesMultiContext is ReqAccountUpdatesMulti returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqAccountUpdatesMultiContext(ctx context.Context, reqID int64, account string, modelCode string, ledgerAndNLV bool) error {
 
 	if c.useProtoBuf(REQ_ACCOUNT_UPDATES_MULTI) {
-		c.reqAccountUpdatesMultiProtoBuf(createAccountUpdatesMultiRequestProto(reqID, account, modelCode, ledgerAndNLV))
-		return
+		return c.reqAccountUpdatesMultiProtoBuf(ctx, createAccountUpdatesMultiRequestProto(reqID, account, modelCode, ledgerAndNLV))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MODELS_SUPPORT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support account updates multi request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MODELS_SUPPORT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support account updates multi request.", "")
 	}
 
 	const VERSION = 1
@@ -3240,10 +3593,10 @@ func (c *EClient) ReqAccountUpdatesMulti(reqID int64, account string, modelCode
 	me.encodeString(modelCode)
 	me.encodeBool(ledgerAndNLV)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqAccountUpdatesMultiProtoBuf(accountUpdatesMultiRequestProto *protobuf.AccountUpdatesMultiRequest) {
+func (c *EClient) reqAccountUpdatesMultiProtoBuf(ctx context.Context, accountUpdatesMultiRequestProto *protobuf.AccountUpdatesMultiRequest) error {
 
 	reqID := NO_VALID_ID
 	if accountUpdatesMultiRequestProto.ReqId != nil {
@@ -3251,8 +3604,7 @@ func (c *EClient) reqAccountUpdatesMultiProtoBuf(accountUpdatesMultiRequestProto
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(6, c)
@@ -3261,31 +3613,33 @@ func (c *EClient) reqAccountUpdatesMultiProtoBuf(accountUpdatesMultiRequestProto
 
 	msg, err := proto.Marshal(accountUpdatesMultiRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelAccountUpdatesMulti cancels account update for reqID.
 func (c *EClient) CancelAccountUpdatesMulti(reqID int64) {
+	c.reportError(c.CancelAccountUpdatesMultiContext(context.Background(), reqID))
+}
+
+// CancelAccountUpdatesMultiContext is CancelAccountUpdatesMulti returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelAccountUpdatesMultiContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_ACCOUNT_UPDATES_MULTI) {
-		c.cancelAccountUpdatesMultiProtoBuf(createCancelAccountUpdatesMultiRequestProto(reqID))
-		return
+		return c.cancelAccountUpdatesMultiProtoBuf(ctx, createCancelAccountUpdatesMultiRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MODELS_SUPPORT {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support cancel account updates multi request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MODELS_SUPPORT {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support cancel account updates multi request.", "")
 	}
 
 	const VERSION = 1
@@ -3296,10 +3650,10 @@ func (c *EClient) CancelAccountUpdatesMulti(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelAccountUpdatesMultiProtoBuf(cancelAccountUpdatesMultiRequestProto *protobuf.CancelAccountUpdatesMulti) {
+func (c *EClient) cancelAccountUpdatesMultiProtoBuf(ctx context.Context, cancelAccountUpdatesMultiRequestProto *protobuf.CancelAccountUpdatesMulti) error {
 
 	reqID := NO_VALID_ID
 	if cancelAccountUpdatesMultiRequestProto.ReqId != nil {
@@ -3307,8 +3661,7 @@ func (c *EClient) cancelAccountUpdatesMultiProtoBuf(cancelAccountUpdatesMultiReq
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -3317,13 +3670,12 @@ func (c *EClient) cancelAccountUpdatesMultiProtoBuf(cancelAccountUpdatesMultiReq
 
 	msg, err := proto.Marshal(cancelAccountUpdatesMultiRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -3332,20 +3684,32 @@ func (c *EClient) cancelAccountUpdatesMultiProtoBuf(cancelAccountUpdatesMultiReq
 
 // ReqPnL requests and subscribe the PnL of assigned account.
 func (c *EClient) ReqPnL(reqID int64, account string, modelCode string) {
+	c.reportError(c.ReqPnLContext(context.Background(), reqID, account, modelCode))
+}
+
+// ReqPnLContext is ReqPnL returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqPnLContext(ctx context.Context, reqID int64, account string, modelCode string) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		c.subscriptions.record(SubscriptionPnL, reqID, nil, func(c *EClient) {
+			c.ReqPnL(reqID, account, modelCode)
+		}, account, modelCode)
+	}()
 
 	if c.useProtoBuf(REQ_PNL) {
-		c.reqPnLProtoBuf(createPnLRequestProto(reqID, account, modelCode))
-		return
+		return c.reqPnLProtoBuf(ctx, createPnLRequestProto(reqID, account, modelCode))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PNL {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support PnL request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PNL {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support PnL request.", "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -3355,10 +3719,10 @@ func (c *EClient) ReqPnL(reqID int64, account string, modelCode string) {
 	me.encodeString(account)
 	me.encodeString(modelCode)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqPnLProtoBuf(pnlRequestProto *protobuf.PnLRequest) {
+func (c *EClient) reqPnLProtoBuf(ctx context.Context, pnlRequestProto *protobuf.PnLRequest) error {
 
 	reqID := NO_VALID_ID
 	if pnlRequestProto.ReqId != nil {
@@ -3366,8 +3730,7 @@ func (c *EClient) reqPnLProtoBuf(pnlRequestProto *protobuf.PnLRequest) {
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -3376,31 +3739,35 @@ func (c *EClient) reqPnLProtoBuf(pnlRequestProto *protobuf.PnLRequest) {
 
 	msg, err := proto.Marshal(pnlRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelPnL cancels the PnL update of assigned account.
 func (c *EClient) CancelPnL(reqID int64) {
+	c.reportError(c.CancelPnLContext(context.Background(), reqID))
+}
+
+// CancelPnLContext is CancelPnL returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelPnLContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionPnL, reqID)
 
 	if c.useProtoBuf(CANCEL_PNL) {
-		c.cancelPnLProtoBuf(createCancelPnLProto(reqID))
-		return
+		return c.cancelPnLProtoBuf(ctx, createCancelPnLProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PNL {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support PnL request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PNL {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support PnL request.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -3408,10 +3775,10 @@ func (c *EClient) CancelPnL(reqID int64) {
 	me.encodeMsgID(CANCEL_PNL)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelPnLProtoBuf(cancelPnLProto *protobuf.CancelPnL) {
+func (c *EClient) cancelPnLProtoBuf(ctx context.Context, cancelPnLProto *protobuf.CancelPnL) error {
 
 	reqID := NO_VALID_ID
 	if cancelPnLProto.ReqId != nil {
@@ -3419,8 +3786,7 @@ func (c *EClient) cancelPnLProtoBuf(cancelPnLProto *protobuf.CancelPnL) {
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -3429,31 +3795,42 @@ func (c *EClient) cancelPnLProtoBuf(cancelPnLProto *protobuf.CancelPnL) {
 
 	msg, err := proto.Marshal(cancelPnLProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqPnLSingle request and subscribe the single contract PnL of assigned account.
 func (c *EClient) ReqPnLSingle(reqID int64, account string, modelCode string, contractID int64) {
+	c.reportError(c.ReqPnLSingleContext(context.Background(), reqID, account, modelCode, contractID))
+}
+
+// ReqPnLSingleContext is ReqPnLSingle returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqPnLSingleContext(ctx context.Context, reqID int64, account string, modelCode string, contractID int64) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		c.subscriptions.record(SubscriptionPnLSingle, reqID, nil, func(c *EClient) {
+			c.ReqPnLSingle(reqID, account, modelCode, contractID)
+		}, account, modelCode, contractID)
+	}()
 
 	if c.useProtoBuf(REQ_PNL_SINGLE) {
-		c.reqPnLSingleProtoBuf(createPnLSingleRequestProto(reqID, account, modelCode, contractID))
-		return
+		return c.reqPnLSingleProtoBuf(ctx, createPnLSingleRequestProto(reqID, account, modelCode, contractID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PNL {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support PnL request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PNL {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support PnL request.", "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -3464,10 +3841,10 @@ func (c *EClient) ReqPnLSingle(reqID int64, account string, modelCode string, co
 	me.encodeString(modelCode)
 	me.encodeInt64(contractID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqPnLSingleProtoBuf(pnlSingleRequestProto *protobuf.PnLSingleRequest) {
+func (c *EClient) reqPnLSingleProtoBuf(ctx context.Context, pnlSingleRequestProto *protobuf.PnLSingleRequest) error {
 
 	reqID := NO_VALID_ID
 	if pnlSingleRequestProto.ReqId != nil {
@@ -3475,8 +3852,7 @@ func (c *EClient) reqPnLSingleProtoBuf(pnlSingleRequestProto *protobuf.PnLSingle
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -3485,31 +3861,35 @@ func (c *EClient) reqPnLSingleProtoBuf(pnlSingleRequestProto *protobuf.PnLSingle
 
 	msg, err := proto.Marshal(pnlSingleRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelPnLSingle cancel the single contract PnL update of assigned account.
 func (c *EClient) CancelPnLSingle(reqID int64) {
+	c.reportError(c.CancelPnLSingleContext(context.Background(), reqID))
+}
+
+// CancelPnLSingleContext is CancelPnLSingle returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelPnLSingleContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionPnLSingle, reqID)
 
 	if c.useProtoBuf(CANCEL_PNL_SINGLE) {
-		c.cancelPnLSingleProtoBuf(createCancelPnLSingleProto(reqID))
-		return
+		return c.cancelPnLSingleProtoBuf(ctx, createCancelPnLSingleProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PNL {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support PnL request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PNL {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support PnL request.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -3517,10 +3897,10 @@ func (c *EClient) CancelPnLSingle(reqID int64) {
 	me.encodeMsgID(CANCEL_PNL_SINGLE)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelPnLSingleProtoBuf(cancelPnLSingleProto *protobuf.CancelPnLSingle) {
+func (c *EClient) cancelPnLSingleProtoBuf(ctx context.Context, cancelPnLSingleProto *protobuf.CancelPnLSingle) error {
 
 	reqID := NO_VALID_ID
 	if cancelPnLSingleProto.ReqId != nil {
@@ -3528,8 +3908,7 @@ func (c *EClient) cancelPnLSingleProtoBuf(cancelPnLSingleProto *protobuf.CancelP
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -3538,13 +3917,12 @@ func (c *EClient) cancelPnLSingleProtoBuf(cancelPnLSingleProto *protobuf.CancelP
 
 	msg, err := proto.Marshal(cancelPnLSingleProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -3557,24 +3935,27 @@ func (c *EClient) cancelPnLSingleProtoBuf(cancelPnLSingleProto *protobuf.CancelP
 // execFilter contains attributes that describe the filter criteria used to determine which execution reports are returned.
 // NOTE: Time format must be 'yyyymmdd-hh:mm:ss' Eg: '20030702-14:55'
 func (c *EClient) ReqExecutions(reqID int64, execFilter *ExecutionFilter) {
+	c.reportError(c.ReqExecutionsContext(context.Background(), reqID, execFilter))
+}
+
+// ReqExecutionsContext is ReqExecutions returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqExecutionsContext(ctx context.Context, reqID int64, execFilter *ExecutionFilter) error {
 
 	if execFilter == nil {
 		execFilter = NewExecutionFilter()
 	}
 
 	if c.useProtoBuf(REQ_EXECUTIONS) {
-		c.reqExecutionProtobuf(createExecutionRequestProto(reqID, execFilter))
-		return
+		return c.reqExecutionProtobuf(ctx, createExecutionRequestProto(reqID, execFilter))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS && (execFilter.LastNDays != UNSET_INT || execFilter.SpecificDates != nil) {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support last N days and specific dates parameters.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS && (execFilter.LastNDays != UNSET_INT || execFilter.SpecificDates != nil) {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support last N days and specific dates parameters.", "")
 	}
 
 	const VERSION = 3
@@ -3584,7 +3965,7 @@ func (c *EClient) ReqExecutions(reqID int64, execFilter *ExecutionFilter) {
 	me.encodeMsgID(REQ_EXECUTIONS)
 	me.encodeInt(VERSION)
 
-	if c.serverVersion >= MIN_SERVER_VER_EXECUTION_DATA_CHAIN {
+	if c.ServerVersion() >= MIN_SERVER_VER_EXECUTION_DATA_CHAIN {
 		me.encodeInt64(reqID)
 	}
 
@@ -3596,7 +3977,7 @@ func (c *EClient) ReqExecutions(reqID int64, execFilter *ExecutionFilter) {
 	me.encodeString(execFilter.Exchange)
 	me.encodeString(execFilter.Side)
 
-	if c.serverVersion >= MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS {
+	if c.ServerVersion() >= MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS {
 		me.encodeInt64(execFilter.LastNDays)
 		specificDatesCount := len(execFilter.SpecificDates)
 		me.encodeInt(specificDatesCount)
@@ -3605,10 +3986,10 @@ func (c *EClient) ReqExecutions(reqID int64, execFilter *ExecutionFilter) {
 		}
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqExecutionProtobuf(executionRequestProto *protobuf.ExecutionRequest) {
+func (c *EClient) reqExecutionProtobuf(ctx context.Context, executionRequestProto *protobuf.ExecutionRequest) error {
 
 	reqID := NO_VALID_ID
 	if executionRequestProto.ReqId != nil {
@@ -3616,8 +3997,7 @@ func (c *EClient) reqExecutionProtobuf(executionRequestProto *protobuf.Execution
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(14, c)
@@ -3626,12 +4006,12 @@ func (c *EClient) reqExecutionProtobuf(executionRequestProto *protobuf.Execution
 
 	msg, err := proto.Marshal(executionRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -3641,35 +4021,35 @@ func (c *EClient) reqExecutionProtobuf(executionRequestProto *protobuf.Execution
 // ReqContractDetails downloads all details for a particular underlying.
 // The contract details will be received via the contractDetails() function on the EWrapper.
 func (c *EClient) ReqContractDetails(reqID int64, contract *Contract) {
+	c.reportError(c.ReqContractDetailsContext(context.Background(), reqID, contract))
+}
+
+// ReqContractDetailsContext is ReqContractDetails returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqContractDetailsContext(ctx context.Context, reqID int64, contract *Contract) error {
 
 	if c.useProtoBuf(REQ_CONTRACT_DATA) {
-		c.reqContractDataProtoBuf(createContractDataRequestProto(reqID, contract))
-		return
+		return c.reqContractDataProtoBuf(ctx, createContractDataRequestProto(reqID, contract))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SEC_ID_TYPE && (contract.SecIDType != "" || contract.SecID != "") {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support secIdType and secId parameters.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SEC_ID_TYPE && (contract.SecIDType != "" || contract.SecID != "") {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support secIdType and secId parameters.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in reqContractDetails.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support tradingClass parameter in reqContractDetails.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING && contract.PrimaryExchange != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support primaryExchange parameter in reqContractDetails.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING && contract.PrimaryExchange != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support primaryExchange parameter in reqContractDetails.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_BOND_ISSUERID && contract.IssuerID != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support issuerId parameter in reqContractDetails.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_BOND_ISSUERID && contract.IssuerID != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support issuerId parameter in reqContractDetails.", "")
 	}
 
 	const VERSION = 8
@@ -3679,7 +4059,7 @@ func (c *EClient) ReqContractDetails(reqID int64, contract *Contract) {
 	me.encodeMsgID(REQ_CONTRACT_DATA)
 	me.encodeInt(VERSION)
 
-	if c.serverVersion >= MIN_SERVER_VER_CONTRACT_DATA_CHAIN {
+	if c.ServerVersion() >= MIN_SERVER_VER_CONTRACT_DATA_CHAIN {
 		me.encodeInt64(reqID)
 	}
 
@@ -3691,10 +4071,10 @@ func (c *EClient) ReqContractDetails(reqID int64, contract *Contract) {
 	me.encodeString(contract.Right)
 	me.encodeString(contract.Multiplier) // srv v15 and above
 
-	if c.serverVersion >= MIN_SERVER_VER_PRIMARYEXCH {
+	if c.ServerVersion() >= MIN_SERVER_VER_PRIMARYEXCH {
 		me.encodeString(contract.Exchange)
 		me.encodeString(contract.PrimaryExchange)
-	} else if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	} else if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		if contract.PrimaryExchange != "" && (contract.Exchange == "BEST" || contract.Exchange == "SMART") {
 			me.encodeString(contract.Exchange + ":" + contract.PrimaryExchange)
 		} else {
@@ -3705,25 +4085,25 @@ func (c *EClient) ReqContractDetails(reqID int64, contract *Contract) {
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 
 	}
 	me.encodeBool(contract.IncludeExpired) //  srv v31 and above
 
-	if c.serverVersion >= MIN_SERVER_VER_SEC_ID_TYPE {
+	if c.ServerVersion() >= MIN_SERVER_VER_SEC_ID_TYPE {
 		me.encodeString(contract.SecIDType)
 		me.encodeString(contract.SecID)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_BOND_ISSUERID {
+	if c.ServerVersion() >= MIN_SERVER_VER_BOND_ISSUERID {
 		me.encodeString(contract.IssuerID)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqContractDataProtoBuf(contractDataRequestProto *protobuf.ContractDataRequest) {
+func (c *EClient) reqContractDataProtoBuf(ctx context.Context, contractDataRequestProto *protobuf.ContractDataRequest) error {
 
 	reqID := NO_VALID_ID
 	if contractDataRequestProto.ReqId != nil {
@@ -3731,8 +4111,7 @@ func (c *EClient) reqContractDataProtoBuf(contractDataRequestProto *protobuf.Con
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(21, c)
@@ -3741,13 +4120,12 @@ func (c *EClient) reqContractDataProtoBuf(contractDataRequestProto *protobuf.Con
 
 	msg, err := proto.Marshal(contractDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -3756,34 +4134,36 @@ func (c *EClient) reqContractDataProtoBuf(contractDataRequestProto *protobuf.Con
 
 // ReqMktDepthExchanges requests market depth exchanges.
 func (c *EClient) ReqMktDepthExchanges() {
+	c.reportError(c.ReqMktDepthExchangesContext(context.Background()))
+}
+
+// ReqMktDepthExchangesContext is ReqMktDepthExchanges returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqMktDepthExchangesContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_MKT_DEPTH_EXCHANGES) {
-		c.reqMarketDepthExchangesProtoBuf(createMarketDepthExchangesRequestProto())
-		return
+		return c.reqMarketDepthExchangesProtoBuf(ctx, createMarketDepthExchangesRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_MKT_DEPTH_EXCHANGES {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support market depth exchanges request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_MKT_DEPTH_EXCHANGES {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support market depth exchanges request.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
 
 	me.encodeMsgID(REQ_MKT_DEPTH_EXCHANGES)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqMarketDepthExchangesProtoBuf(marketDepthExchangesRequestProto *protobuf.MarketDepthExchangesRequest) {
+func (c *EClient) reqMarketDepthExchangesProtoBuf(ctx context.Context, marketDepthExchangesRequestProto *protobuf.MarketDepthExchangesRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -3792,13 +4172,12 @@ func (c *EClient) reqMarketDepthExchangesProtoBuf(marketDepthExchangesRequestPro
 
 	msg, err := proto.Marshal(marketDepthExchangesRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqMktDepth requests the market depth for a specific contract.
@@ -3813,32 +4192,43 @@ func (c *EClient) reqMarketDepthExchangesProtoBuf(marketDepthExchangesRequestPro
 // isSmartDepth	specifies SMART depth request.
 // mktDepthOptions is for internal use only. Use default value XYZ.
 func (c *EClient) ReqMktDepth(reqID int64, contract *Contract, numRows int, isSmartDepth bool, mktDepthOptions []TagValue) {
+	c.reportError(c.ReqMktDepthContext(context.Background(), reqID, contract, numRows, isSmartDepth, mktDepthOptions))
+}
+
+// ReqMktDepthContext is ReqMktDepth returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqMktDepthContext(ctx context.Context, reqID int64, contract *Contract, numRows int, isSmartDepth bool, mktDepthOptions []TagValue) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		contract := contract.clone()
+		c.subscriptions.record(SubscriptionMktDepth, reqID, contract, func(c *EClient) {
+			c.ReqMktDepth(reqID, contract, numRows, isSmartDepth, mktDepthOptions)
+		}, numRows, isSmartDepth, mktDepthOptions)
+	}()
 
 	if c.useProtoBuf(REQ_MKT_DEPTH) {
-		c.reqMarketDepthProtoBuf(createMarketDepthRequestProto(reqID, contract, int64(numRows), isSmartDepth, mktDepthOptions))
-		return
+		return c.reqMarketDepthProtoBuf(ctx, createMarketDepthRequestProto(reqID, contract, int64(numRows), isSmartDepth, mktDepthOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS {
 		if contract.TradingClass != "" || contract.ConID > 0 {
-			c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support conId and tradingClass parameters in reqMktDepth.", "")
-			return
+			return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support conId and tradingClass parameters in reqMktDepth.", "")
 		}
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SMART_DEPTH && isSmartDepth {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support SMART depth request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SMART_DEPTH && isSmartDepth {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support SMART depth request.", "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_MKT_DEPTH_PRIM_EXCHANGE && contract.PrimaryExchange != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support primaryExchange parameter in reqMktDepth.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_MKT_DEPTH_PRIM_EXCHANGE && contract.PrimaryExchange != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support primaryExchange parameter in reqMktDepth.", "")
 	}
 
 	const VERSION = 5
@@ -3851,7 +4241,7 @@ func (c *EClient) ReqMktDepth(reqID int64, contract *Contract, numRows int, isSm
 	me.encodeInt64(reqID)
 
 	// send contract fields
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeInt64(contract.ConID)
 	}
 
@@ -3863,32 +4253,32 @@ func (c *EClient) ReqMktDepth(reqID int64, contract *Contract, numRows int, isSm
 	me.encodeString(contract.Multiplier) // srv v15 and above
 	me.encodeString(contract.Exchange)
 
-	if c.serverVersion >= MIN_SERVER_VER_MKT_DEPTH_PRIM_EXCHANGE {
+	if c.ServerVersion() >= MIN_SERVER_VER_MKT_DEPTH_PRIM_EXCHANGE {
 		me.encodeString(contract.PrimaryExchange)
 	}
 
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
 	me.encodeInt(numRows) // srv v19 and above
 
-	if c.serverVersion >= MIN_SERVER_VER_SMART_DEPTH {
+	if c.ServerVersion() >= MIN_SERVER_VER_SMART_DEPTH {
 		me.encodeBool(isSmartDepth)
 	}
 
 	// send mktDepthOptions parameter
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(mktDepthOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqMarketDepthProtoBuf(marketDepthRequestProto *protobuf.MarketDepthRequest) {
+func (c *EClient) reqMarketDepthProtoBuf(ctx context.Context, marketDepthRequestProto *protobuf.MarketDepthRequest) error {
 
 	reqID := NO_VALID_ID
 	if marketDepthRequestProto.ReqId != nil {
@@ -3896,8 +4286,7 @@ func (c *EClient) reqMarketDepthProtoBuf(marketDepthRequestProto *protobuf.Marke
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(17, c)
@@ -3906,31 +4295,35 @@ func (c *EClient) reqMarketDepthProtoBuf(marketDepthRequestProto *protobuf.Marke
 
 	msg, err := proto.Marshal(marketDepthRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelMktDepth cancels market depth updates.
 func (c *EClient) CancelMktDepth(reqID int64, isSmartDepth bool) {
+	c.reportError(c.CancelMktDepthContext(context.Background(), reqID, isSmartDepth))
+}
+
+// CancelMktDepthContext is CancelMktDepth returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelMktDepthContext(ctx context.Context, reqID int64, isSmartDepth bool) error {
+
+	c.subscriptions.remove(SubscriptionMktDepth, reqID)
 
 	if c.useProtoBuf(CANCEL_MKT_DEPTH) {
-		c.cancelMarketDepthProtoBuf(createCancelMarketDepthProto(reqID, isSmartDepth))
-		return
+		return c.cancelMarketDepthProtoBuf(ctx, createCancelMarketDepthProto(reqID, isSmartDepth))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SMART_DEPTH && isSmartDepth {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support SMART depth cancel.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SMART_DEPTH && isSmartDepth {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support SMART depth cancel.", "")
 	}
 
 	const VERSION = 1
@@ -3941,14 +4334,14 @@ func (c *EClient) CancelMktDepth(reqID int64, isSmartDepth bool) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	if c.serverVersion >= MIN_SERVER_VER_SMART_DEPTH {
+	if c.ServerVersion() >= MIN_SERVER_VER_SMART_DEPTH {
 		me.encodeBool(isSmartDepth)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelMarketDepthProtoBuf(cancelMarketDepthProto *protobuf.CancelMarketDepth) {
+func (c *EClient) cancelMarketDepthProtoBuf(ctx context.Context, cancelMarketDepthProto *protobuf.CancelMarketDepth) error {
 
 	reqID := NO_VALID_ID
 	if cancelMarketDepthProto.ReqId != nil {
@@ -3956,8 +4349,7 @@ func (c *EClient) cancelMarketDepthProtoBuf(cancelMarketDepthProto *protobuf.Can
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -3966,13 +4358,12 @@ func (c *EClient) cancelMarketDepthProtoBuf(cancelMarketDepthProto *protobuf.Can
 
 	msg, err := proto.Marshal(cancelMarketDepthProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -3985,15 +4376,19 @@ func (c *EClient) cancelMarketDepthProtoBuf(cancelMarketDepthProto *protobuf.Can
 // If allMsgs sets to TRUE, returns all the existing bulletins for the currencyent day and any new ones.
 // If allMsgs sets to FALSE, will only return new bulletins.
 func (c *EClient) ReqNewsBulletins(allMsgs bool) {
+	c.reportError(c.ReqNewsBulletinsContext(context.Background(), allMsgs))
+}
+
+// ReqNewsBulletinsContext is ReqNewsBulletins returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqNewsBulletinsContext(ctx context.Context, allMsgs bool) error {
 
 	if c.useProtoBuf(REQ_NEWS_BULLETINS) {
-		c.reqNewsBulletinsProtoBuf(createNewsBulletinsRequestProto(allMsgs))
-		return
+		return c.reqNewsBulletinsProtoBuf(ctx, createNewsBulletinsRequestProto(allMsgs))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4004,14 +4399,13 @@ func (c *EClient) ReqNewsBulletins(allMsgs bool) {
 	me.encodeInt(VERSION)
 	me.encodeBool(allMsgs)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqNewsBulletinsProtoBuf(newsBulletinsRequestProto *protobuf.NewsBulletinsRequest) {
+func (c *EClient) reqNewsBulletinsProtoBuf(ctx context.Context, newsBulletinsRequestProto *protobuf.NewsBulletinsRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -4020,25 +4414,29 @@ func (c *EClient) reqNewsBulletinsProtoBuf(newsBulletinsRequestProto *protobuf.N
 
 	msg, err := proto.Marshal(newsBulletinsRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelNewsBulletins cancels the news bulletins updates
 func (c *EClient) CancelNewsBulletins() {
+	c.reportError(c.CancelNewsBulletinsContext(context.Background()))
+}
+
+// CancelNewsBulletinsContext is CancelNewsBulletins returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelNewsBulletinsContext(ctx context.Context) error {
 
 	if c.useProtoBuf(CANCEL_NEWS_BULLETINS) {
-		c.cancelNewsBulletinsProtoBuf(createCancelNewsBulletinsProto())
-		return
+		return c.cancelNewsBulletinsProtoBuf(ctx, createCancelNewsBulletinsProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4048,14 +4446,13 @@ func (c *EClient) CancelNewsBulletins() {
 	me.encodeMsgID(CANCEL_NEWS_BULLETINS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelNewsBulletinsProtoBuf(cancelNewsBulletinsProto *protobuf.CancelNewsBulletins) {
+func (c *EClient) cancelNewsBulletinsProtoBuf(ctx context.Context, cancelNewsBulletinsProto *protobuf.CancelNewsBulletins) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4064,12 +4461,12 @@ func (c *EClient) cancelNewsBulletinsProtoBuf(cancelNewsBulletinsProto *protobuf
 
 	msg, err := proto.Marshal(cancelNewsBulletinsProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -4080,15 +4477,19 @@ func (c *EClient) cancelNewsBulletinsProtoBuf(cancelNewsBulletinsProto *protobuf
 // The result will be delivered via wrapper.ManagedAccounts().
 // This request can only be made when connected to a FA managed account.
 func (c *EClient) ReqManagedAccts() {
+	c.reportError(c.ReqManagedAcctsContext(context.Background()))
+}
+
+// ReqManagedAcctsContext is ReqManagedAccts returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqManagedAcctsContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_MANAGED_ACCTS) {
-		c.reqManagedAcctsProtoBuf(createManagedAccountsRequestProto())
-		return
+		return c.reqManagedAcctsProtoBuf(ctx, createManagedAccountsRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4098,14 +4499,13 @@ func (c *EClient) ReqManagedAccts() {
 	me.encodeMsgID(REQ_MANAGED_ACCTS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqManagedAcctsProtoBuf(managedAccountsRequestProto *protobuf.ManagedAccountsRequest) {
+func (c *EClient) reqManagedAcctsProtoBuf(ctx context.Context, managedAccountsRequestProto *protobuf.ManagedAccountsRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4114,12 +4514,12 @@ func (c *EClient) reqManagedAcctsProtoBuf(managedAccountsRequestProto *protobuf.
 
 	msg, err := proto.Marshal(managedAccountsRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 
 }
 
@@ -4127,20 +4527,23 @@ func (c *EClient) reqManagedAcctsProtoBuf(managedAccountsRequestProto *protobuf.
 // The data returns in an XML string via wrapper.ReceiveFA().
 // faData is 1->"GROUPS", 3->"ALIASES".
 func (c *EClient) RequestFA(faDataType FaDataType) {
+	c.reportError(c.RequestFAContext(context.Background(), faDataType))
+}
+
+// RequestFAContext is RequestFA returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) RequestFAContext(ctx context.Context, faDataType FaDataType) error {
 
 	if c.useProtoBuf(REQ_FA) {
-		c.reqFAProtoBuf(createFARequestProto(int64(faDataType)))
-		return
+		return c.reqFAProtoBuf(ctx, createFARequestProto(int64(faDataType)))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_FA_PROFILE_DESUPPORT && faDataType == 2 {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), FA_PROFILE_NOT_SUPPORTED.Code, FA_PROFILE_NOT_SUPPORTED.Msg, "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_FA_PROFILE_DESUPPORT && faDataType == 2 {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), FA_PROFILE_NOT_SUPPORTED.Code, FA_PROFILE_NOT_SUPPORTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4151,14 +4554,13 @@ func (c *EClient) RequestFA(faDataType FaDataType) {
 	me.encodeInt(VERSION)
 	me.encodeInt(int(faDataType))
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqFAProtoBuf(faRequestProto *protobuf.FARequest) {
+func (c *EClient) reqFAProtoBuf(ctx context.Context, faRequestProto *protobuf.FARequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -4167,12 +4569,12 @@ func (c *EClient) reqFAProtoBuf(faRequestProto *protobuf.FARequest) {
 
 	msg, err := proto.Marshal(faRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 
 }
 
@@ -4183,20 +4585,23 @@ func (c *EClient) reqFAProtoBuf(faRequestProto *protobuf.FARequest) {
 // 3 = ACCOUNT ALIASES
 // cxml is the XML string containing the new FA configuration information.
 func (c *EClient) ReplaceFA(reqID int64, faDataType FaDataType, cxml string) {
+	c.reportError(c.ReplaceFAContext(context.Background(), reqID, faDataType, cxml))
+}
+
+// ReplaceFAContext is ReplaceFA returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReplaceFAContext(ctx context.Context, reqID int64, faDataType FaDataType, cxml string) error {
 
 	if c.useProtoBuf(REPLACE_FA) {
-		c.replaceFAProtoBuf(createFAReplaceProto(reqID, int64(faDataType), cxml))
-		return
+		return c.replaceFAProtoBuf(ctx, createFAReplaceProto(reqID, int64(faDataType), cxml))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_REPLACE_FA_END && faDataType == 2 {
-		c.wrapper.Error(reqID, currentTimeMillis(), FA_PROFILE_NOT_SUPPORTED.Code, FA_PROFILE_NOT_SUPPORTED.Msg, "")
-		return
+	if c.ServerVersion() >= MIN_SERVER_VER_REPLACE_FA_END && faDataType == 2 {
+		return NewIBError(reqID, currentTimeMillis(), FA_PROFILE_NOT_SUPPORTED.Code, FA_PROFILE_NOT_SUPPORTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4208,14 +4613,14 @@ func (c *EClient) ReplaceFA(reqID int64, faDataType FaDataType, cxml string) {
 	me.encodeInt(int(faDataType))
 	me.encodeString(cxml)
 
-	if c.serverVersion >= MIN_SERVER_VER_REPLACE_FA_END {
+	if c.ServerVersion() >= MIN_SERVER_VER_REPLACE_FA_END {
 		me.encodeInt64(reqID)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) replaceFAProtoBuf(faReplaceProto *protobuf.FAReplace) {
+func (c *EClient) replaceFAProtoBuf(ctx context.Context, faReplaceProto *protobuf.FAReplace) error {
 
 	reqID := NO_VALID_ID
 	if faReplaceProto.ReqId != nil {
@@ -4223,8 +4628,7 @@ func (c *EClient) replaceFAProtoBuf(faReplaceProto *protobuf.FAReplace) {
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -4233,13 +4637,12 @@ func (c *EClient) replaceFAProtoBuf(faReplaceProto *protobuf.FAReplace) {
 
 	msg, err := proto.Marshal(faReplaceProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -4295,20 +4698,46 @@ func (c *EClient) replaceFAProtoBuf(faReplaceProto *protobuf.FAReplace) {
 // chartOptions is for internal use only. Use default value XYZ.
 
 func (c *EClient) ReqHistoricalData(reqID int64, contract *Contract, endDateTime string, duration string, barSize string, whatToShow string, useRTH bool, formatDate int, keepUpToDate bool, chartOptions []TagValue) {
+	c.reportError(c.ReqHistoricalDataContext(context.Background(), reqID, contract, endDateTime, duration, barSize, whatToShow, useRTH, formatDate, keepUpToDate, chartOptions))
+}
+
+// ReqHistoricalDataContext is ReqHistoricalData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqHistoricalDataContext(ctx context.Context, reqID int64, contract *Contract, endDateTime string, duration string, barSize string, whatToShow string, useRTH bool, formatDate int, keepUpToDate bool, chartOptions []TagValue) (err error) {
+
+	release, err := c.paceHistorical(ctx, reqID, REQ_HISTORICAL_DATA, contract, whatToShow, endDateTime, duration, barSize, useRTH, formatDate, keepUpToDate, chartOptions)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
+
+	if keepUpToDate {
+		defer func() {
+			if err != nil {
+				return
+			}
+			contract := contract.clone()
+			c.subscriptions.record(SubscriptionHistoricalData, reqID, contract, func(c *EClient) {
+				c.ReqHistoricalData(reqID, contract, endDateTime, duration, barSize, whatToShow, useRTH, formatDate, keepUpToDate, chartOptions)
+			}, endDateTime, duration, barSize, whatToShow, useRTH, formatDate, keepUpToDate, chartOptions)
+		}()
+	}
 
 	if c.useProtoBuf(REQ_HISTORICAL_DATA) {
-		c.reqHistoricalDataProtoBuf(createHistoricalDataRequestProto(reqID, contract, endDateTime, duration, barSize, whatToShow, useRTH, formatDate, keepUpToDate, chartOptions))
-		return
+		return c.reqHistoricalDataProtoBuf(ctx, createHistoricalDataRequestProto(reqID, contract, endDateTime, duration, barSize, whatToShow, useRTH, formatDate, keepUpToDate, chartOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS {
 		if contract.TradingClass != "" || contract.ConID > 0 {
-			c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg, "")
+			return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg, "")
 		}
 	}
 
@@ -4318,13 +4747,13 @@ func (c *EClient) ReqHistoricalData(reqID int64, contract *Contract, endDateTime
 
 	me.encodeMsgID(REQ_HISTORICAL_DATA)
 
-	if c.serverVersion <= MIN_SERVER_VER_SYNT_REALTIME_BARS {
+	if c.ServerVersion() <= MIN_SERVER_VER_SYNT_REALTIME_BARS {
 		me.encodeInt(VERSION)
 	}
 
 	me.encodeInt64(reqID)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeInt64(contract.ConID)
 	}
 
@@ -4339,7 +4768,7 @@ func (c *EClient) ReqHistoricalData(reqID int64, contract *Contract, endDateTime
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
@@ -4361,18 +4790,18 @@ func (c *EClient) ReqHistoricalData(reqID int64, contract *Contract, endDateTime
 		}
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_SYNT_REALTIME_BARS {
+	if c.ServerVersion() >= MIN_SERVER_VER_SYNT_REALTIME_BARS {
 		me.encodeBool(keepUpToDate)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(chartOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqHistoricalDataProtoBuf(historicalDataRequestProto *protobuf.HistoricalDataRequest) {
+func (c *EClient) reqHistoricalDataProtoBuf(ctx context.Context, historicalDataRequestProto *protobuf.HistoricalDataRequest) error {
 
 	reqID := NO_VALID_ID
 	if historicalDataRequestProto.ReqId != nil {
@@ -4380,8 +4809,7 @@ func (c *EClient) reqHistoricalDataProtoBuf(historicalDataRequestProto *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(20, c)
@@ -4390,28 +4818,33 @@ func (c *EClient) reqHistoricalDataProtoBuf(historicalDataRequestProto *protobuf
 
 	msg, err := proto.Marshal(historicalDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelHistoricalData cancels the update of historical data.
 // Used if an internet disconnect has occurred or the results of a query are otherwise delayed and the application is no longer interested in receiving the data.
 // reqId, the ticker ID, must be a unique value.
 func (c *EClient) CancelHistoricalData(reqID int64) {
+	c.reportError(c.CancelHistoricalDataContext(context.Background(), reqID))
+}
+
+// CancelHistoricalDataContext is CancelHistoricalData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelHistoricalDataContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionHistoricalData, reqID)
 
 	if c.useProtoBuf(CANCEL_HISTORICAL_DATA) {
-		c.cancelHistoricalDataProtoBuf(createCancelHistoricalDataProto(reqID))
-		return
+		return c.cancelHistoricalDataProtoBuf(ctx, createCancelHistoricalDataProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4422,10 +4855,10 @@ func (c *EClient) CancelHistoricalData(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelHistoricalDataProtoBuf(cancelHistoricalDataProto *protobuf.CancelHistoricalData) {
+func (c *EClient) cancelHistoricalDataProtoBuf(ctx context.Context, cancelHistoricalDataProto *protobuf.CancelHistoricalData) error {
 
 	reqID := NO_VALID_ID
 	if cancelHistoricalDataProto.ReqId != nil {
@@ -4433,8 +4866,7 @@ func (c *EClient) cancelHistoricalDataProtoBuf(cancelHistoricalDataProto *protob
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -4443,32 +4875,44 @@ func (c *EClient) cancelHistoricalDataProtoBuf(cancelHistoricalDataProto *protob
 
 	msg, err := proto.Marshal(cancelHistoricalDataProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqHeadTimeStamp request the head timestamp of assigned contract.
 // call this func to get the headmost data you can get
 func (c *EClient) ReqHeadTimeStamp(reqID int64, contract *Contract, whatToShow string, useRTH bool, formatDate int) {
+	c.reportError(c.ReqHeadTimeStampContext(context.Background(), reqID, contract, whatToShow, useRTH, formatDate))
+}
+
+// ReqHeadTimeStampContext is ReqHeadTimeStamp returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqHeadTimeStampContext(ctx context.Context, reqID int64, contract *Contract, whatToShow string, useRTH bool, formatDate int) (err error) {
+
+	release, err := c.paceHistorical(ctx, reqID, REQ_HEAD_TIMESTAMP, contract, whatToShow, useRTH, formatDate)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
 
 	if c.useProtoBuf(REQ_HEAD_TIMESTAMP) {
-		c.reqHeadTimestampProtoBuf(createHeadTimestampRequestProto(reqID, contract, whatToShow, useRTH, formatDate))
-		return
+		return c.reqHeadTimestampProtoBuf(ctx, createHeadTimestampRequestProto(reqID, contract, whatToShow, useRTH, formatDate))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_HEAD_TIMESTAMP {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support head time stamp requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_HEAD_TIMESTAMP {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support head time stamp requests.", "")
 	}
 
 	me := NewMsgEncoder(19, c)
@@ -4480,10 +4924,10 @@ func (c *EClient) ReqHeadTimeStamp(reqID int64, contract *Contract, whatToShow s
 	me.encodeString(whatToShow)
 	me.encodeInt(formatDate)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqHeadTimestampProtoBuf(headTimestampRequestProto *protobuf.HeadTimestampRequest) {
+func (c *EClient) reqHeadTimestampProtoBuf(ctx context.Context, headTimestampRequestProto *protobuf.HeadTimestampRequest) error {
 
 	reqID := NO_VALID_ID
 	if headTimestampRequestProto.ReqId != nil {
@@ -4491,8 +4935,7 @@ func (c *EClient) reqHeadTimestampProtoBuf(headTimestampRequestProto *protobuf.H
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(19, c)
@@ -4501,31 +4944,33 @@ func (c *EClient) reqHeadTimestampProtoBuf(headTimestampRequestProto *protobuf.H
 
 	msg, err := proto.Marshal(headTimestampRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelHeadTimeStamp cancels the head timestamp data.
 func (c *EClient) CancelHeadTimeStamp(reqID int64) {
+	c.reportError(c.CancelHeadTimeStampContext(context.Background(), reqID))
+}
+
+// CancelHeadTimeStampContext is CancelHeadTimeStamp returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelHeadTimeStampContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_HEAD_TIMESTAMP) {
-		c.cancelHeadTimestampProtoBuf(createCancelHeadTimestampProto(reqID))
-		return
+		return c.cancelHeadTimestampProtoBuf(ctx, createCancelHeadTimestampProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CANCEL_HEADTIMESTAMP {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support head time stamp requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CANCEL_HEADTIMESTAMP {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support head time stamp requests.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4533,10 +4978,10 @@ func (c *EClient) CancelHeadTimeStamp(reqID int64) {
 	me.encodeMsgID(CANCEL_HEAD_TIMESTAMP)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelHeadTimestampProtoBuf(cancelHeadTimestampProto *protobuf.CancelHeadTimestamp) {
+func (c *EClient) cancelHeadTimestampProtoBuf(ctx context.Context, cancelHeadTimestampProto *protobuf.CancelHeadTimestamp) error {
 
 	reqID := NO_VALID_ID
 	if cancelHeadTimestampProto.ReqId != nil {
@@ -4544,8 +4989,7 @@ func (c *EClient) cancelHeadTimestampProtoBuf(cancelHeadTimestampProto *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4554,31 +4998,43 @@ func (c *EClient) cancelHeadTimestampProtoBuf(cancelHeadTimestampProto *protobuf
 
 	msg, err := proto.Marshal(cancelHeadTimestampProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqHistogramData requests histogram data.
 func (c *EClient) ReqHistogramData(reqID int64, contract *Contract, useRTH bool, timePeriod string) {
+	c.reportError(c.ReqHistogramDataContext(context.Background(), reqID, contract, useRTH, timePeriod))
+}
+
+// ReqHistogramDataContext is ReqHistogramData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqHistogramDataContext(ctx context.Context, reqID int64, contract *Contract, useRTH bool, timePeriod string) (err error) {
+
+	release, err := c.paceHistorical(ctx, reqID, REQ_HISTOGRAM_DATA, contract, "", useRTH, timePeriod)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
 
 	if c.useProtoBuf(REQ_HISTOGRAM_DATA) {
-		c.reqHistogramDataProtoBuf(createHistogramDataRequestProto(reqID, contract, useRTH, timePeriod))
-		return
+		return c.reqHistogramDataProtoBuf(ctx, createHistogramDataRequestProto(reqID, contract, useRTH, timePeriod))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_HISTOGRAM {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support histogram requests..", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_HISTOGRAM {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support histogram requests..", "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -4589,10 +5045,10 @@ func (c *EClient) ReqHistogramData(reqID int64, contract *Contract, useRTH bool,
 	me.encodeBool(useRTH)
 	me.encodeString(timePeriod)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqHistogramDataProtoBuf(histogramDataRequestProto *protobuf.HistogramDataRequest) {
+func (c *EClient) reqHistogramDataProtoBuf(ctx context.Context, histogramDataRequestProto *protobuf.HistogramDataRequest) error {
 
 	reqID := NO_VALID_ID
 	if histogramDataRequestProto.ReqId != nil {
@@ -4600,8 +5056,7 @@ func (c *EClient) reqHistogramDataProtoBuf(histogramDataRequestProto *protobuf.H
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -4610,31 +5065,33 @@ func (c *EClient) reqHistogramDataProtoBuf(histogramDataRequestProto *protobuf.H
 
 	msg, err := proto.Marshal(histogramDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelHistogramData cancels histogram data.
 func (c *EClient) CancelHistogramData(reqID int64) {
+	c.reportError(c.CancelHistogramDataContext(context.Background(), reqID))
+}
+
+// CancelHistogramDataContext is CancelHistogramData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelHistogramDataContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_HISTOGRAM_DATA) {
-		c.cancelHistogramDataProtoBuf(createCancelHistogramDataProto(reqID))
-		return
+		return c.cancelHistogramDataProtoBuf(ctx, createCancelHistogramDataProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_HISTOGRAM {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support histogram requests..", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_HISTOGRAM {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support histogram requests..", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4642,10 +5099,10 @@ func (c *EClient) CancelHistogramData(reqID int64) {
 	me.encodeMsgID(CANCEL_HISTOGRAM_DATA)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelHistogramDataProtoBuf(cancelHistogramDataProto *protobuf.CancelHistogramData) {
+func (c *EClient) cancelHistogramDataProtoBuf(ctx context.Context, cancelHistogramDataProto *protobuf.CancelHistogramData) error {
 
 	reqID := NO_VALID_ID
 	if cancelHistogramDataProto.ReqId != nil {
@@ -4653,8 +5110,7 @@ func (c *EClient) cancelHistogramDataProtoBuf(cancelHistogramDataProto *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4663,31 +5119,43 @@ func (c *EClient) cancelHistogramDataProtoBuf(cancelHistogramDataProto *protobuf
 
 	msg, err := proto.Marshal(cancelHistogramDataProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqHistoricalTicks requests historical ticks.
 func (c *EClient) ReqHistoricalTicks(reqID int64, contract *Contract, startDateTime string, endDateTime string, numberOfTicks int, whatToShow string, useRTH bool, ignoreSize bool, miscOptions []TagValue) {
+	c.reportError(c.ReqHistoricalTicksContext(context.Background(), reqID, contract, startDateTime, endDateTime, numberOfTicks, whatToShow, useRTH, ignoreSize, miscOptions))
+}
+
+// ReqHistoricalTicksContext is ReqHistoricalTicks returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqHistoricalTicksContext(ctx context.Context, reqID int64, contract *Contract, startDateTime string, endDateTime string, numberOfTicks int, whatToShow string, useRTH bool, ignoreSize bool, miscOptions []TagValue) (err error) {
+
+	release, err := c.paceHistorical(ctx, reqID, REQ_HISTORICAL_TICKS, contract, whatToShow, startDateTime, endDateTime, numberOfTicks, useRTH, ignoreSize, miscOptions)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
 
 	if c.useProtoBuf(REQ_HISTORICAL_TICKS) {
-		c.reqHistoricalTicksProtoBuf(createHistoricalTicksRequestProto(reqID, contract, startDateTime, endDateTime, numberOfTicks, whatToShow, useRTH, ignoreSize, miscOptions))
-		return
+		return c.reqHistoricalTicksProtoBuf(ctx, createHistoricalTicksRequestProto(reqID, contract, startDateTime, endDateTime, numberOfTicks, whatToShow, useRTH, ignoreSize, miscOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_HISTORICAL_TICKS {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support historical ticks requests..", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_HISTORICAL_TICKS {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support historical ticks requests..", "")
 	}
 
 	me := NewMsgEncoder(22, c)
@@ -4703,10 +5171,10 @@ func (c *EClient) ReqHistoricalTicks(reqID int64, contract *Contract, startDateT
 	me.encodeBool(ignoreSize)
 	me.encodeTagValues(miscOptions)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqHistoricalTicksProtoBuf(historicalTicksRequestProto *protobuf.HistoricalTicksRequest) {
+func (c *EClient) reqHistoricalTicksProtoBuf(ctx context.Context, historicalTicksRequestProto *protobuf.HistoricalTicksRequest) error {
 
 	reqID := NO_VALID_ID
 	if historicalTicksRequestProto.ReqId != nil {
@@ -4714,8 +5182,7 @@ func (c *EClient) reqHistoricalTicksProtoBuf(historicalTicksRequestProto *protob
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(22, c)
@@ -4724,13 +5191,12 @@ func (c *EClient) reqHistoricalTicksProtoBuf(historicalTicksRequestProto *protob
 
 	msg, err := proto.Marshal(historicalTicksRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -4739,15 +5205,19 @@ func (c *EClient) reqHistoricalTicksProtoBuf(historicalTicksRequestProto *protob
 
 // ReqScannerParameters requests an XML string that describes all possible scanner queries.
 func (c *EClient) ReqScannerParameters() {
+	c.reportError(c.ReqScannerParametersContext(context.Background()))
+}
+
+// ReqScannerParametersContext is ReqScannerParameters returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqScannerParametersContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_SCANNER_PARAMETERS) {
-		c.reqScannerParametersProtoBuf(createScannerParametersRequestProto())
-		return
+		return c.reqScannerParametersProtoBuf(ctx, createScannerParametersRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4757,14 +5227,13 @@ func (c *EClient) ReqScannerParameters() {
 	me.encodeMsgID(REQ_SCANNER_PARAMETERS)
 	me.encodeInt(VERSION)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqScannerParametersProtoBuf(scannerParametersRequestProto *protobuf.ScannerParametersRequest) {
+func (c *EClient) reqScannerParametersProtoBuf(ctx context.Context, scannerParametersRequestProto *protobuf.ScannerParametersRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -4773,13 +5242,12 @@ func (c *EClient) reqScannerParametersProtoBuf(scannerParametersRequestProto *pr
 
 	msg, err := proto.Marshal(scannerParametersRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqScannerSubscription subcribes a scanner that matched the subcription.
@@ -4787,20 +5255,23 @@ func (c *EClient) reqScannerParametersProtoBuf(scannerParametersRequestProto *pr
 // scannerSubscription contains possible parameters used to filter results.
 // scannerSubscriptionOptions is for internal use only.Use default value XYZ.
 func (c *EClient) ReqScannerSubscription(reqID int64, subscription *ScannerSubscription, scannerSubscriptionOptions []TagValue, scannerSubscriptionFilterOptions []TagValue) {
+	c.reportError(c.ReqScannerSubscriptionContext(context.Background(), reqID, subscription, scannerSubscriptionOptions, scannerSubscriptionFilterOptions))
+}
+
+// ReqScannerSubscriptionContext is ReqScannerSubscription returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqScannerSubscriptionContext(ctx context.Context, reqID int64, subscription *ScannerSubscription, scannerSubscriptionOptions []TagValue, scannerSubscriptionFilterOptions []TagValue) error {
 
 	if c.useProtoBuf(REQ_SCANNER_SUBSCRIPTION) {
-		c.reqScannerSubscriptionProtoBuf(createScannerSubscriptionRequestProto(reqID, subscription, scannerSubscriptionOptions, scannerSubscriptionFilterOptions))
-		return
+		return c.reqScannerSubscriptionProtoBuf(ctx, createScannerSubscriptionRequestProto(reqID, subscription, scannerSubscriptionOptions, scannerSubscriptionFilterOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SCANNER_GENERIC_OPTS && len(scannerSubscriptionFilterOptions) > 0 {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support API scanner subscription generic filter options", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SCANNER_GENERIC_OPTS && len(scannerSubscriptionFilterOptions) > 0 {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support API scanner subscription generic filter options", "")
 	}
 
 	const VERSION = 4
@@ -4809,7 +5280,7 @@ func (c *EClient) ReqScannerSubscription(reqID int64, subscription *ScannerSubsc
 
 	me.encodeMsgID(REQ_SCANNER_SUBSCRIPTION)
 
-	if c.serverVersion < MIN_SERVER_VER_SCANNER_GENERIC_OPTS {
+	if c.ServerVersion() < MIN_SERVER_VER_SCANNER_GENERIC_OPTS {
 		me.encodeInt(VERSION)
 	}
 
@@ -4836,18 +5307,18 @@ func (c *EClient) ReqScannerSubscription(reqID int64, subscription *ScannerSubsc
 	me.encodeString(subscription.ScannerSettingPairs)
 	me.encodeString(subscription.StockTypeFilter)
 
-	if c.serverVersion >= MIN_SERVER_VER_SCANNER_GENERIC_OPTS {
+	if c.ServerVersion() >= MIN_SERVER_VER_SCANNER_GENERIC_OPTS {
 		me.encodeTagValues(scannerSubscriptionFilterOptions)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(scannerSubscriptionOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqScannerSubscriptionProtoBuf(scannerSubscriptionRequestProto *protobuf.ScannerSubscriptionRequest) {
+func (c *EClient) reqScannerSubscriptionProtoBuf(ctx context.Context, scannerSubscriptionRequestProto *protobuf.ScannerSubscriptionRequest) error {
 
 	reqID := NO_VALID_ID
 	if scannerSubscriptionRequestProto.ReqId != nil {
@@ -4855,8 +5326,7 @@ func (c *EClient) reqScannerSubscriptionProtoBuf(scannerSubscriptionRequestProto
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(25, c)
@@ -4865,27 +5335,30 @@ func (c *EClient) reqScannerSubscriptionProtoBuf(scannerSubscriptionRequestProto
 
 	msg, err := proto.Marshal(scannerSubscriptionRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelScannerSubscription cancel scanner.
 // reqId is the unique ticker ID used for subscription.
 func (c *EClient) CancelScannerSubscription(reqID int64) {
+	c.reportError(c.CancelScannerSubscriptionContext(context.Background(), reqID))
+}
+
+// CancelScannerSubscriptionContext is CancelScannerSubscription returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelScannerSubscriptionContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_SCANNER_SUBSCRIPTION) {
-		c.cancelScannerSubscriptionProtoBuf(createCancelScannerSubscriptionProto(reqID))
-		return
+		return c.cancelScannerSubscriptionProtoBuf(ctx, createCancelScannerSubscriptionProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -4896,10 +5369,10 @@ func (c *EClient) CancelScannerSubscription(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelScannerSubscriptionProtoBuf(cancelScannerSubscriptionProto *protobuf.CancelScannerSubscription) {
+func (c *EClient) cancelScannerSubscriptionProtoBuf(ctx context.Context, cancelScannerSubscriptionProto *protobuf.CancelScannerSubscription) error {
 
 	reqID := NO_VALID_ID
 	if cancelScannerSubscriptionProto.ReqId != nil {
@@ -4907,8 +5380,7 @@ func (c *EClient) cancelScannerSubscriptionProtoBuf(cancelScannerSubscriptionPro
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -4917,13 +5389,12 @@ func (c *EClient) cancelScannerSubscriptionProtoBuf(cancelScannerSubscriptionPro
 
 	msg, err := proto.Marshal(cancelScannerSubscriptionProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -4956,20 +5427,33 @@ func (c *EClient) cancelScannerSubscriptionProtoBuf(cancelScannerSubscriptionPro
 //
 // realTimeBarOptions is for internal use only. Use default value XYZ.
 func (c *EClient) ReqRealTimeBars(reqID int64, contract *Contract, barSize int, whatToShow string, useRTH bool, realTimeBarsOptions []TagValue) {
+	c.reportError(c.ReqRealTimeBarsContext(context.Background(), reqID, contract, barSize, whatToShow, useRTH, realTimeBarsOptions))
+}
+
+// ReqRealTimeBarsContext is ReqRealTimeBars returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqRealTimeBarsContext(ctx context.Context, reqID int64, contract *Contract, barSize int, whatToShow string, useRTH bool, realTimeBarsOptions []TagValue) (err error) {
+
+	defer func() {
+		if err != nil {
+			return
+		}
+		contract := contract.clone()
+		c.subscriptions.record(SubscriptionRealTimeBars, reqID, contract, func(c *EClient) {
+			c.ReqRealTimeBars(reqID, contract, barSize, whatToShow, useRTH, realTimeBarsOptions)
+		}, barSize, whatToShow, useRTH, realTimeBarsOptions)
+	}()
 
 	if c.useProtoBuf(REQ_REAL_TIME_BARS) {
-		c.reqRealTimeBarsProtoBuf(createRealTimeBarsRequestProto(reqID, contract, barSize, whatToShow, useRTH, realTimeBarsOptions))
-		return
+		return c.reqRealTimeBarsProtoBuf(ctx, createRealTimeBarsRequestProto(reqID, contract, barSize, whatToShow, useRTH, realTimeBarsOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId and tradingClass parameter in reqRealTimeBars.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_TRADING_CLASS && contract.TradingClass != "" {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support conId and tradingClass parameter in reqRealTimeBars.", "")
 	}
 
 	const VERSION = 3
@@ -4980,7 +5464,7 @@ func (c *EClient) ReqRealTimeBars(reqID int64, contract *Contract, barSize int,
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeInt64(contract.ConID)
 	}
 
@@ -4995,7 +5479,7 @@ func (c *EClient) ReqRealTimeBars(reqID int64, contract *Contract, barSize int,
 	me.encodeString(contract.Currency)
 	me.encodeString(contract.LocalSymbol)
 
-	if c.serverVersion >= MIN_SERVER_VER_TRADING_CLASS {
+	if c.ServerVersion() >= MIN_SERVER_VER_TRADING_CLASS {
 		me.encodeString(contract.TradingClass)
 	}
 
@@ -5003,14 +5487,14 @@ func (c *EClient) ReqRealTimeBars(reqID int64, contract *Contract, barSize int,
 	me.encodeString(whatToShow)
 	me.encodeBool(useRTH)
 
-	if c.serverVersion >= MIN_SERVER_VER_LINKING {
+	if c.ServerVersion() >= MIN_SERVER_VER_LINKING {
 		me.encodeTagValues(realTimeBarsOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqRealTimeBarsProtoBuf(realTimeBarsRequestProto *protobuf.RealTimeBarsRequest) {
+func (c *EClient) reqRealTimeBarsProtoBuf(ctx context.Context, realTimeBarsRequestProto *protobuf.RealTimeBarsRequest) error {
 
 	reqID := NO_VALID_ID
 	if realTimeBarsRequestProto.ReqId != nil {
@@ -5018,8 +5502,7 @@ func (c *EClient) reqRealTimeBarsProtoBuf(realTimeBarsRequestProto *protobuf.Rea
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(19, c)
@@ -5028,26 +5511,31 @@ func (c *EClient) reqRealTimeBarsProtoBuf(realTimeBarsRequestProto *protobuf.Rea
 
 	msg, err := proto.Marshal(realTimeBarsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelRealTimeBars cancels realtime bars.
 func (c *EClient) CancelRealTimeBars(reqID int64) {
+	c.reportError(c.CancelRealTimeBarsContext(context.Background(), reqID))
+}
+
+// CancelRealTimeBarsContext is CancelRealTimeBars returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelRealTimeBarsContext(ctx context.Context, reqID int64) error {
+
+	c.subscriptions.remove(SubscriptionRealTimeBars, reqID)
 
 	if c.useProtoBuf(CANCEL_REAL_TIME_BARS) {
-		c.cancelRealTimeBarsProtoBuf(createCancelRealTimeBarsProto(reqID))
-		return
+		return c.cancelRealTimeBarsProtoBuf(ctx, createCancelRealTimeBarsProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	const VERSION = 1
@@ -5058,10 +5546,10 @@ func (c *EClient) CancelRealTimeBars(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelRealTimeBarsProtoBuf(cancelRealTimeBarsProto *protobuf.CancelRealTimeBars) {
+func (c *EClient) cancelRealTimeBarsProtoBuf(ctx context.Context, cancelRealTimeBarsProto *protobuf.CancelRealTimeBars) error {
 
 	reqID := NO_VALID_ID
 	if cancelRealTimeBarsProto.ReqId != nil {
@@ -5069,8 +5557,7 @@ func (c *EClient) cancelRealTimeBarsProtoBuf(cancelRealTimeBarsProto *protobuf.C
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5079,13 +5566,12 @@ func (c *EClient) cancelRealTimeBarsProtoBuf(cancelRealTimeBarsProto *protobuf.C
 
 	msg, err := proto.Marshal(cancelRealTimeBarsProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -5094,34 +5580,36 @@ func (c *EClient) cancelRealTimeBarsProtoBuf(cancelRealTimeBarsProto *protobuf.C
 
 // ReqNewsProviders request news providers.
 func (c *EClient) ReqNewsProviders() {
+	c.reportError(c.ReqNewsProvidersContext(context.Background()))
+}
+
+// ReqNewsProvidersContext is ReqNewsProviders returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqNewsProvidersContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_NEWS_PROVIDERS) {
-		c.reqNewsProvidersProtoBuf(createNewsProvidersRequestProto())
-		return
+		return c.reqNewsProvidersProtoBuf(ctx, createNewsProvidersRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_NEWS_PROVIDERS {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support news providers request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_NEWS_PROVIDERS {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support news providers request.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
 
 	me.encodeMsgID(REQ_NEWS_PROVIDERS)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqNewsProvidersProtoBuf(newsProvidersRequestProto *protobuf.NewsProvidersRequest) {
+func (c *EClient) reqNewsProvidersProtoBuf(ctx context.Context, newsProvidersRequestProto *protobuf.NewsProvidersRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5130,31 +5618,33 @@ func (c *EClient) reqNewsProvidersProtoBuf(newsProvidersRequestProto *protobuf.N
 
 	msg, err := proto.Marshal(newsProvidersRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqNewsArticle request news article.
 func (c *EClient) ReqNewsArticle(reqID int64, providerCode string, articleID string, newsArticleOptions []TagValue) {
+	c.reportError(c.ReqNewsArticleContext(context.Background(), reqID, providerCode, articleID, newsArticleOptions))
+}
+
+// ReqNewsArticleContext is ReqNewsArticle returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqNewsArticleContext(ctx context.Context, reqID int64, providerCode string, articleID string, newsArticleOptions []TagValue) error {
 
 	if c.useProtoBuf(REQ_NEWS_ARTICLE) {
-		c.reqNewsArticleProtoBuf(createNewsArticleRequestProto(reqID, providerCode, articleID, newsArticleOptions))
-		return
+		return c.reqNewsArticleProtoBuf(ctx, createNewsArticleRequestProto(reqID, providerCode, articleID, newsArticleOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_NEWS_ARTICLE {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support news article request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_NEWS_ARTICLE {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support news article request.", "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -5164,14 +5654,14 @@ func (c *EClient) ReqNewsArticle(reqID int64, providerCode string, articleID str
 	me.encodeString(providerCode)
 	me.encodeString(articleID)
 
-	if c.serverVersion >= MIN_SERVER_VER_NEWS_QUERY_ORIGINS {
+	if c.ServerVersion() >= MIN_SERVER_VER_NEWS_QUERY_ORIGINS {
 		me.encodeTagValues(newsArticleOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqNewsArticleProtoBuf(newsArticleRequestProto *protobuf.NewsArticleRequest) {
+func (c *EClient) reqNewsArticleProtoBuf(ctx context.Context, newsArticleRequestProto *protobuf.NewsArticleRequest) error {
 
 	reqID := NO_VALID_ID
 	if newsArticleRequestProto.ReqId != nil {
@@ -5179,8 +5669,7 @@ func (c *EClient) reqNewsArticleProtoBuf(newsArticleRequestProto *protobuf.NewsA
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(5, c)
@@ -5189,31 +5678,33 @@ func (c *EClient) reqNewsArticleProtoBuf(newsArticleRequestProto *protobuf.NewsA
 
 	msg, err := proto.Marshal(newsArticleRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqHistoricalNews request historical news.
 func (c *EClient) ReqHistoricalNews(reqID int64, contractID int64, providerCode string, startDateTime string, endDateTime string, totalResults int64, historicalNewsOptions []TagValue) {
+	c.reportError(c.ReqHistoricalNewsContext(context.Background(), reqID, contractID, providerCode, startDateTime, endDateTime, totalResults, historicalNewsOptions))
+}
+
+// ReqHistoricalNewsContext is ReqHistoricalNews returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqHistoricalNewsContext(ctx context.Context, reqID int64, contractID int64, providerCode string, startDateTime string, endDateTime string, totalResults int64, historicalNewsOptions []TagValue) error {
 
 	if c.useProtoBuf(REQ_HISTORICAL_NEWS) {
-		c.reqHistoricalNewsProtoBuf(createHistoricalNewsRequestProto(reqID, contractID, providerCode, startDateTime, endDateTime, totalResults, historicalNewsOptions))
-		return
+		return c.reqHistoricalNewsProtoBuf(ctx, createHistoricalNewsRequestProto(reqID, contractID, providerCode, startDateTime, endDateTime, totalResults, historicalNewsOptions))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_HISTORICAL_NEWS {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support historical news request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_HISTORICAL_NEWS {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support historical news request.", "")
 	}
 
 	me := NewMsgEncoder(8, c)
@@ -5226,14 +5717,14 @@ func (c *EClient) ReqHistoricalNews(reqID int64, contractID int64, providerCode
 	me.encodeString(endDateTime)
 	me.encodeInt64(totalResults)
 
-	if c.serverVersion >= MIN_SERVER_VER_NEWS_QUERY_ORIGINS {
+	if c.ServerVersion() >= MIN_SERVER_VER_NEWS_QUERY_ORIGINS {
 		me.encodeTagValues(historicalNewsOptions)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqHistoricalNewsProtoBuf(historicalNewsRequestProto *protobuf.HistoricalNewsRequest) {
+func (c *EClient) reqHistoricalNewsProtoBuf(ctx context.Context, historicalNewsRequestProto *protobuf.HistoricalNewsRequest) error {
 
 	reqID := NO_VALID_ID
 	if historicalNewsRequestProto.ReqId != nil {
@@ -5241,8 +5732,7 @@ func (c *EClient) reqHistoricalNewsProtoBuf(historicalNewsRequestProto *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(8, c)
@@ -5251,13 +5741,12 @@ func (c *EClient) reqHistoricalNewsProtoBuf(historicalNewsRequestProto *protobuf
 
 	msg, err := proto.Marshal(historicalNewsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 //	##########################################################################
@@ -5266,20 +5755,23 @@ func (c *EClient) reqHistoricalNewsProtoBuf(historicalNewsRequestProto *protobuf
 
 // QueryDisplayGroups request the display groups in TWS.
 func (c *EClient) QueryDisplayGroups(reqID int64) {
+	c.reportError(c.QueryDisplayGroupsContext(context.Background(), reqID))
+}
+
+// QueryDisplayGroupsContext is QueryDisplayGroups returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) QueryDisplayGroupsContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(QUERY_DISPLAY_GROUPS) {
-		c.queryDisplayGroupsProtoBuf(createQueryDisplayGroupsRequestProto(reqID))
-		return
+		return c.queryDisplayGroupsProtoBuf(ctx, createQueryDisplayGroupsRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support queryDisplayGroups request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support queryDisplayGroups request.", "")
 	}
 
 	const VERSION = 1
@@ -5290,10 +5782,10 @@ func (c *EClient) QueryDisplayGroups(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) queryDisplayGroupsProtoBuf(queryDisplayGroupsRequest *protobuf.QueryDisplayGroupsRequest) {
+func (c *EClient) queryDisplayGroupsProtoBuf(ctx context.Context, queryDisplayGroupsRequest *protobuf.QueryDisplayGroupsRequest) error {
 
 	reqID := NO_VALID_ID
 	if queryDisplayGroupsRequest.ReqId != nil {
@@ -5301,8 +5793,7 @@ func (c *EClient) queryDisplayGroupsProtoBuf(queryDisplayGroupsRequest *protobuf
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5311,33 +5802,35 @@ func (c *EClient) queryDisplayGroupsProtoBuf(queryDisplayGroupsRequest *protobuf
 
 	msg, err := proto.Marshal(queryDisplayGroupsRequest)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // SubscribeToGroupEvents subcribes the group events.
 // reqId is the unique number associated with the notification.
 // groupId is the ID of the group, currently it is a number from 1 to 7.
 func (c *EClient) SubscribeToGroupEvents(reqID int64, groupID int) {
+	c.reportError(c.SubscribeToGroupEventsContext(context.Background(), reqID, groupID))
+}
+
+// SubscribeToGroupEventsContext is SubscribeToGroupEvents returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) SubscribeToGroupEventsContext(ctx context.Context, reqID int64, groupID int) error {
 
 	if c.useProtoBuf(SUBSCRIBE_TO_GROUP_EVENTS) {
-		c.subscribeToGroupEventsProtoBuf(createSubscribeToGroupEventsRequestProto(reqID, int64(groupID)))
-		return
+		return c.subscribeToGroupEventsProtoBuf(ctx, createSubscribeToGroupEventsRequestProto(reqID, int64(groupID)))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support subscribeToGroupEvents request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support subscribeToGroupEvents request.", "")
 	}
 
 	const VERSION = 1
@@ -5349,10 +5842,10 @@ func (c *EClient) SubscribeToGroupEvents(reqID int64, groupID int) {
 	me.encodeInt64(reqID)
 	me.encodeInt(groupID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) subscribeToGroupEventsProtoBuf(subscribeToGroupEventsRequestProto *protobuf.SubscribeToGroupEventsRequest) {
+func (c *EClient) subscribeToGroupEventsProtoBuf(ctx context.Context, subscribeToGroupEventsRequestProto *protobuf.SubscribeToGroupEventsRequest) error {
 
 	reqID := NO_VALID_ID
 	if subscribeToGroupEventsRequestProto.ReqId != nil {
@@ -5360,8 +5853,7 @@ func (c *EClient) subscribeToGroupEventsProtoBuf(subscribeToGroupEventsRequestPr
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -5370,13 +5862,12 @@ func (c *EClient) subscribeToGroupEventsProtoBuf(subscribeToGroupEventsRequestPr
 
 	msg, err := proto.Marshal(subscribeToGroupEventsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // UpdateDisplayGroup updates the display group in TWS.
@@ -5389,20 +5880,23 @@ func (c *EClient) subscribeToGroupEventsProtoBuf(subscribeToGroupEventsRequestPr
 //		Examples: 8314@SMART for IBM SMART; 8314@ARCA for IBM @ARCA.
 //	combo = if any combo is selected.
 func (c *EClient) UpdateDisplayGroup(reqID int64, contractInfo string) {
+	c.reportError(c.UpdateDisplayGroupContext(context.Background(), reqID, contractInfo))
+}
+
+// UpdateDisplayGroupContext is UpdateDisplayGroup returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) UpdateDisplayGroupContext(ctx context.Context, reqID int64, contractInfo string) error {
 
 	if c.useProtoBuf(UPDATE_DISPLAY_GROUP) {
-		c.updateDisplayGroupProtoBuf(createUpdateDisplayGroupRequestProto(reqID, contractInfo))
-		return
+		return c.updateDisplayGroupProtoBuf(ctx, createUpdateDisplayGroupRequestProto(reqID, contractInfo))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support updateDisplayGroup request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support updateDisplayGroup request.", "")
 	}
 
 	const VERSION = 1
@@ -5414,10 +5908,10 @@ func (c *EClient) UpdateDisplayGroup(reqID int64, contractInfo string) {
 	me.encodeInt64(reqID)
 	me.encodeString(contractInfo)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) updateDisplayGroupProtoBuf(updateDisplayGroupRequestProto *protobuf.UpdateDisplayGroupRequest) {
+func (c *EClient) updateDisplayGroupProtoBuf(ctx context.Context, updateDisplayGroupRequestProto *protobuf.UpdateDisplayGroupRequest) error {
 
 	reqID := NO_VALID_ID
 	if updateDisplayGroupRequestProto.ReqId != nil {
@@ -5425,8 +5919,7 @@ func (c *EClient) updateDisplayGroupProtoBuf(updateDisplayGroupRequestProto *pro
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -5435,31 +5928,33 @@ func (c *EClient) updateDisplayGroupProtoBuf(updateDisplayGroupRequestProto *pro
 
 	msg, err := proto.Marshal(updateDisplayGroupRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // UnsubscribeFromGroupEvents unsubcribes the display group events.
 func (c *EClient) UnsubscribeFromGroupEvents(reqID int64) {
+	c.reportError(c.UnsubscribeFromGroupEventsContext(context.Background(), reqID))
+}
+
+// UnsubscribeFromGroupEventsContext is UnsubscribeFromGroupEvents returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) UnsubscribeFromGroupEventsContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(UNSUBSCRIBE_FROM_GROUP_EVENTS) {
-		c.unsubscribeFromGroupEventsProtoBuf(createUnsubscribeFromGroupEventsRequestProto(reqID))
-		return
+		return c.unsubscribeFromGroupEventsProtoBuf(ctx, createUnsubscribeFromGroupEventsRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support unsubscribeFromGroupEvents request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support unsubscribeFromGroupEvents request.", "")
 	}
 
 	const VERSION = 1
@@ -5470,10 +5965,10 @@ func (c *EClient) UnsubscribeFromGroupEvents(reqID int64) {
 	me.encodeInt(VERSION)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) unsubscribeFromGroupEventsProtoBuf(unsubscribeFromGroupEventsRequestProto *protobuf.UnsubscribeFromGroupEventsRequest) {
+func (c *EClient) unsubscribeFromGroupEventsProtoBuf(ctx context.Context, unsubscribeFromGroupEventsRequestProto *protobuf.UnsubscribeFromGroupEventsRequest) error {
 
 	reqID := NO_VALID_ID
 	if unsubscribeFromGroupEventsRequestProto.ReqId != nil {
@@ -5481,8 +5976,7 @@ func (c *EClient) unsubscribeFromGroupEventsProtoBuf(unsubscribeFromGroupEventsR
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5491,38 +5985,39 @@ func (c *EClient) unsubscribeFromGroupEventsProtoBuf(unsubscribeFromGroupEventsR
 
 	msg, err := proto.Marshal(unsubscribeFromGroupEventsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // VerifyRequest is just for IB's internal use.
 // Allows to provide means of verification between the TWS and third party programs.
 func (c *EClient) VerifyRequest(apiName string, apiVersion string) {
+	c.reportError(c.VerifyRequestContext(context.Background(), apiName, apiVersion))
+}
+
+// VerifyRequestContext is VerifyRequest returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) VerifyRequestContext(ctx context.Context, apiName string, apiVersion string) error {
 
 	if c.useProtoBuf(VERIFY_REQUEST) {
-		c.verifyRequestProtoBuf(createVerifyRequestProto(apiName, apiVersion))
-		return
+		return c.verifyRequestProtoBuf(ctx, createVerifyRequestProto(apiName, apiVersion))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
 	}
 
 	if c.extraAuth {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), BAD_MESSAGE.Code, BAD_MESSAGE.Msg+
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), BAD_MESSAGE.Code, BAD_MESSAGE.Msg+
 			" Intent to authenticate needs to be expressed during initial connect request.", "")
-		return
 	}
 
 	const VERSION = 1
@@ -5534,14 +6029,13 @@ func (c *EClient) VerifyRequest(apiName string, apiVersion string) {
 	me.encodeString(apiName)
 	me.encodeString(apiVersion)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) verifyRequestProtoBuf(VerifyRequest *protobuf.VerifyRequest) {
+func (c *EClient) verifyRequestProtoBuf(ctx context.Context, VerifyRequest *protobuf.VerifyRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -5550,32 +6044,34 @@ func (c *EClient) verifyRequestProtoBuf(VerifyRequest *protobuf.VerifyRequest) {
 
 	msg, err := proto.Marshal(VerifyRequest)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // VerifyMessage is just for IB's internal use.
 // Allows to provide means of verification between the TWS and third party programs.
 func (c *EClient) VerifyMessage(apiData string) {
+	c.reportError(c.VerifyMessageContext(context.Background(), apiData))
+}
+
+// VerifyMessageContext is VerifyMessage returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) VerifyMessageContext(ctx context.Context, apiData string) error {
 
 	if c.useProtoBuf(VERIFY_MESSAGE) {
-		c.verifyMessageProtoBuf(createVerifyMessageRequestProto(apiData))
-		return
+		return c.verifyMessageProtoBuf(ctx, createVerifyMessageRequestProto(apiData))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
 	}
 
 	const VERSION = 1
@@ -5586,14 +6082,13 @@ func (c *EClient) VerifyMessage(apiData string) {
 	me.encodeInt(VERSION)
 	me.encodeString(apiData)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) verifyMessageProtoBuf(verifyMessageRequestProto *protobuf.VerifyMessageRequest) {
+func (c *EClient) verifyMessageProtoBuf(ctx context.Context, verifyMessageRequestProto *protobuf.VerifyMessageRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(4, c)
@@ -5602,33 +6097,35 @@ func (c *EClient) verifyMessageProtoBuf(verifyMessageRequestProto *protobuf.Veri
 
 	msg, err := proto.Marshal(verifyMessageRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // VerifyAndAuthRequest is just for IB's internal use.
 // Allows to provide means of verification between the TWS and third party programs.
 func (c *EClient) VerifyAndAuthRequest(apiName string, apiVersion string, opaqueIsvKey string) {
+	c.reportError(c.VerifyAndAuthRequestContext(context.Background(), apiName, apiVersion, opaqueIsvKey))
+}
+
+// VerifyAndAuthRequestContext is VerifyAndAuthRequest returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) VerifyAndAuthRequestContext(ctx context.Context, apiName string, apiVersion string, opaqueIsvKey string) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
 	}
 
 	if c.extraAuth {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+
 			" Intent to authenticate needs to be expressed during initial connect request.", "")
-		return
 	}
 
 	const VERSION = 1
@@ -5641,21 +6138,25 @@ func (c *EClient) VerifyAndAuthRequest(apiName string, apiVersion string, opaque
 	me.encodeString(apiVersion)
 	me.encodeString(opaqueIsvKey)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // VerifyAndAuthMessage is just for IB's internal use.
 // Allows to provide means of verification between the TWS and third party programs.
 func (c *EClient) VerifyAndAuthMessage(apiData string, xyzResponse string) {
+	c.reportError(c.VerifyAndAuthMessageContext(context.Background(), apiData, xyzResponse))
+}
+
+// VerifyAndAuthMessageContext is VerifyAndAuthMessage returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) VerifyAndAuthMessageContext(ctx context.Context, apiData string, xyzResponse string) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_LINKING {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_LINKING {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support verification request.", "")
 	}
 
 	const VERSION = 1
@@ -5667,7 +6168,7 @@ func (c *EClient) VerifyAndAuthMessage(apiData string, xyzResponse string) {
 	me.encodeString(apiData)
 	me.encodeString(xyzResponse)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqSecDefOptParams requests security definition option parameters.
@@ -5677,20 +6178,23 @@ func (c *EClient) VerifyAndAuthMessage(apiData string, xyzResponse string) {
 // underlyingConId is the contract ID of the underlying security.
 // Response comes via wrapper.SecurityDefinitionOptionParameter().
 func (c *EClient) ReqSecDefOptParams(reqID int64, underlyingSymbol string, futFopExchange string, underlyingSecurityType string, underlyingContractID int64) {
+	c.reportError(c.ReqSecDefOptParamsContext(context.Background(), reqID, underlyingSymbol, futFopExchange, underlyingSecurityType, underlyingContractID))
+}
+
+// ReqSecDefOptParamsContext is ReqSecDefOptParams returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqSecDefOptParamsContext(ctx context.Context, reqID int64, underlyingSymbol string, futFopExchange string, underlyingSecurityType string, underlyingContractID int64) error {
 
 	if c.useProtoBuf(REQ_SEC_DEF_OPT_PARAMS) {
-		c.reqSecDefOptParamsProtoBuf(createSecDefOptParamsRequestProto(reqID, underlyingSymbol, futFopExchange, underlyingSecurityType, underlyingContractID))
-		return
+		return c.reqSecDefOptParamsProtoBuf(ctx, createSecDefOptParamsRequestProto(reqID, underlyingSymbol, futFopExchange, underlyingSecurityType, underlyingContractID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_SEC_DEF_OPT_PARAMS_REQ {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support security definition option request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_SEC_DEF_OPT_PARAMS_REQ {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support security definition option request.", "")
 	}
 
 	me := NewMsgEncoder(6, c)
@@ -5702,10 +6206,10 @@ func (c *EClient) ReqSecDefOptParams(reqID int64, underlyingSymbol string, futFo
 	me.encodeString(underlyingSecurityType)
 	me.encodeInt64(underlyingContractID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqSecDefOptParamsProtoBuf(secDefOptParamsRequestProto *protobuf.SecDefOptParamsRequest) {
+func (c *EClient) reqSecDefOptParamsProtoBuf(ctx context.Context, secDefOptParamsRequestProto *protobuf.SecDefOptParamsRequest) error {
 
 	reqID := NO_VALID_ID
 	if secDefOptParamsRequestProto.ReqId != nil {
@@ -5713,8 +6217,7 @@ func (c *EClient) reqSecDefOptParamsProtoBuf(secDefOptParamsRequestProto *protob
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(6, c)
@@ -5723,28 +6226,31 @@ func (c *EClient) reqSecDefOptParamsProtoBuf(secDefOptParamsRequestProto *protob
 
 	msg, err := proto.Marshal(secDefOptParamsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqSoftDollarTiers request pre-defined Soft Dollar Tiers.
 // This is only supported for registered professional advisors and hedge and mutual funds
 // who have configured Soft Dollar Tiers in Account Management.
 func (c *EClient) ReqSoftDollarTiers(reqID int64) {
+	c.reportError(c.ReqSoftDollarTiersContext(context.Background(), reqID))
+}
+
+// ReqSoftDollarTiersContext is ReqSoftDollarTiers returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqSoftDollarTiersContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(REQ_SOFT_DOLLAR_TIERS) {
-		c.reqSoftDollarTiersProtoBuf(createSoftDollarTiersRequestProto(reqID))
-		return
+		return c.reqSoftDollarTiersProtoBuf(ctx, createSoftDollarTiersRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -5752,10 +6258,10 @@ func (c *EClient) ReqSoftDollarTiers(reqID int64) {
 	me.encodeMsgID(REQ_SOFT_DOLLAR_TIERS)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqSoftDollarTiersProtoBuf(softDollarTiersRequestProto *protobuf.SoftDollarTiersRequest) {
+func (c *EClient) reqSoftDollarTiersProtoBuf(ctx context.Context, softDollarTiersRequestProto *protobuf.SoftDollarTiersRequest) error {
 
 	reqID := NO_VALID_ID
 	if softDollarTiersRequestProto.ReqId != nil {
@@ -5763,8 +6269,7 @@ func (c *EClient) reqSoftDollarTiersProtoBuf(softDollarTiersRequestProto *protob
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -5773,45 +6278,46 @@ func (c *EClient) reqSoftDollarTiersProtoBuf(softDollarTiersRequestProto *protob
 
 	msg, err := proto.Marshal(softDollarTiersRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqFamilyCodes requests family codes.
 func (c *EClient) ReqFamilyCodes() {
+	c.reportError(c.ReqFamilyCodesContext(context.Background()))
+}
+
+// ReqFamilyCodesContext is ReqFamilyCodes returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqFamilyCodesContext(ctx context.Context) error {
 
 	if c.useProtoBuf(REQ_FAMILY_CODES) {
-		c.reqFamilyCodesProtoBuf(createFamilyCodesRequestProto())
-		return
+		return c.reqFamilyCodesProtoBuf(ctx, createFamilyCodesRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_FAMILY_CODES {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support family codes request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_FAMILY_CODES {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support family codes request.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
 
 	me.encodeMsgID(REQ_FAMILY_CODES)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqFamilyCodesProtoBuf(familyCodesRequestProto *protobuf.FamilyCodesRequest) {
+func (c *EClient) reqFamilyCodesProtoBuf(ctx context.Context, familyCodesRequestProto *protobuf.FamilyCodesRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -5820,31 +6326,33 @@ func (c *EClient) reqFamilyCodesProtoBuf(familyCodesRequestProto *protobuf.Famil
 
 	msg, err := proto.Marshal(familyCodesRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqMatchingSymbols requests matching symbols.
 func (c *EClient) ReqMatchingSymbols(reqID int64, pattern string) {
+	c.reportError(c.ReqMatchingSymbolsContext(context.Background(), reqID, pattern))
+}
+
+// ReqMatchingSymbolsContext is ReqMatchingSymbols returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqMatchingSymbolsContext(ctx context.Context, reqID int64, pattern string) error {
 
 	if c.useProtoBuf(REQ_MATCHING_SYMBOLS) {
-		c.reqMatchingSymbolsProtoBuf(createMatchingSymbolsRequestProto(reqID, pattern))
-		return
+		return c.reqMatchingSymbolsProtoBuf(ctx, createMatchingSymbolsRequestProto(reqID, pattern))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_REQ_MATCHING_SYMBOLS {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support matching symbols request.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_REQ_MATCHING_SYMBOLS {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support matching symbols request.", "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5853,10 +6361,10 @@ func (c *EClient) ReqMatchingSymbols(reqID int64, pattern string) {
 	me.encodeInt64(reqID)
 	me.encodeString(pattern)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqMatchingSymbolsProtoBuf(matchingSymbolsRequestProto *protobuf.MatchingSymbolsRequest) {
+func (c *EClient) reqMatchingSymbolsProtoBuf(ctx context.Context, matchingSymbolsRequestProto *protobuf.MatchingSymbolsRequest) error {
 
 	reqID := NO_VALID_ID
 	if matchingSymbolsRequestProto.ReqId != nil {
@@ -5864,8 +6372,7 @@ func (c *EClient) reqMatchingSymbolsProtoBuf(matchingSymbolsRequestProto *protob
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5874,28 +6381,31 @@ func (c *EClient) reqMatchingSymbolsProtoBuf(matchingSymbolsRequestProto *protob
 
 	msg, err := proto.Marshal(matchingSymbolsRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqCompletedOrders request the completed orders.
 // If apiOnly parameter is true, then only completed orders placed from API are requested.
 // Result will be delivered via wrapper.CompletedOrder().
 func (c *EClient) ReqCompletedOrders(apiOnly bool) {
+	c.reportError(c.ReqCompletedOrdersContext(context.Background(), apiOnly))
+}
+
+// ReqCompletedOrdersContext is ReqCompletedOrders returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqCompletedOrdersContext(ctx context.Context, apiOnly bool) error {
 
 	if c.useProtoBuf(REQ_COMPLETED_ORDERS) {
-		c.reqCompletedOrdersProtoBuf(createCompletedOrdersRequestProto(apiOnly))
-		return
+		return c.reqCompletedOrdersProtoBuf(ctx, createCompletedOrdersRequestProto(apiOnly))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -5903,14 +6413,13 @@ func (c *EClient) ReqCompletedOrders(apiOnly bool) {
 	me.encodeMsgID(REQ_COMPLETED_ORDERS)
 	me.encodeBool(apiOnly)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqCompletedOrdersProtoBuf(completedOrdersRequestProto *protobuf.CompletedOrdersRequest) {
+func (c *EClient) reqCompletedOrdersProtoBuf(ctx context.Context, completedOrdersRequestProto *protobuf.CompletedOrdersRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(3, c)
@@ -5919,31 +6428,33 @@ func (c *EClient) reqCompletedOrdersProtoBuf(completedOrdersRequestProto *protob
 
 	msg, err := proto.Marshal(completedOrdersRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqWshMetaData requests WSHE Meta data.
 func (c *EClient) ReqWshMetaData(reqID int64) {
+	c.reportError(c.ReqWshMetaDataContext(context.Background(), reqID))
+}
+
+// ReqWshMetaDataContext is ReqWshMetaData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqWshMetaDataContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(REQ_WSH_META_DATA) {
-		c.reqWshMetaDataProtoBuf(createWshMetaDataRequestProto(reqID))
-		return
+		return c.reqWshMetaDataProtoBuf(ctx, createWshMetaDataRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_WSHE_CALENDAR {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_WSHE_CALENDAR {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -5951,10 +6462,10 @@ func (c *EClient) ReqWshMetaData(reqID int64) {
 	me.encodeMsgID(REQ_WSH_META_DATA)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqWshMetaDataProtoBuf(wshMetaDataRequestProto *protobuf.WshMetaDataRequest) {
+func (c *EClient) reqWshMetaDataProtoBuf(ctx context.Context, wshMetaDataRequestProto *protobuf.WshMetaDataRequest) error {
 
 	reqID := NO_VALID_ID
 	if wshMetaDataRequestProto.ReqId != nil {
@@ -5962,8 +6473,7 @@ func (c *EClient) reqWshMetaDataProtoBuf(wshMetaDataRequestProto *protobuf.WshMe
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -5972,31 +6482,33 @@ func (c *EClient) reqWshMetaDataProtoBuf(wshMetaDataRequestProto *protobuf.WshMe
 
 	msg, err := proto.Marshal(wshMetaDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelWshMetaData cancels WSHE Meta data.
 func (c *EClient) CancelWshMetaData(reqID int64) {
+	c.reportError(c.CancelWshMetaDataContext(context.Background(), reqID))
+}
+
+// CancelWshMetaDataContext is CancelWshMetaData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelWshMetaDataContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_WSH_META_DATA) {
-		c.cancelWshMetaDataProtoBuf(createCancelWshMetaDataProto(reqID))
-		return
+		return c.cancelWshMetaDataProtoBuf(ctx, createCancelWshMetaDataProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_WSHE_CALENDAR {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_WSHE_CALENDAR {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -6004,10 +6516,10 @@ func (c *EClient) CancelWshMetaData(reqID int64) {
 	me.encodeMsgID(CANCEL_WSH_META_DATA)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelWshMetaDataProtoBuf(cancelWshMetaDataProto *protobuf.CancelWshMetaData) {
+func (c *EClient) cancelWshMetaDataProtoBuf(ctx context.Context, cancelWshMetaDataProto *protobuf.CancelWshMetaData) error {
 
 	reqID := NO_VALID_ID
 	if cancelWshMetaDataProto.ReqId != nil {
@@ -6015,8 +6527,7 @@ func (c *EClient) cancelWshMetaDataProtoBuf(cancelWshMetaDataProto *protobuf.Can
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -6025,41 +6536,41 @@ func (c *EClient) cancelWshMetaDataProtoBuf(cancelWshMetaDataProto *protobuf.Can
 
 	msg, err := proto.Marshal(cancelWshMetaDataProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqWshEventData requests WSHE Event data.
 func (c *EClient) ReqWshEventData(reqID int64, wshEventData WshEventData) {
+	c.reportError(c.ReqWshEventDataContext(context.Background(), reqID, wshEventData))
+}
+
+// ReqWshEventDataContext is ReqWshEventData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqWshEventDataContext(ctx context.Context, reqID int64, wshEventData WshEventData) error {
 
 	if c.useProtoBuf(REQ_WSH_EVENT_DATA) {
-		c.reqWshEventDataProtoBuf(createWshEventDataRequestProto(reqID, &wshEventData))
-		return
+		return c.reqWshEventDataProtoBuf(ctx, createWshEventDataRequestProto(reqID, &wshEventData))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_WSHE_CALENDAR {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_WSHE_CALENDAR {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
 	}
-	if c.serverVersion < MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS &&
+	if c.ServerVersion() < MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS &&
 		(wshEventData.Filter != "" || wshEventData.FillWatchList || wshEventData.FillPortfolio) {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE event data filters.", "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE event data filters.", "")
 	}
-	if c.serverVersion < MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS_DATE &&
+	if c.ServerVersion() < MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS_DATE &&
 		(wshEventData.StartDate != "" || wshEventData.EndDate != "" || wshEventData.TotalLimit != UNSET_INT) {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE event data date filters.", "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE event data date filters.", "")
 	}
 
 	me := NewMsgEncoder(10, c)
@@ -6068,23 +6579,23 @@ func (c *EClient) ReqWshEventData(reqID int64, wshEventData WshEventData) {
 	me.encodeInt64(reqID)
 	me.encodeInt64(wshEventData.ConID)
 
-	if c.serverVersion >= MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS {
+	if c.ServerVersion() >= MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS {
 		me.encodeString(wshEventData.Filter)
 		me.encodeBool(wshEventData.FillWatchList)
 		me.encodeBool(wshEventData.FillPortfolio)
 		me.encodeBool(wshEventData.FillCompetitors)
 	}
 
-	if c.serverVersion >= MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS_DATE {
+	if c.ServerVersion() >= MIN_SERVER_VER_WSH_EVENT_DATA_FILTERS_DATE {
 		me.encodeString(wshEventData.StartDate)
 		me.encodeString(wshEventData.EndDate)
 		me.encodeInt64(wshEventData.TotalLimit)
 	}
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqWshEventDataProtoBuf(wshEventDataRequestProto *protobuf.WshEventDataRequest) {
+func (c *EClient) reqWshEventDataProtoBuf(ctx context.Context, wshEventDataRequestProto *protobuf.WshEventDataRequest) error {
 
 	reqID := NO_VALID_ID
 	if wshEventDataRequestProto.ReqId != nil {
@@ -6092,8 +6603,7 @@ func (c *EClient) reqWshEventDataProtoBuf(wshEventDataRequestProto *protobuf.Wsh
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(10, c)
@@ -6102,31 +6612,33 @@ func (c *EClient) reqWshEventDataProtoBuf(wshEventDataRequestProto *protobuf.Wsh
 
 	msg, err := proto.Marshal(wshEventDataRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelWshEventData cancels WSHE Event data.
 func (c *EClient) CancelWshEventData(reqID int64) {
+	c.reportError(c.CancelWshEventDataContext(context.Background(), reqID))
+}
+
+// CancelWshEventDataContext is CancelWshEventData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelWshEventDataContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(CANCEL_WSH_EVENT_DATA) {
-		c.cancelWshEventDataProtoBuf(createCancelWshEventDataProto(reqID))
-		return
+		return c.cancelWshEventDataProtoBuf(ctx, createCancelWshEventDataProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_WSHE_CALENDAR {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_WSHE_CALENDAR {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support WSHE Calendar API.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -6134,10 +6646,10 @@ func (c *EClient) CancelWshEventData(reqID int64) {
 	me.encodeMsgID(CANCEL_WSH_EVENT_DATA)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) cancelWshEventDataProtoBuf(cancelWshEventDataProto *protobuf.CancelWshEventData) {
+func (c *EClient) cancelWshEventDataProtoBuf(ctx context.Context, cancelWshEventDataProto *protobuf.CancelWshEventData) error {
 
 	reqID := NO_VALID_ID
 	if cancelWshEventDataProto.ReqId != nil {
@@ -6145,8 +6657,7 @@ func (c *EClient) cancelWshEventDataProtoBuf(cancelWshEventDataProto *protobuf.C
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -6155,31 +6666,33 @@ func (c *EClient) cancelWshEventDataProtoBuf(cancelWshEventDataProto *protobuf.C
 
 	msg, err := proto.Marshal(cancelWshEventDataProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqUserInfo requests user info.
 func (c *EClient) ReqUserInfo(reqID int64) {
+	c.reportError(c.ReqUserInfoContext(context.Background(), reqID))
+}
+
+// ReqUserInfoContext is ReqUserInfo returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqUserInfoContext(ctx context.Context, reqID int64) error {
 
 	if c.useProtoBuf(REQ_USER_INFO) {
-		c.reqUserInfoProtoBuf(createUserInfoRequestProto(reqID))
-		return
+		return c.reqUserInfoProtoBuf(ctx, createUserInfoRequestProto(reqID))
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_USER_INFO {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support user info requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_USER_INFO {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support user info requests.", "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -6187,10 +6700,10 @@ func (c *EClient) ReqUserInfo(reqID int64) {
 	me.encodeMsgID(REQ_USER_INFO)
 	me.encodeInt64(reqID)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqUserInfoProtoBuf(userInfoRequestProto *protobuf.UserInfoRequest) {
+func (c *EClient) reqUserInfoProtoBuf(ctx context.Context, userInfoRequestProto *protobuf.UserInfoRequest) error {
 
 	reqID := NO_VALID_ID
 	if userInfoRequestProto.ReqId != nil {
@@ -6198,8 +6711,7 @@ func (c *EClient) reqUserInfoProtoBuf(userInfoRequestProto *protobuf.UserInfoReq
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(2, c)
@@ -6208,45 +6720,53 @@ func (c *EClient) reqUserInfoProtoBuf(userInfoRequestProto *protobuf.UserInfoReq
 
 	msg, err := proto.Marshal(userInfoRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // ReqCurrentTimeInMillis requests the current system time in milliseconds on the server side.
 func (c *EClient) ReqCurrentTimeInMillis() {
+	c.reportError(c.ReqCurrentTimeInMillisContext(context.Background()))
+}
+
+// ReqCurrentTimeInMillisContext is ReqCurrentTimeInMillis returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqCurrentTimeInMillisContext(ctx context.Context) (err error) {
+
+	withdraw := c.watchdog.ask(true)
+	defer func() {
+		if err != nil {
+			withdraw()
+		}
+	}()
 
 	if c.useProtoBuf(REQ_CURRENT_TIME_IN_MILLIS) {
-		c.reqCurrentTimeInMillisProtoBuf(createCurrentTimeInMillisRequestProto())
-		return
+		return c.reqCurrentTimeInMillisProtoBuf(ctx, createCurrentTimeInMillisRequestProto())
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CURRENT_TIME_IN_MILLIS {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support current time in millis requests", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CURRENT_TIME_IN_MILLIS {
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+" It does not support current time in millis requests", "")
 	}
 
 	me := NewMsgEncoder(1, c)
 
 	me.encodeMsgID(REQ_CURRENT_TIME_IN_MILLIS)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
-func (c *EClient) reqCurrentTimeInMillisProtoBuf(currentTimeInMillisRequestProto *protobuf.CurrentTimeInMillisRequest) {
+func (c *EClient) reqCurrentTimeInMillisProtoBuf(ctx context.Context, currentTimeInMillisRequestProto *protobuf.CurrentTimeInMillisRequest) error {
 
 	if !c.IsConnected() {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -6255,34 +6775,37 @@ func (c *EClient) reqCurrentTimeInMillisProtoBuf(currentTimeInMillisRequestProto
 
 	msg, err := proto.Marshal(currentTimeInMillisRequestProto)
 	if err != nil {
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(NO_VALID_ID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelContractData cancels contract data request.
 func (c *EClient) CancelContractData(reqID int64) {
-	c.cancelContractDataProtoBuf(createCancelContractDataProto(reqID))
+	c.reportError(c.CancelContractDataContext(context.Background(), reqID))
 }
 
-func (c *EClient) cancelContractDataProtoBuf(cancelContractDataProto *protobuf.CancelContractData) {
+// CancelContractDataContext is CancelContractData returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelContractDataContext(ctx context.Context, reqID int64) error {
+	return c.cancelContractDataProtoBuf(ctx, createCancelContractDataProto(reqID))
+}
+
+func (c *EClient) cancelContractDataProtoBuf(ctx context.Context, cancelContractDataProto *protobuf.CancelContractData) error {
 	reqID := NO_VALID_ID
 	if cancelContractDataProto.ReqId != nil {
 		reqID = int64(*cancelContractDataProto.ReqId)
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CANCEL_CONTRACT_DATA {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support contract data cancels.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CANCEL_CONTRACT_DATA {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support contract data cancels.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -6291,34 +6814,37 @@ func (c *EClient) cancelContractDataProtoBuf(cancelContractDataProto *protobuf.C
 
 	msg, err := proto.Marshal(cancelContractDataProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 // CancelHistoricalTicks cancels historical ticks request.
 func (c *EClient) CancelHistoricalTicks(reqID int64) {
-	c.cancelHistoricalTicksProtoBuf(createCancelHistoricalTicksProto(reqID))
+	c.reportError(c.CancelHistoricalTicksContext(context.Background(), reqID))
+}
+
+// CancelHistoricalTicksContext is CancelHistoricalTicks returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) CancelHistoricalTicksContext(ctx context.Context, reqID int64) error {
+	return c.cancelHistoricalTicksProtoBuf(ctx, createCancelHistoricalTicksProto(reqID))
 }
 
-func (c *EClient) cancelHistoricalTicksProtoBuf(cancelHistoricalTicksProto *protobuf.CancelHistoricalTicks) {
+func (c *EClient) cancelHistoricalTicksProtoBuf(ctx context.Context, cancelHistoricalTicksProto *protobuf.CancelHistoricalTicks) error {
 	reqID := NO_VALID_ID
 	if cancelHistoricalTicksProto.ReqId != nil {
 		reqID = int64(*cancelHistoricalTicksProto.ReqId)
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CANCEL_CONTRACT_DATA {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support historical ticks cancels.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CANCEL_CONTRACT_DATA {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support historical ticks cancels.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -6327,29 +6853,32 @@ func (c *EClient) cancelHistoricalTicksProtoBuf(cancelHistoricalTicksProto *prot
 
 	msg, err := proto.Marshal(cancelHistoricalTicksProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 func (c *EClient) ReqConfigProtoBuf(configRequestProto *protobuf.ConfigRequest) {
+	c.reportError(c.ReqConfigProtoBufContext(context.Background(), configRequestProto))
+}
+
+// ReqConfigProtoBufContext is ReqConfigProtoBuf returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) ReqConfigProtoBufContext(ctx context.Context, configRequestProto *protobuf.ConfigRequest) error {
 	reqID := NO_VALID_ID
 	if configRequestProto.ReqId != nil {
 		reqID = int64(*configRequestProto.ReqId)
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_CONFIG {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support config requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_CONFIG {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support config requests.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -6358,29 +6887,32 @@ func (c *EClient) ReqConfigProtoBuf(configRequestProto *protobuf.ConfigRequest)
 
 	msg, err := proto.Marshal(configRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
 
 func (c *EClient) UpdateConfigProtoBuf(updateConfigRequestProto *protobuf.UpdateConfigRequest) {
+	c.reportError(c.UpdateConfigProtoBufContext(context.Background(), updateConfigRequestProto))
+}
+
+// UpdateConfigProtoBufContext is UpdateConfigProtoBuf returning the errors detected client side instead of reporting them to EWrapper.Error.
+// ctx bounds the wait for the request to be queued.
+func (c *EClient) UpdateConfigProtoBufContext(ctx context.Context, updateConfigRequestProto *protobuf.UpdateConfigRequest) error {
 	reqID := NO_VALID_ID
 	if updateConfigRequestProto.ReqId != nil {
 		reqID = int64(*updateConfigRequestProto.ReqId)
 	}
 
 	if !c.IsConnected() {
-		c.wrapper.Error(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
 	}
 
-	if c.serverVersion < MIN_SERVER_VER_UPDATE_CONFIG {
-		c.wrapper.Error(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support update config requests.", "")
-		return
+	if c.ServerVersion() < MIN_SERVER_VER_UPDATE_CONFIG {
+		return NewIBError(reqID, currentTimeMillis(), UPDATE_TWS.Code, UPDATE_TWS.Msg+"  It does not support update config requests.", "")
 	}
 
 	me := NewMsgEncoder(1, c)
@@ -6389,11 +6921,10 @@ func (c *EClient) UpdateConfigProtoBuf(updateConfigRequestProto *protobuf.Update
 
 	msg, err := proto.Marshal(updateConfigRequestProto)
 	if err != nil {
-		c.wrapper.Error(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
-		return
+		return NewIBError(reqID, currentTimeMillis(), ERROR_ENCODING_PROTOBUF.Code, ERROR_ENCODING_PROTOBUF.Msg+err.Error(), "")
 	}
 
 	me.encodeProto(msg)
 
-	c.reqChan <- me.Bytes()
+	return c.enqueue(ctx, me)
 }
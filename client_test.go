@@ -1,6 +1,7 @@
 package ibapi
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -825,24 +826,24 @@ func TestOrderParentChildOperations(t *testing.T) {
 	ib := setupIBClient(t)
 	parentint64 := nextID()
 	childint64 := nextID()
-	ib.placeOrderProtoBuf(CreatePlaceOrderRequest(parentint64, IBMStockAtSmart(), LimitOrderProto("BUY", StringToDecimal("100"), 40, false)))
+	ib.reportError(ib.placeOrderProtoBuf(context.Background(), CreatePlaceOrderRequest(parentint64, IBMStockAtSmart(), LimitOrderProto("BUY", StringToDecimal("100"), 40, false))))
 	time.Sleep(1 * time.Second)
-	ib.placeOrderProtoBuf(CreatePlaceOrderRequest(childint64, MSFTStockAtSmart(), BetaHedgeOrder(parentint64, "SELL", "0.05", 75, true)))
+	ib.reportError(ib.placeOrderProtoBuf(context.Background(), CreatePlaceOrderRequest(childint64, MSFTStockAtSmart(), BetaHedgeOrder(parentint64, "SELL", "0.05", 75, true))))
 }
 
 func TestNewsOperationsProto(t *testing.T) {
 	ib := setupIBClient(t)
-	ib.reqHistoricalNewsProtoBuf(HistoricalNewsRequestWithEndTime(10001))
+	ib.reportError(ib.reqHistoricalNewsProtoBuf(context.Background(), HistoricalNewsRequestWithEndTime(10001)))
 	time.Sleep(1 * time.Second)
-	ib.reqHistoricalNewsProtoBuf(HistoricalNewsRequestWithStartTime(10002))
+	ib.reportError(ib.reqHistoricalNewsProtoBuf(context.Background(), HistoricalNewsRequestWithStartTime(10002)))
 }
 
 func TestTickDataOperationsProto(t *testing.T) {
 	ib := setupIBClient(t)
-	ib.reqMarketDataProtoBuf(OddLotBidAskQuotesRequest(20001, IBMStockAtSmart()))
+	ib.reportError(ib.reqMarketDataProtoBuf(context.Background(), OddLotBidAskQuotesRequest(20001, IBMStockAtSmart())))
 	time.Sleep(10 * time.Second)
-	ib.cancelMarketDataProtoBuf(CancelMarketDataRequest(20001))
+	ib.reportError(ib.cancelMarketDataProtoBuf(context.Background(), CancelMarketDataRequest(20001)))
 	time.Sleep(1 * time.Second)
-	ib.reqMarketDataProtoBuf(RegulatorySnapshotRequest(20002, IBMStockAtSmart()))
+	ib.reportError(ib.reqMarketDataProtoBuf(context.Background(), RegulatorySnapshotRequest(20002, IBMStockAtSmart())))
 	time.Sleep(10 * time.Second)
 }
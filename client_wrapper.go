@@ -0,0 +1,438 @@
+package ibapi
+
+import "time"
+
+// clientWrapper sits between the EClient internals and the user EWrapper so that the client can follow the session.
+// Every callback not overridden here goes straight to the user EWrapper.
+type clientWrapper struct {
+	EWrapper
+	c *EClient
+}
+
+func (w *clientWrapper) Error(reqID int64, errTime int64, errCode int64, errString string, advancedOrderRejectJson string) {
+	// Duplicate order id: skip it and resync with TWS
+	if errCode == ErrDuplicateOrderID.Code && reqID != NO_VALID_ID && w.c.orderIDs.bump(reqID) {
+		go w.c.ReqIDs(-1)
+	}
+
+	// Errors ending a blocking call or a subscription are returned by them
+	err := NewIBError(reqID, errTime, errCode, errString, advancedOrderRejectJson)
+	if err.Severity < SeverityRequestFatal || !(w.c.calls.fail(reqID, err) || w.c.streams.fail(reqID, err)) {
+		w.EWrapper.Error(reqID, errTime, errCode, errString, advancedOrderRejectJson)
+	}
+
+	if errCode == ErrMarketDepthReset.Code {
+		if b := orderBook(w.c.streams, reqID); b != nil {
+			b.reset()
+		}
+	}
+	w.c.trades.orderError(err)
+	w.c.subscriptions.onError(reqID, errCode)
+	w.c.gatewayConnectivity(errTime, errCode, errString)
+
+	// Connectivity between IB and TWS has been restored - data lost
+	if errCode == 1101 && w.c.subscriptions != nil {
+		w.c.streams.resetOrderBooks()
+		go w.c.subscriptions.replay(w.c)
+	}
+}
+
+func (w *clientWrapper) NextValidID(reqID int64) {
+	w.c.ready.setNextValidID(reqID)
+	w.c.orderIDs.seed(reqID)
+	w.EWrapper.NextValidID(reqID)
+}
+
+func (w *clientWrapper) ManagedAccounts(accountsList []string) {
+	w.c.ready.setManagedAccounts(accountsList)
+	w.c.accounts.managedAccounts(accountsList)
+	w.EWrapper.ManagedAccounts(accountsList)
+}
+
+// The answers to the watchdog probes do not reach the user EWrapper.
+
+func (w *clientWrapper) CurrentTime(t int64) {
+	if w.c.watchdog != nil && !w.c.watchdog.answer(false, time.Unix(t, 0), time.Now()) {
+		return
+	}
+	w.EWrapper.CurrentTime(t)
+}
+
+func (w *clientWrapper) CurrentTimeInMillis(timeInMillis int64) {
+	if w.c.watchdog != nil && !w.c.watchdog.answer(true, time.UnixMilli(timeInMillis), time.Now()) {
+		return
+	}
+	w.EWrapper.CurrentTimeInMillis(timeInMillis)
+}
+
+// Answers to the blocking calls do not reach the user EWrapper, except those without reqID.
+
+func (w *clientWrapper) ContractDetails(reqID int64, contractDetails *ContractDetails) {
+	if call := pending[ContractDetails](w.c.calls, reqID); call != nil {
+		call.add(*contractDetails)
+		return
+	}
+	w.EWrapper.ContractDetails(reqID, contractDetails)
+}
+
+func (w *clientWrapper) BondContractDetails(reqID int64, contractDetails *ContractDetails) {
+	if call := pending[ContractDetails](w.c.calls, reqID); call != nil {
+		call.add(*contractDetails)
+		return
+	}
+	w.EWrapper.BondContractDetails(reqID, contractDetails)
+}
+
+func (w *clientWrapper) ContractDetailsEnd(reqID int64) {
+	if call := finish[ContractDetails](w.c.calls, reqID); call != nil {
+		call.end()
+		return
+	}
+	w.EWrapper.ContractDetailsEnd(reqID)
+}
+
+func (w *clientWrapper) HistoricalData(reqID int64, bar *Bar) {
+	if call := pending[Bar](w.c.calls, reqID); call != nil {
+		call.add(*bar)
+		return
+	}
+	if s := stream[BarEvent](w.c.streams, reqID); s != nil {
+		s.push(BarEvent{Bar: *bar})
+		return
+	}
+	w.EWrapper.HistoricalData(reqID, bar)
+}
+
+func (w *clientWrapper) HistoricalDataEnd(reqID int64, startDateStr string, endDateStr string) {
+	if call := finish[Bar](w.c.calls, reqID); call != nil {
+		call.end()
+		return
+	}
+	if stream[BarEvent](w.c.streams, reqID) != nil {
+		return
+	}
+	w.EWrapper.HistoricalDataEnd(reqID, startDateStr, endDateStr)
+}
+
+func (w *clientWrapper) HistoricalTicks(reqID int64, ticks []HistoricalTick, done bool) {
+	if historicalTicksPageDone(w.c.calls, reqID, ticks, done) {
+		return
+	}
+	w.EWrapper.HistoricalTicks(reqID, ticks, done)
+}
+
+func (w *clientWrapper) HistoricalTicksBidAsk(reqID int64, ticks []HistoricalTickBidAsk, done bool) {
+	if historicalTicksPageDone(w.c.calls, reqID, ticks, done) {
+		return
+	}
+	w.EWrapper.HistoricalTicksBidAsk(reqID, ticks, done)
+}
+
+func (w *clientWrapper) HistoricalTicksLast(reqID int64, ticks []HistoricalTickLast, done bool) {
+	if historicalTicksPageDone(w.c.calls, reqID, ticks, done) {
+		return
+	}
+	w.EWrapper.HistoricalTicksLast(reqID, ticks, done)
+}
+
+// historicalTicksPageDone hands a page to the call waiting for it and tells whether there was one.
+func historicalTicksPageDone[T any](calls *pendingCalls, reqID int64, ticks []T, done bool) bool {
+	call := pending[T](calls, reqID)
+	if call == nil {
+		return false
+	}
+	for _, tick := range ticks {
+		call.add(tick)
+	}
+	if done {
+		if call := finish[T](calls, reqID); call != nil {
+			call.end()
+		}
+	}
+	return true
+}
+
+func (w *clientWrapper) HeadTimestamp(reqID int64, headTimestamp string) {
+	if call := finish[string](w.c.calls, reqID); call != nil {
+		call.add(headTimestamp)
+		call.end()
+		return
+	}
+	w.EWrapper.HeadTimestamp(reqID, headTimestamp)
+}
+
+func (w *clientWrapper) SymbolSamples(reqID int64, contractDescriptions []ContractDescription) {
+	if call := finish[[]ContractDescription](w.c.calls, reqID); call != nil {
+		call.add(contractDescriptions)
+		call.end()
+		return
+	}
+	w.EWrapper.SymbolSamples(reqID, contractDescriptions)
+}
+
+func (w *clientWrapper) SecurityDefinitionOptionParameter(reqID int64, exchange string, underlyingConID int64, tradingClass string, multiplier string, expirations []string, strikes []float64) {
+	if call := pending[SecDefOptParams](w.c.calls, reqID); call != nil {
+		call.add(SecDefOptParams{exchange, underlyingConID, tradingClass, multiplier, expirations, strikes})
+		return
+	}
+	w.EWrapper.SecurityDefinitionOptionParameter(reqID, exchange, underlyingConID, tradingClass, multiplier, expirations, strikes)
+}
+
+func (w *clientWrapper) SecurityDefinitionOptionParameterEnd(reqID int64) {
+	if call := finish[SecDefOptParams](w.c.calls, reqID); call != nil {
+		call.end()
+		return
+	}
+	w.EWrapper.SecurityDefinitionOptionParameterEnd(reqID)
+}
+
+func (w *clientWrapper) Position(account string, contract *Contract, position Decimal, avgCost float64) {
+	w.c.accounts.position(account, "", contract, position, avgCost)
+	if call := w.c.calls.positionsCall(); call != nil {
+		call.add(Position{account, contract, position, avgCost})
+	}
+	w.EWrapper.Position(account, contract, position, avgCost)
+}
+
+func (w *clientWrapper) PositionEnd() {
+	if call := w.c.calls.positionsCall(); call != nil {
+		call.end()
+	}
+	w.EWrapper.PositionEnd()
+}
+
+func (w *clientWrapper) CompletedOrder(contract *Contract, order *Order, orderState *OrderState) {
+	w.c.trades.completedOrder(contract, order, orderState)
+	if call := w.c.calls.completedOrdersCall(); call != nil {
+		call.add(CompletedOrder{contract, order, orderState})
+	}
+	w.EWrapper.CompletedOrder(contract, order, orderState)
+}
+
+func (w *clientWrapper) CompletedOrdersEnd() {
+	if call := w.c.calls.completedOrdersCall(); call != nil {
+		call.end()
+	}
+	w.EWrapper.CompletedOrdersEnd()
+}
+
+// Events of the subscriptions and tickers do not reach the user EWrapper.
+
+func (w *clientWrapper) TickPrice(reqID int64, tickType TickType, price float64, attrib TickAttrib) {
+	if s := stream[TickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickPriceEvent{tickType, price, attrib})
+		return
+	}
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.tickPrice(tickType, price)
+		return
+	}
+	w.EWrapper.TickPrice(reqID, tickType, price, attrib)
+}
+
+func (w *clientWrapper) TickSize(reqID int64, tickType TickType, size Decimal) {
+	if s := stream[TickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickSizeEvent{tickType, size})
+		return
+	}
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.tickSize(tickType, size)
+		return
+	}
+	w.EWrapper.TickSize(reqID, tickType, size)
+}
+
+func (w *clientWrapper) TickGeneric(reqID int64, tickType TickType, value float64) {
+	if s := stream[TickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickGenericEvent{tickType, value})
+		return
+	}
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.tickGeneric(tickType, value)
+		return
+	}
+	w.EWrapper.TickGeneric(reqID, tickType, value)
+}
+
+func (w *clientWrapper) TickString(reqID int64, tickType TickType, value string) {
+	if s := stream[TickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickStringEvent{tickType, value})
+		return
+	}
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.tickString(tickType, value)
+		return
+	}
+	w.EWrapper.TickString(reqID, tickType, value)
+}
+
+func (w *clientWrapper) TickOptionComputation(reqID int64, tickType TickType, tickAttrib int64, impliedVol float64, delta float64, optPrice float64, pvDividend float64, gamma float64, vega float64, theta float64, undPrice float64) {
+	if s := stream[TickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickOptionComputationEvent{tickType, tickAttrib, impliedVol, delta, optPrice, pvDividend, gamma, vega, theta, undPrice})
+		return
+	}
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.tickOptionComputation(tickType, OptionGreeks{tickAttrib, impliedVol, delta, optPrice, pvDividend, gamma, vega, theta, undPrice})
+		return
+	}
+	w.EWrapper.TickOptionComputation(reqID, tickType, tickAttrib, impliedVol, delta, optPrice, pvDividend, gamma, vega, theta, undPrice)
+}
+
+func (w *clientWrapper) TickReqParams(reqID int64, minTick float64, bboExchange string, snapshotPermissions int64) {
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.tickReqParams(minTick, bboExchange, snapshotPermissions)
+		return
+	}
+	w.EWrapper.TickReqParams(reqID, minTick, bboExchange, snapshotPermissions)
+}
+
+func (w *clientWrapper) MarketDataType(reqID int64, marketDataType int64) {
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.marketDataType(marketDataType)
+		return
+	}
+	w.EWrapper.MarketDataType(reqID, marketDataType)
+}
+
+func (w *clientWrapper) TickSnapshotEnd(reqID int64) {
+	if t := ticker(w.c.streams, reqID); t != nil {
+		t.snapshotEnd()
+		return
+	}
+	w.EWrapper.TickSnapshotEnd(reqID)
+}
+
+func (w *clientWrapper) TickByTickAllLast(reqID int64, tickType int64, time int64, price float64, size Decimal, tickAttribLast TickAttribLast, exchange string, specialConditions string) {
+	if s := stream[TickByTickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickByTickLastEvent{tickType, HistoricalTickLast{time, tickAttribLast, price, size, exchange, specialConditions}})
+		return
+	}
+	w.EWrapper.TickByTickAllLast(reqID, tickType, time, price, size, tickAttribLast, exchange, specialConditions)
+}
+
+func (w *clientWrapper) TickByTickBidAsk(reqID int64, time int64, bidPrice float64, askPrice float64, bidSize Decimal, askSize Decimal, tickAttribBidAsk TickAttribBidAsk) {
+	if s := stream[TickByTickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickByTickBidAskEvent{HistoricalTickBidAsk{time, tickAttribBidAsk, bidPrice, askPrice, bidSize, askSize}})
+		return
+	}
+	w.EWrapper.TickByTickBidAsk(reqID, time, bidPrice, askPrice, bidSize, askSize, tickAttribBidAsk)
+}
+
+func (w *clientWrapper) TickByTickMidPoint(reqID int64, time int64, midPoint float64) {
+	if s := stream[TickByTickEvent](w.c.streams, reqID); s != nil {
+		s.push(TickByTickMidPointEvent{time, midPoint})
+		return
+	}
+	w.EWrapper.TickByTickMidPoint(reqID, time, midPoint)
+}
+
+func (w *clientWrapper) RealtimeBar(reqID int64, time int64, open float64, high float64, low float64, close float64, volume Decimal, wap Decimal, count int64) {
+	if s := stream[RealTimeBar](w.c.streams, reqID); s != nil {
+		s.push(RealTimeBar{Time: time, Open: open, High: high, Low: low, Close: close, Volume: volume, Wap: wap, Count: count})
+		return
+	}
+	w.EWrapper.RealtimeBar(reqID, time, open, high, low, close, volume, wap, count)
+}
+
+func (w *clientWrapper) UpdateMktDepth(reqID int64, position int64, operation int64, side int64, price float64, size Decimal) {
+	if s := stream[DepthEvent](w.c.streams, reqID); s != nil {
+		s.push(DepthEvent{Position: position, Operation: operation, Side: side, Price: price, Size: size})
+		return
+	}
+	if b := orderBook(w.c.streams, reqID); b != nil {
+		b.apply(position, "", operation, side, price, size)
+		return
+	}
+	w.EWrapper.UpdateMktDepth(reqID, position, operation, side, price, size)
+}
+
+func (w *clientWrapper) UpdateMktDepthL2(reqID int64, position int64, marketMaker string, operation int64, side int64, price float64, size Decimal, isSmartDepth bool) {
+	if s := stream[DepthEvent](w.c.streams, reqID); s != nil {
+		s.push(DepthEvent{position, marketMaker, operation, side, price, size, isSmartDepth})
+		return
+	}
+	if b := orderBook(w.c.streams, reqID); b != nil {
+		b.apply(position, marketMaker, operation, side, price, size)
+		return
+	}
+	w.EWrapper.UpdateMktDepthL2(reqID, position, marketMaker, operation, side, price, size, isSmartDepth)
+}
+
+func (w *clientWrapper) RerouteMktDepthReq(reqID int64, conID int64, exchange string) {
+	if b := orderBook(w.c.streams, reqID); b != nil {
+		b.rerouted(conID, exchange)
+	}
+	w.EWrapper.RerouteMktDepthReq(reqID, conID, exchange)
+}
+
+func (w *clientWrapper) HistoricalDataUpdate(reqID int64, bar *Bar) {
+	if s := stream[BarEvent](w.c.streams, reqID); s != nil {
+		s.push(BarEvent{Bar: *bar, Update: true})
+		return
+	}
+	w.EWrapper.HistoricalDataUpdate(reqID, bar)
+}
+
+// Order state feeding the trade tracker.
+
+func (w *clientWrapper) OpenOrder(orderID int64, contract *Contract, order *Order, orderState *OrderState) {
+	w.c.trades.openOrder(orderID, contract, order, orderState)
+	w.EWrapper.OpenOrder(orderID, contract, order, orderState)
+}
+
+func (w *clientWrapper) OrderStatus(orderID int64, status string, filled Decimal, remaining Decimal, avgFillPrice float64, permID int64, parentID int64, lastFillPrice float64, clientID int64, whyHeld string, mktCapPrice float64) {
+	w.c.trades.orderStatus(orderID, status, filled, remaining, avgFillPrice, permID, lastFillPrice, clientID, whyHeld)
+	w.EWrapper.OrderStatus(orderID, status, filled, remaining, avgFillPrice, permID, parentID, lastFillPrice, clientID, whyHeld, mktCapPrice)
+}
+
+func (w *clientWrapper) ExecDetails(reqID int64, contract *Contract, execution *Execution) {
+	w.c.trades.execDetails(contract, execution)
+	w.EWrapper.ExecDetails(reqID, contract, execution)
+}
+
+func (w *clientWrapper) CommissionAndFeesReport(commissionAndFeesReport CommissionAndFeesReport) {
+	w.c.trades.commissionAndFeesReport(commissionAndFeesReport)
+	w.EWrapper.CommissionAndFeesReport(commissionAndFeesReport)
+}
+
+func (w *clientWrapper) OrderBound(permID int64, clientID int64, orderID int64) {
+	w.c.trades.orderBound(permID, clientID, orderID)
+	w.EWrapper.OrderBound(permID, clientID, orderID)
+}
+
+// Account state feeding the account cache.
+
+func (w *clientWrapper) UpdateAccountValue(tag string, val string, currency string, accountName string) {
+	w.c.accounts.updateAccountValue(tag, val, currency, accountName)
+	w.EWrapper.UpdateAccountValue(tag, val, currency, accountName)
+}
+
+func (w *clientWrapper) UpdatePortfolio(contract *Contract, position Decimal, marketPrice float64, marketValue float64, averageCost float64, unrealizedPNL float64, realizedPNL float64, accountName string) {
+	w.c.accounts.updatePortfolio(contract, position, marketPrice, marketValue, averageCost, unrealizedPNL, realizedPNL, accountName)
+	w.EWrapper.UpdatePortfolio(contract, position, marketPrice, marketValue, averageCost, unrealizedPNL, realizedPNL, accountName)
+}
+
+func (w *clientWrapper) UpdateAccountTime(timeStamp string) {
+	w.c.accounts.updateAccountTime(timeStamp)
+	w.EWrapper.UpdateAccountTime(timeStamp)
+}
+
+func (w *clientWrapper) AccountDownloadEnd(accountName string) {
+	w.c.accounts.accountDownloadEnd(accountName)
+	w.EWrapper.AccountDownloadEnd(accountName)
+}
+
+func (w *clientWrapper) AccountSummary(reqID int64, account string, tag string, value string, currency string) {
+	w.c.accounts.setValue(account, "", tag, value, currency)
+	w.EWrapper.AccountSummary(reqID, account, tag, value, currency)
+}
+
+func (w *clientWrapper) AccountUpdateMulti(reqID int64, account string, modelCode string, key string, value string, currency string) {
+	w.c.accounts.setValue(account, modelCode, key, value, currency)
+	w.EWrapper.AccountUpdateMulti(reqID, account, modelCode, key, value, currency)
+}
+
+func (w *clientWrapper) PositionMulti(reqID int64, account string, modelCode string, contract *Contract, pos Decimal, avgCost float64) {
+	w.c.accounts.position(account, modelCode, contract, pos, avgCost)
+	w.EWrapper.PositionMulti(reqID, account, modelCode, contract, pos, avgCost)
+}
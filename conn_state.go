@@ -0,0 +1,97 @@
+package ibapi
+
+import "time"
+
+// ConnStateCause tells why the connection state changed.
+type ConnStateCause int
+
+const (
+	CauseConnectRequested    ConnStateCause = iota // Connect was called
+	CauseSessionStarted                            // handshake and startAPI done
+	CauseConnectFailed                             // dial or handshake failed
+	CauseDisconnectRequested                       // Disconnect was called
+	CauseSessionLost                               // socket closed, I/O error or dead session detected by the watchdog
+	CauseReconnectFailed                           // the reconnect policy gave up
+	CauseFailback                                  // moving back to the preferred endpoint
+	CauseGateway                                   // TWS/IBGW reported its connectivity with IB
+)
+
+func (c ConnStateCause) String() string {
+	switch c {
+	case CauseConnectRequested:
+		return "connect requested"
+	case CauseSessionStarted:
+		return "session started"
+	case CauseConnectFailed:
+		return "connect failed"
+	case CauseDisconnectRequested:
+		return "disconnect requested"
+	case CauseSessionLost:
+		return "session lost"
+	case CauseReconnectFailed:
+		return "reconnect failed"
+	case CauseFailback:
+		return "failback"
+	case CauseGateway:
+		return "gateway"
+	default:
+		return "unknown cause"
+	}
+}
+
+// ConnStateChange is a transition of the connection state.
+type ConnStateChange struct {
+	From  ConnState
+	To    ConnState
+	Cause ConnStateCause
+	// Err details the cause when known: the dial or I/O error, ErrWatchdogTimeout,
+	// or for CauseGateway the *IBError of the 1100, 1101, 1102 or 2110 message.
+	Err  error
+	Time time.Time
+}
+
+// AddConnStateObserver registers fn to be called on every connection state transition, in order.
+// fn runs synchronously in the goroutine making the transition: it must return quickly and must not call Connect or Disconnect.
+// The returned function unregisters fn.
+func (c *EClient) AddConnStateObserver(fn func(ConnStateChange)) (remove func()) {
+	return c.observers.add(fn)
+}
+
+// stateChanged logs a transition, tears down what the lost session leaves behind and notifies the observers.
+func (c *EClient) stateChanged(from, to ConnState, cause ConnStateCause, err error) {
+	if from == to {
+		return
+	}
+	log.Debug().Stringer("from", from).Stringer("to", to).Stringer("cause", cause).AnErr("reason", err).Msg("connection state changed")
+
+	c.teardownSession(to)
+
+	c.observers.notify(ConnStateChange{From: from, To: to, Cause: cause, Err: err, Time: time.Now()})
+}
+
+// endSession ends the current session for the given reason, the supervisor takes it from there.
+func (c *EClient) endSession(err error) {
+	c.sessionErr.CompareAndSwap(nil, &err)
+	c.cancel()
+}
+
+// sessionEndError returns the reason given to endSession, nil if the session ended otherwise.
+func (c *EClient) sessionEndError() error {
+	if err := c.sessionErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// gatewayConnectivity follows the connectivity between TWS/IBGW and IB reported by the 1100, 1101, 1102 and 2110 messages.
+func (c *EClient) gatewayConnectivity(errTime int64, errCode int64, errString string) {
+	err := NewIBError(NO_VALID_ID, errTime, errCode, errString, "")
+	switch errCode {
+	case 1100, 2110:
+		// Connectivity between IB and TWS lost, or between TWS and the server broken
+		c.compareAndSetConnState(CONNECTED, CONNECTIVITY_LOST, CauseGateway, err)
+	case 1101, 1102:
+		// Connectivity restored, market data lost or maintained
+		c.compareAndSetConnState(CONNECTIVITY_LOST, CONNECTED, CauseGateway, err)
+	}
+}
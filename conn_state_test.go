@@ -0,0 +1,115 @@
+package ibapi
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type stateRecorder struct {
+	mu      sync.Mutex
+	changes []ConnStateChange
+}
+
+func (r *stateRecorder) record(change ConnStateChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, change)
+}
+
+func (r *stateRecorder) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.changes)
+}
+
+func (r *stateRecorder) at(i int) ConnStateChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.changes[i]
+}
+
+func TestConnStateObserver(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	rec := &stateRecorder{}
+	ib := NewEClient(nil)
+	remove := ib.AddConnStateObserver(rec.record)
+
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	session := gw.nextSession(t)
+
+	// IB connectivity lost then restored by the gateway
+	writeFrame(session, ERR_MSG, NO_VALID_ID, 1100, "Connectivity between IB and TWS has been lost.", "", currentTimeMillis())
+	waitFor(t, "connectivity lost", func() bool { return ib.ConnState() == CONNECTIVITY_LOST })
+	if !ib.IsConnected() {
+		t.Error("requests must still be accepted while the gateway connectivity is lost")
+	}
+	writeFrame(session, ERR_MSG, NO_VALID_ID, 1102, "Connectivity between IB and TWS has been restored - data maintained.", "", currentTimeMillis())
+	waitFor(t, "connectivity restored", func() bool { return ib.ConnState() == CONNECTED })
+
+	if err := ib.Disconnect(); err != nil {
+		t.Fatalf("disconnect: %v", err)
+	}
+
+	expected := []struct {
+		from, to ConnState
+		cause    ConnStateCause
+		code     int64
+	}{
+		{DISCONNECTED, CONNECTING, CauseConnectRequested, 0},
+		{CONNECTING, CONNECTED, CauseSessionStarted, 0},
+		{CONNECTED, CONNECTIVITY_LOST, CauseGateway, 1100},
+		{CONNECTIVITY_LOST, CONNECTED, CauseGateway, 1102},
+		{CONNECTED, DISCONNECTED, CauseDisconnectRequested, 0},
+	}
+	if rec.len() != len(expected) {
+		t.Fatalf("expected %d transitions, got %d: %+v", len(expected), rec.len(), rec.changes)
+	}
+	for i, want := range expected {
+		got := rec.at(i)
+		if got.From != want.from || got.To != want.to || got.Cause != want.cause {
+			t.Errorf("transition %d: expected %v -> %v (%v), got %v -> %v (%v)", i, want.from, want.to, want.cause, got.From, got.To, got.Cause)
+		}
+		var ibErr *IBError
+		if want.code != 0 && (!errors.As(got.Err, &ibErr) || ibErr.Code != want.code) {
+			t.Errorf("transition %d: expected code %d, got %v", i, want.code, got.Err)
+		}
+		if got.Time.IsZero() {
+			t.Errorf("transition %d: missing timestamp", i)
+		}
+	}
+
+	// Removed observers are no longer called
+	remove()
+	ib.Connect(host, port, 1)
+	ib.Disconnect()
+	if rec.len() != len(expected) {
+		t.Errorf("removed observer still called")
+	}
+}
+
+func TestConnStateSessionLostCause(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	rec := &stateRecorder{}
+	ib := NewEClient(nil)
+	ib.AddConnStateObserver(rec.record)
+	ib.SetWatchdog(&WatchdogConfig{Interval: 20 * time.Millisecond, Timeout: 50 * time.Millisecond, MaxMissed: 1})
+
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+
+	waitFor(t, "session declared dead", func() bool { return ib.ConnState() == DISCONNECTED })
+	last := rec.at(rec.len() - 1)
+	if last.Cause != CauseSessionLost || !errors.Is(last.Err, ErrWatchdogTimeout) {
+		t.Errorf("expected session lost by watchdog, got %v (%v)", last.Cause, last.Err)
+	}
+}
@@ -0,0 +1,114 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestConnectContextHandshakeTimeout(t *testing.T) {
+	// A gateway accepting the socket but never answering the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create listener: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	addr := listener.Addr().(*net.TCPAddr)
+
+	ib := NewEClient(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = ib.ConnectContext(ctx, addr.IP.String(), addr.Port, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("ConnectContext returned after %v", elapsed)
+	}
+	if ib.IsConnected() || ib.conn.IsConnected() {
+		t.Error("client left connected after a failed ConnectContext")
+	}
+	if ib.serverVersion != -1 || ib.host != "" {
+		t.Error("client left half-initialized after a failed ConnectContext")
+	}
+
+	// The client must be reusable
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+	if err := ib.ConnectContext(context.Background(), host, port, 1); err != nil {
+		t.Fatalf("connect after failure: %v", err)
+	}
+	gw.nextRequest(t, START_API)
+	ib.Disconnect()
+}
+
+func TestConnectContextCanceled(t *testing.T) {
+	ib := NewEClient(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ib.ConnectContext(ctx, "127.0.0.1", 1, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if ib.IsConnected() {
+		t.Error("client connected with a canceled context")
+	}
+}
+
+func TestConnectAndWait(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	go func() {
+		conn := gw.nextSession(t)
+		gw.nextRequest(t, START_API)
+		writeFrame(conn, NEXT_VALID_ID, 1, 42)
+		writeFrame(conn, MANAGED_ACCTS, 1, "DU111,DU222")
+		// Later ids do not change the session readiness
+		writeFrame(conn, NEXT_VALID_ID, 1, 43)
+	}()
+
+	ib := NewEClient(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ready, err := ib.ConnectAndWait(ctx, host, port, 1)
+	if err != nil {
+		t.Fatalf("connect and wait: %v", err)
+	}
+	defer ib.Disconnect()
+
+	if ready.NextValidID != 42 {
+		t.Errorf("expected NextValidID 42, got %d", ready.NextValidID)
+	}
+	if !slices.Equal(ready.ManagedAccounts, []string{"DU111", "DU222"}) {
+		t.Errorf("unexpected ManagedAccounts %v", ready.ManagedAccounts)
+	}
+}
+
+func TestConnectAndWaitTimeout(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	ib := NewEClient(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := ib.ConnectAndWait(ctx, host, port, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if ib.IsConnected() {
+		t.Error("client left connected after ConnectAndWait timed out")
+	}
+}
@@ -1,8 +1,10 @@
 package ibapi
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,11 +15,25 @@ const (
 	reconnectDelay       = 500 * time.Millisecond
 )
 
-// Connection is a TCPConn wrapper with lock-free statistics and minimal contention.
+// Dialer opens the transport to TWS/IBGW. *net.Dialer, *tls.Dialer and most proxy dialers implement it.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// DialerFunc adapts a function to the Dialer interface, e.g. to dial a Unix socket whatever the address.
+type DialerFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// DialContext calls f(ctx, network, address).
+func (f DialerFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f(ctx, network, address)
+}
+
+// Connection is a net.Conn wrapper with lock-free statistics and minimal contention.
 type Connection struct {
 	// Connection state - protected by mutex for host/port coordination only
 	mu          sync.RWMutex
-	tcpConn     atomic.Pointer[net.TCPConn] // Lock-free pointer for maximum performance
+	netConn     atomic.Pointer[net.Conn] // Lock-free pointer for maximum performance
+	dialer      Dialer                   // nil dials tcp4 with a net.Dialer
 	wrapper     EWrapper
 	host        string
 	port        int
@@ -28,9 +44,13 @@ type Connection struct {
 	numMsgSent   int64 // atomic
 	numBytesRecv int64 // atomic
 	numMsgRecv   int64 // atomic
+	lastRecv     int64 // atomic: unix nanoseconds of the last read
 
 	// Reconnection control - prevents multiple concurrent reconnections
 	reconnecting int32 // atomic: 0=not reconnecting, 1=reconnecting
+
+	// sessionManaged disables the socket redial on write errors, the owning EClient rebuilds the whole session instead
+	sessionManaged bool
 }
 
 func (c *Connection) Write(bs []byte) (int, error) {
@@ -46,8 +66,14 @@ func (c *Connection) Write(bs []byte) (int, error) {
 			return n, nil
 		}
 
+		if c.sessionManaged {
+			return n, err
+		}
+
 		// Write failed, try to reconnect
 		log.Warn().Err(err).Msg("Write error detected, attempting to reconnect...")
+	} else if c.sessionManaged {
+		return 0, fmt.Errorf("connection not available")
 	}
 
 	// Slow path: reconnect and retry
@@ -84,20 +110,42 @@ func (c *Connection) Read(bs []byte) (int, error) {
 	// Lock-free atomic statistics update
 	atomic.AddInt64(&c.numBytesRecv, int64(n))
 	atomic.AddInt64(&c.numMsgRecv, 1)
+	if n > 0 {
+		atomic.StoreInt64(&c.lastRecv, time.Now().UnixNano())
+	}
 
 	log.Trace().Int("nBytes", n).Msg("conn read")
 
 	return n, err
 }
 
-// getConn returns the current TCP connection in a lock-free way
-func (c *Connection) getConn() *net.TCPConn {
-	return c.tcpConn.Load()
+// getConn returns the current connection in a lock-free way
+func (c *Connection) getConn() net.Conn {
+	if conn := c.netConn.Load(); conn != nil {
+		return *conn
+	}
+	return nil
+}
+
+// setConn swaps the connection and returns the previous value.
+func (c *Connection) setConn(conn net.Conn) net.Conn {
+	var newConn *net.Conn
+	if conn != nil {
+		newConn = &conn
+	}
+	if oldConn := c.netConn.Swap(newConn); oldConn != nil {
+		return *oldConn
+	}
+	return nil
 }
 
-// setConn swaps the TCP connection and returns the previous value.
-func (c *Connection) setConn(conn *net.TCPConn) *net.TCPConn {
-	return c.tcpConn.Swap(conn)
+// setDeadline sets the read and write deadline of the underlying socket, a zero value clears it.
+func (c *Connection) setDeadline(t time.Time) error {
+	conn := c.getConn()
+	if conn == nil {
+		return fmt.Errorf("connection not available")
+	}
+	return conn.SetDeadline(t)
 }
 
 func (c *Connection) reset() {
@@ -106,9 +154,15 @@ func (c *Connection) reset() {
 	atomic.StoreInt64(&c.numBytesRecv, 0)
 	atomic.StoreInt64(&c.numMsgSent, 0)
 	atomic.StoreInt64(&c.numMsgRecv, 0)
+	atomic.StoreInt64(&c.lastRecv, 0)
 }
 
 func (c *Connection) connect(host string, port int) error {
+	return c.connectContext(context.Background(), host, port)
+}
+
+// connectContext dials host:port, giving up as soon as ctx is done.
+func (c *Connection) connectContext(ctx context.Context, host string, port int) error {
 	// Protect host/port assignment with mutex to prevent races
 	c.mu.Lock()
 	c.host = host
@@ -118,29 +172,35 @@ func (c *Connection) connect(host string, port int) error {
 	c.reset()
 
 	// Use the parameters directly instead of reading from struct to avoid races
-	address := fmt.Sprintf("%v:%v", host, port)
-	addr, err := net.ResolveTCPAddr("tcp4", address)
+	newConn, err := c.dial(ctx, host, port)
 	if err != nil {
-		log.Error().Err(err).Str("host", address).Msg("failed to resove tcp address")
+		log.Error().Err(err).Str("host", host).Int("port", port).Msg("failed to dial")
 		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), FAIL_CREATE_SOCK.Code, FAIL_CREATE_SOCK.Msg, "")
 		return err
 	}
 
-	newConn, err := net.DialTCP("tcp4", nil, addr)
-	if err != nil {
-		log.Error().Err(err).Any("address", addr).Msg("failed to dial tcp")
-		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), FAIL_CREATE_SOCK.Code, FAIL_CREATE_SOCK.Msg, "")
-		return err
+	c.attach(newConn)
+
+	log.Debug().Any("address", newConn.RemoteAddr()).Msg("socket connected")
+	return nil
+}
+
+// dial opens a new transport to host:port without touching the current one.
+func (c *Connection) dial(ctx context.Context, host string, port int) (net.Conn, error) {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	if c.dialer != nil {
+		return c.dialer.DialContext(ctx, "tcp", address)
 	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp4", address)
+}
 
-	// Atomically update connection state (close previous socket if necessary)
-	if oldConn := c.setConn(newConn); oldConn != nil {
+// attach makes conn the current connection, closing the previous one if necessary.
+func (c *Connection) attach(conn net.Conn) {
+	if oldConn := c.setConn(conn); oldConn != nil {
 		_ = oldConn.Close()
 	}
 	atomic.StoreInt32(&c.isConnected, 1)
-
-	log.Debug().Any("address", newConn.RemoteAddr()).Msg("tcp socket connected")
-	return nil
 }
 
 func (c *Connection) reconnect() error {
@@ -220,6 +280,14 @@ func (c *Connection) IsConnected() bool {
 	return atomic.LoadInt32(&c.isConnected) == 1
 }
 
+// lastRecvTime returns the time of the last read, zero if nothing was read yet.
+func (c *Connection) lastRecvTime() time.Time {
+	if ns := atomic.LoadInt64(&c.lastRecv); ns != 0 {
+		return time.Unix(0, ns)
+	}
+	return time.Time{}
+}
+
 // GetStatistics returns current connection statistics atomically
 func (c *Connection) GetStatistics() (bytesSent, msgSent, bytesRecv, msgRecv int64) {
 	return atomic.LoadInt64(&c.numBytesSent),
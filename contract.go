@@ -3,6 +3,7 @@ package ibapi
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 )
 
 // LegOpenClose .
@@ -126,6 +127,17 @@ func NewContract() *Contract {
 	}
 }
 
+// clone returns a copy of the contract sharing nothing mutable with it.
+func (c *Contract) clone() *Contract {
+	cc := *c
+	cc.ComboLegs = slices.Clone(c.ComboLegs)
+	if c.DeltaNeutralContract != nil {
+		dnc := *c.DeltaNeutralContract
+		cc.DeltaNeutralContract = &dnc
+	}
+	return &cc
+}
+
 func (c *Contract) Equal(other *Contract) bool {
 	if c.ConID != 0 && other.ConID != 0 {
 		return c.ConID == other.ConID
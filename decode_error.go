@@ -0,0 +1,109 @@
+package ibapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// maxDecodeErrorBytes bounds the raw bytes quoted in the message of a DecodeError.
+const maxDecodeErrorBytes = 256
+
+// DecodeError describes an incoming message the decoder failed to process.
+type DecodeError struct {
+	MsgID    IN     // message id, PROTOBUF_MSG_ID removed, NO_VALID_ID if unreadable
+	ProtoBuf bool   // message encoded with protobuf
+	Msg      []byte // raw message, without the size header
+	Reason   any    // value recovered from the decoder panic
+}
+
+func (e *DecodeError) Error() string {
+	raw := e.Msg
+	suffix := ""
+	if len(raw) > maxDecodeErrorBytes {
+		raw = raw[:maxDecodeErrorBytes]
+		suffix = fmt.Sprintf("... (%d bytes)", len(e.Msg))
+	}
+	return fmt.Sprintf("msgID %d: %v: %q%s", e.MsgID, e.Reason, raw, suffix)
+}
+
+// SetStrictDecoding sets whether a message failing to decode ends the session.
+// By default the faulty message is skipped and reported through EWrapper.Error with the BAD_MESSAGE code
+// and to the DecodeErrorHandler, while the session continues.
+// It must be called before Connect.
+func (c *EClient) SetStrictDecoding(strict bool) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	c.strictDecoding = strict
+}
+
+// SetDecodeErrorHandler sets a function receiving every message skipped because it failed to decode, nil removes it.
+// It is called from the decoder goroutine. It must be called before Connect.
+func (c *EClient) SetDecodeErrorHandler(handler func(*DecodeError)) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	c.decodeErrorHandler = handler
+}
+
+// processMsg decodes a message and calls the wrapper.
+// Unless strict, a malformed message is recovered and reported so that the session continues.
+// Any other panic, like one raised by a wrapper method, is not recovered.
+func (d *EDecoder) processMsg(msgBytes []byte) {
+	if d.strict {
+		d.parseAndProcessMsg(msgBytes)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			fault, ok := r.(decodeFault)
+			if !ok {
+				panic(r)
+			}
+			d.reportBadMsg(msgBytes, fault)
+		}
+	}()
+	d.parseAndProcessMsg(msgBytes)
+}
+
+func (d *EDecoder) reportBadMsg(msgBytes []byte, reason any) {
+	msgID, protoBuf := d.peekMsgID(msgBytes)
+	decodeErr := &DecodeError{MsgID: msgID, ProtoBuf: protoBuf, Msg: msgBytes, Reason: reason}
+	log.Error().Int64("msgID", msgID).Interface("reason", reason).Msg("failed to process message, skipping it")
+
+	d.wrapper.Error(NO_VALID_ID, currentTimeMillis(), BAD_MESSAGE.Code, BAD_MESSAGE.Msg+" "+decodeErr.Error(), "")
+	if d.onError != nil {
+		d.onError(decodeErr)
+	}
+}
+
+// peekMsgID reads the message id without panicking on a malformed message.
+func (d *EDecoder) peekMsgID(msgBytes []byte) (IN, bool) {
+	var msgID int64
+	if d.serverVersion >= MIN_SERVER_VER_PROTOBUF {
+		if len(msgBytes) < 4 {
+			return NO_VALID_ID, false
+		}
+		msgID = int64(binary.BigEndian.Uint32(msgBytes[:4]))
+	} else {
+		field, _, _ := bytes.Cut(msgBytes, []byte{delim})
+		id, err := strconv.ParseInt(string(field), 10, 64)
+		if err != nil {
+			return NO_VALID_ID, false
+		}
+		msgID = id
+	}
+	if msgID >= PROTOBUF_MSG_ID {
+		return msgID - PROTOBUF_MSG_ID, true
+	}
+	return msgID, false
+}
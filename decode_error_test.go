@@ -0,0 +1,149 @@
+package ibapi
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type decodeWrapper struct {
+	Wrapper
+	mu          sync.Mutex
+	errCodes    []int64
+	errStrings  []string
+	nextValidID int64
+}
+
+func (w *decodeWrapper) Error(reqID int64, errTime int64, errCode int64, errString string, advancedOrderRejectJson string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.errCodes = append(w.errCodes, errCode)
+	w.errStrings = append(w.errStrings, errString)
+}
+
+func (w *decodeWrapper) NextValidID(reqID int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nextValidID = reqID
+}
+
+func (w *decodeWrapper) lastNextValidID() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.nextValidID
+}
+
+func textMsg(fields ...string) []byte {
+	var b bytes.Buffer
+	for _, f := range fields {
+		b.WriteString(f)
+		b.WriteByte(delim)
+	}
+	return b.Bytes()
+}
+
+func TestDecoderSkipsBadMessage(t *testing.T) {
+	w := &decodeWrapper{}
+	var decodeErrs []*DecodeError
+	d := &EDecoder{wrapper: w, serverVersion: fakeGatewayVersion, onError: func(e *DecodeError) { decodeErrs = append(decodeErrs, e) }}
+
+	bad := textMsg("9", "1", "not a number")
+	d.processMsg(bad)
+	d.processMsg(textMsg("9", "1", "42"))
+
+	if len(w.errCodes) != 1 || w.errCodes[0] != BAD_MESSAGE.Code {
+		t.Fatalf("expected one BAD_MESSAGE error, got %v", w.errCodes)
+	}
+	if !strings.Contains(w.errStrings[0], "msgID 9") || !strings.Contains(w.errStrings[0], "not a number") {
+		t.Errorf("error does not carry the message id and bytes: %s", w.errStrings[0])
+	}
+	if len(decodeErrs) != 1 || decodeErrs[0].MsgID != NEXT_VALID_ID || !bytes.Equal(decodeErrs[0].Msg, bad) {
+		t.Errorf("unexpected decode errors %+v", decodeErrs)
+	}
+	if w.lastNextValidID() != 42 {
+		t.Errorf("the following message was not processed")
+	}
+}
+
+func TestDecoderStrict(t *testing.T) {
+	d := &EDecoder{wrapper: &decodeWrapper{}, serverVersion: fakeGatewayVersion, strict: true}
+	defer func() {
+		if recover() == nil {
+			t.Error("strict decoder did not fail fast")
+		}
+	}()
+	d.processMsg(textMsg("9", "1", "not a number"))
+}
+
+type panickingWrapper struct {
+	decodeWrapper
+}
+
+func (w *panickingWrapper) NextValidID(reqID int64) {
+	panic("application bug")
+}
+
+func TestDecoderLetsWrapperPanic(t *testing.T) {
+	w := &panickingWrapper{}
+	d := &EDecoder{wrapper: w, serverVersion: fakeGatewayVersion}
+	defer func() {
+		if r := recover(); r != "application bug" {
+			t.Errorf("expected the wrapper panic, got %v", r)
+		}
+		if len(w.errCodes) != 0 {
+			t.Errorf("wrapper panic reported as a decode error: %v", w.errCodes)
+		}
+	}()
+	d.processMsg(textMsg("9", "1", "42"))
+}
+
+func (w *panickingWrapper) UpdateNewsBulletin(msgID int64, msgType int64, newsMessage string, originExch string) {
+	panic("application bug")
+}
+
+func TestDecoderLetsWrapperPanicThroughClient(t *testing.T) {
+	w := &panickingWrapper{}
+	// UpdateNewsBulletin is not intercepted by the client: the user method is called through the embedded EWrapper
+	d := &EDecoder{wrapper: NewEClient(w).wrapper, serverVersion: fakeGatewayVersion}
+	defer func() {
+		if r := recover(); r != "application bug" {
+			t.Errorf("expected the wrapper panic, got %v", r)
+		}
+		if len(w.errCodes) != 0 {
+			t.Errorf("wrapper panic reported as a decode error: %v", w.errCodes)
+		}
+	}()
+	d.processMsg(textMsg("14", "1", "7", "1", "news", "NYSE"))
+}
+
+func TestPeekMsgID(t *testing.T) {
+	d := &EDecoder{serverVersion: MIN_SERVER_VER_PROTOBUF}
+	if id, pb := d.peekMsgID([]byte{0, 0, 0, 203, 1}); id != ORDER_STATUS || !pb {
+		t.Errorf("expected protobuf ORDER_STATUS, got %d %v", id, pb)
+	}
+	if id, _ := d.peekMsgID([]byte{0}); id != NO_VALID_ID {
+		t.Errorf("expected NO_VALID_ID for a truncated message, got %d", id)
+	}
+}
+
+func TestSessionSurvivesBadMessage(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	w := &decodeWrapper{}
+	ib := NewEClient(w)
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+	session := gw.nextSession(t)
+
+	writeFrame(session, NEXT_VALID_ID, 1, "garbage")
+	writeFrame(session, NEXT_VALID_ID, 1, 7)
+
+	waitFor(t, "next message processed", func() bool { return w.lastNextValidID() == 7 })
+	if !ib.IsConnected() {
+		t.Error("a bad message ended the session")
+	}
+}
@@ -16,6 +16,8 @@ import (
 type EDecoder struct {
 	wrapper       EWrapper
 	serverVersion Version
+	strict        bool               // a message failing to decode ends the session
+	onError       func(*DecodeError) // receives the messages skipped because they failed to decode
 }
 
 func (d *EDecoder) parseAndProcessMsg(msgBytes []byte) {
@@ -1612,7 +1614,7 @@ func (d *EDecoder) processExecutionDetailsMsgProtoBuf(msgBuf *MsgBuffer) {
 	var executionDetailsProto protobuf.ExecutionDetails
 	err := proto.Unmarshal(msgBuf.Bytes(), &executionDetailsProto)
 	if err != nil {
-		log.Panic().Err(err).Msg("processExecutionDetailsMsgProtoBuf unmarshal error")
+		panic(decodeFault{msg: "processExecutionDetailsMsgProtoBuf unmarshal error", err: err})
 	}
 
 	d.wrapper.ExecDetailsProtoBuf(&executionDetailsProto)
@@ -2190,7 +2192,7 @@ func (d *EDecoder) processOpenOrderEndMsgProtoBuf(msgBuf *MsgBuffer) {
 	var openOrdersEndProto protobuf.OpenOrdersEnd
 	err := proto.Unmarshal(msgBuf.Bytes(), &openOrdersEndProto)
 	if err != nil {
-		log.Panic().Err(err).Msg("processOpenOrderEndMsgProtoBuf unmarshal error")
+		panic(decodeFault{msg: "processOpenOrderEndMsgProtoBuf unmarshal error", err: err})
 	}
 
 	d.wrapper.OpenOrdersEndProtoBuf(&openOrdersEndProto)
@@ -2240,7 +2242,7 @@ func (d *EDecoder) processExecutionDetailsEndMsgProtoBuf(msgBuf *MsgBuffer) {
 	var executionDetailsEndProto protobuf.ExecutionDetailsEnd
 	err := proto.Unmarshal(msgBuf.Bytes(), &executionDetailsEndProto)
 	if err != nil {
-		log.Panic().Err(err).Msg("processExecutionDetailsEndMsgProtoBuf unmarshal error")
+		panic(decodeFault{msg: "processExecutionDetailsEndMsgProtoBuf unmarshal error", err: err})
 	}
 
 	d.wrapper.ExecDetailsEndProtoBuf(&executionDetailsEndProto)
@@ -4135,7 +4137,7 @@ func (d *EDecoder) processCompletedOrderMsgProtoBuf(msgBuf *MsgBuffer) {
 	var completedOrderProto protobuf.CompletedOrder
 	err := proto.Unmarshal(msgBuf.Bytes(), &completedOrderProto)
 	if err != nil {
-		log.Panic().Err(err).Msg("processOpenOrderEndMsgProtoBuf unmarshal error")
+		panic(decodeFault{msg: "processOpenOrderEndMsgProtoBuf unmarshal error", err: err})
 	}
 
 	d.wrapper.CompletedOrderProtoBuf(&completedOrderProto)
@@ -4164,7 +4166,7 @@ func (d *EDecoder) processCompletedOrdersEndMsgProtoBuf(msgBuf *MsgBuffer) {
 	var completedOrdersEndProto protobuf.CompletedOrdersEnd
 	err := proto.Unmarshal(msgBuf.Bytes(), &completedOrdersEndProto)
 	if err != nil {
-		log.Panic().Err(err).Msg("processOpenOrderEndMsgProtoBuf unmarshal error")
+		panic(decodeFault{msg: "processOpenOrderEndMsgProtoBuf unmarshal error", err: err})
 	}
 
 	d.wrapper.CompletedOrdersEndProtoBuf(&completedOrdersEndProto)
@@ -0,0 +1,439 @@
+package ibapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrorSeverity tells the impact of a message received through EWrapper.Error.
+type ErrorSeverity int
+
+const (
+	SeverityInfo            ErrorSeverity = iota // status notification, nothing went wrong
+	SeverityWarning                              // the request goes on, possibly degraded
+	SeverityRequestFatal                         // the request identified by reqID or orderID is over
+	SeverityConnectionFatal                      // the session with TWS/IBGW is unusable
+)
+
+func (s ErrorSeverity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityRequestFatal:
+		return "request-fatal"
+	case SeverityConnectionFatal:
+		return "connection-fatal"
+	default:
+		return "unknown severity"
+	}
+}
+
+// ErrorCategory groups the messages by domain.
+type ErrorCategory int
+
+const (
+	CategoryGeneral      ErrorCategory = iota
+	CategoryClient                     // detected by the API client itself, codes 5xx
+	CategoryConnectivity               // TWS/IBGW connectivity with IB
+	CategoryFarm                       // data farm status
+	CategoryPacing                     // request rate limits
+	CategoryOrder
+	CategoryContract
+	CategoryMarketData
+	CategoryMarketDepth
+	CategoryHistoricalData
+	CategoryScanner
+	CategoryAccount
+	CategoryFundamentalData
+	CategoryServer // TWS failed to handle the request
+)
+
+func (c ErrorCategory) String() string {
+	switch c {
+	case CategoryGeneral:
+		return "general"
+	case CategoryClient:
+		return "client"
+	case CategoryConnectivity:
+		return "connectivity"
+	case CategoryFarm:
+		return "farm"
+	case CategoryPacing:
+		return "pacing"
+	case CategoryOrder:
+		return "order"
+	case CategoryContract:
+		return "contract"
+	case CategoryMarketData:
+		return "market data"
+	case CategoryMarketDepth:
+		return "market depth"
+	case CategoryHistoricalData:
+		return "historical data"
+	case CategoryScanner:
+		return "scanner"
+	case CategoryAccount:
+		return "account"
+	case CategoryFundamentalData:
+		return "fundamental data"
+	case CategoryServer:
+		return "server"
+	default:
+		return "unknown category"
+	}
+}
+
+// ErrorInfo describes a TWS/IBGW message code.
+type ErrorInfo struct {
+	Code        int64
+	Severity    ErrorSeverity
+	Category    ErrorCategory
+	Description string
+}
+
+// errorGroup lists message codes sharing a severity and a category.
+type errorGroup struct {
+	severity     ErrorSeverity
+	category     ErrorCategory
+	descriptions map[int64]string
+}
+
+func newErrorCatalog(groups ...errorGroup) map[int64]ErrorInfo {
+	catalog := make(map[int64]ErrorInfo)
+	for _, g := range groups {
+		for code, description := range g.descriptions {
+			catalog[code] = ErrorInfo{Code: code, Severity: g.severity, Category: g.category, Description: description}
+		}
+	}
+	return catalog
+}
+
+var errorCatalog = newErrorCatalog(
+	// Client side errors
+	errorGroup{SeverityConnectionFatal, CategoryClient, map[int64]string{
+		CONNECT_FAIL.Code:        "Couldn't connect to TWS.",
+		UNSUPPORTED_VERSION.Code: "Unsupported version.",
+		FAIL_CREATE_SOCK.Code:    "Failed to create socket.",
+	}},
+	errorGroup{SeverityRequestFatal, CategoryClient, map[int64]string{
+		ALREADY_CONNECTED.Code:        "Already connected.",
+		UPDATE_TWS.Code:               "The TWS is out of date and must be upgraded.",
+		NOT_CONNECTED.Code:            "Not connected.",
+		BAD_LENGTH.Code:               "Bad message length.",
+		INVALID_SYMBOL.Code:           "Invalid symbol in string.",
+		FA_PROFILE_NOT_SUPPORTED.Code: "FA Profile is not supported anymore, use FA Group instead.",
+		ERROR_ENCODING_PROTOBUF.Code:  "Error encoding protobuf.",
+	}},
+	errorGroup{SeverityWarning, CategoryClient, map[int64]string{
+		UNKNOWN_ID.Code:  "Unknown message id.",
+		BAD_MESSAGE.Code: "Bad message.",
+	}},
+
+	// Connectivity
+	errorGroup{SeverityConnectionFatal, CategoryConnectivity, map[int64]string{
+		326:  "Unable to connect as the client id is already in use.",
+		1300: "TWS socket port has been reset and this connection is being dropped.",
+	}},
+	errorGroup{SeverityWarning, CategoryConnectivity, map[int64]string{
+		1100: "Connectivity between IB and TWS has been lost.",
+		2110: "Connectivity between TWS and server is broken. It will be restored automatically.",
+	}},
+	errorGroup{SeverityInfo, CategoryConnectivity, map[int64]string{
+		1101: "Connectivity between IB and TWS has been restored - data lost.",
+		1102: "Connectivity between IB and TWS has been restored - data maintained.",
+	}},
+
+	// Farms
+	errorGroup{SeverityWarning, CategoryFarm, map[int64]string{
+		2103: "A market data farm is disconnected.",
+		2105: "A historical data farm is disconnected.",
+		2157: "A sec-def data farm is disconnected.",
+	}},
+	errorGroup{SeverityInfo, CategoryFarm, map[int64]string{
+		2104: "Market data farm connection is OK.",
+		2106: "A historical data farm is connected.",
+		2107: "A historical data farm connection has become inactive but should be available upon demand.",
+		2108: "A market data farm connection has become inactive but should be available upon demand.",
+		2119: "Market data farm is connecting.",
+		2158: "Sec-def data farm connection is OK.",
+	}},
+
+	// Pacing
+	errorGroup{SeverityRequestFatal, CategoryPacing, map[int64]string{
+		100: "Max rate of messages per second has been exceeded.",
+		420: "Invalid real-time query, e.g. pacing violation.",
+	}},
+
+	// Orders
+	errorGroup{SeverityRequestFatal, CategoryOrder, map[int64]string{
+		103:   "Duplicate order id.",
+		104:   "Can't modify a filled order.",
+		105:   "Order being modified does not match original order.",
+		106:   "Can't transmit order id.",
+		107:   "Cannot transmit incomplete order.",
+		109:   "Price is out of the range defined by the Percentage setting at order defaults frame.",
+		110:   "The price does not conform to the minimum price variation for this contract.",
+		111:   "The TIF (Tif type) and the order type are incompatible.",
+		113:   "The Tif option should be set to DAY for MOC and LOC orders.",
+		135:   "Can't find order with id.",
+		136:   "This order cannot be cancelled.",
+		161:   "Cancel attempted when order is not in a cancellable state.",
+		201:   "Order rejected.",
+		202:   "Order cancelled.",
+		325:   "Discretionary orders are not supported for this combination of exchange and order type.",
+		328:   "Trailing stop orders can be attached to limit or stop-limit orders only.",
+		329:   "Order modify failed. Cannot change to the new order type.",
+		332:   "The account code for the order profile is invalid.",
+		333:   "Invalid share allocation syntax.",
+		334:   "Invalid Good Till Date order.",
+		335:   "Invalid delta: The delta must be between 0 and 100.",
+		338:   "Good After Time orders are currently disabled on this exchange.",
+		342:   "Pegged order is not supported on this exchange.",
+		355:   "Order size does not conform to market rule.",
+		361:   "Invalid trigger price.",
+		382:   "The price specified violates the number of ticks constraint specified in the default order settings.",
+		383:   "The size specified violates the size constraint specified in the default order settings.",
+		387:   "Unsupported order type for this exchange and security type.",
+		388:   "Order size is smaller than the minimum requirement.",
+		392:   "Invalid order: contract expired.",
+		400:   "Algo order error.",
+		403:   "Invalid stop price.",
+		411:   "The Outside Regular Trading Hours flag is not valid for this order.",
+		413:   "What-if order should have the transmit flag set to true.",
+		424:   "FA order requires allocation to be specified.",
+		434:   "The order size cannot be zero.",
+		435:   "You must specify an account.",
+		436:   "You must specify an allocation.",
+		10147: "OrderId that needs to be cancelled is not found.",
+		10148: "OrderId that needs to be cancelled cannot be cancelled.",
+	}},
+	errorGroup{SeverityWarning, CategoryOrder, map[int64]string{
+		399:  "Order message, e.g. the order will not be placed at the exchange until the market opens.",
+		404:  "Shares for this order are not immediately available for short sale. The order will be held.",
+		2102: "Unable to modify this order as it is still being processed.",
+		2109: "Order event warning: attribute Outside Regular Trading Hours is ignored based on the order type and destination.",
+		2137: "The closing order quantity is greater than your current position.",
+	}},
+
+	// Contracts
+	errorGroup{SeverityRequestFatal, CategoryContract, map[int64]string{
+		200: "No security definition has been found for the request.",
+		203: "The security is not available or allowed for this account.",
+		412: "The contract is not available for trading.",
+	}},
+
+	// Market data
+	errorGroup{SeverityRequestFatal, CategoryMarketData, map[int64]string{
+		101:   "Max number of tickers has been reached.",
+		102:   "Duplicate ticker id.",
+		300:   "Can't find EId with ticker id.",
+		354:   "Requested market data is not subscribed.",
+		414:   "Snapshot market data subscription is not applicable to generic ticks.",
+		10089: "Requested market data requires additional subscription for API.",
+		10168: "Requested market data is not subscribed. Delayed market data is not enabled.",
+		10186: "Requested market data is not subscribed. Delayed market data is not available.",
+		10189: "Failed to request tick-by-tick data.",
+	}},
+	errorGroup{SeverityWarning, CategoryMarketData, map[int64]string{
+		10090: "Part of requested market data is not subscribed.",
+		10167: "Requested market data is not subscribed. Displaying delayed market data.",
+		10197: "No market data during competing live session.",
+	}},
+
+	// Market depth
+	errorGroup{SeverityRequestFatal, CategoryMarketDepth, map[int64]string{
+		309: "Max number of market depth requests has been reached.",
+		310: "Can't find the subscribed market depth with ticker id.",
+		316: "Market depth data has been halted. Please re-subscribe.",
+	}},
+	errorGroup{SeverityWarning, CategoryMarketDepth, map[int64]string{
+		317: "Market depth data has been reset. Please empty deep book contents before applying any new entries.",
+	}},
+
+	// Historical data
+	errorGroup{SeverityRequestFatal, CategoryHistoricalData, map[int64]string{
+		162:   "Historical market data service error message.",
+		166:   "HMDS expired contract violation.",
+		366:   "No historical data query found for ticker id.",
+		386:   "Duplicate ticker id for API historical data query.",
+		10187: "Failed to request historical ticks.",
+		10225: "Bust event occurred, current subscription is deactivated. Please resubscribe real-time bars immediately.",
+	}},
+	errorGroup{SeverityInfo, CategoryHistoricalData, map[int64]string{
+		165: "Historical market data service query message.",
+	}},
+
+	// Scanner
+	errorGroup{SeverityRequestFatal, CategoryScanner, map[int64]string{
+		365: "No scanner subscription found for ticker id.",
+		385: "Duplicate ticker id for API scanner subscription.",
+	}},
+
+	// Account
+	errorGroup{SeverityRequestFatal, CategoryAccount, map[int64]string{
+		330: "Only FA or STL customers can request managed accounts list.",
+		331: "Internal error. FA or STL does not have any managed accounts.",
+	}},
+	errorGroup{SeverityWarning, CategoryAccount, map[int64]string{
+		2100: "New account data requested from TWS. API client has been unsubscribed from account data.",
+		2101: "Unable to subscribe to account as the following clients are subscribed to a different account.",
+	}},
+
+	// Fundamental data
+	errorGroup{SeverityRequestFatal, CategoryFundamentalData, map[int64]string{
+		430: "Fundamentals data for the security specified is not available.",
+	}},
+
+	// Server
+	errorGroup{SeverityRequestFatal, CategoryServer, map[int64]string{
+		320: "Server error when reading an API client request.",
+		321: "Server error when validating an API client request.",
+		322: "Server error when processing an API client request.",
+		323: "Server error.",
+	}},
+)
+
+// LookupError returns the catalog entry of a message code.
+// Codes absent from the catalog are classified from the IB numbering: 1100-1300 system messages,
+// 2100-2199 warnings, 501-599 requests refused client side. Other codes end the request they are about,
+// NewIBError keeps them as warnings when they are not tied to a request.
+func LookupError(code int64) (ErrorInfo, bool) {
+	if info, ok := errorCatalog[code]; ok {
+		return info, true
+	}
+	switch {
+	case code >= 1100 && code <= 1300:
+		return ErrorInfo{Code: code, Severity: SeverityWarning, Category: CategoryConnectivity}, false
+	case code >= 2100 && code < 2200:
+		return ErrorInfo{Code: code, Severity: SeverityWarning, Category: CategoryGeneral}, false
+	case code >= 501 && code < 600:
+		return ErrorInfo{Code: code, Severity: SeverityRequestFatal, Category: CategoryClient}, false
+	default:
+		return ErrorInfo{Code: code, Severity: SeverityRequestFatal, Category: CategoryGeneral}, false
+	}
+}
+
+// errorInfo classifies a message code received about reqID.
+// An unknown code ends the request it is about, without request it is a warning.
+func errorInfo(reqID int64, code int64) ErrorInfo {
+	info, known := LookupError(code)
+	if !known && reqID == NO_VALID_ID && info.Severity == SeverityRequestFatal && info.Category == CategoryGeneral {
+		info.Severity = SeverityWarning
+	}
+	return info
+}
+
+// IBError is a message received through EWrapper.Error, classified with the catalog.
+// errors.Is matches it against the sentinels below, other IBError and CodeMsgPair values by code.
+type IBError struct {
+	ReqID                   int64
+	Time                    time.Time
+	Code                    int64
+	Msg                     string
+	AdvancedOrderRejectJson string
+	Severity                ErrorSeverity
+	Category                ErrorCategory
+}
+
+// NewIBError builds a typed error from the arguments of EWrapper.Error.
+func NewIBError(reqID int64, errTime int64, errCode int64, errString string, advancedOrderRejectJson string) *IBError {
+	info := errorInfo(reqID, errCode)
+	e := &IBError{
+		ReqID:                   reqID,
+		Code:                    errCode,
+		Msg:                     errString,
+		AdvancedOrderRejectJson: advancedOrderRejectJson,
+		Severity:                info.Severity,
+		Category:                info.Category,
+	}
+	if errTime > 0 {
+		e.Time = time.UnixMilli(errTime)
+	}
+	return e
+}
+
+func (e *IBError) Error() string {
+	if e.ReqID != NO_VALID_ID {
+		return fmt.Sprintf("ib error %d (reqID %d): %s", e.Code, e.ReqID, e.Msg)
+	}
+	return fmt.Sprintf("ib error %d: %s", e.Code, e.Msg)
+}
+
+// Is reports whether target designates the same message code.
+func (e *IBError) Is(target error) bool {
+	switch t := target.(type) {
+	case *IBError:
+		if t == ErrPacingViolation {
+			return e.IsPacingViolation()
+		}
+		return t.Code == e.Code
+	case CodeMsgPair:
+		return t.Code == e.Code
+	}
+	return false
+}
+
+// IsPacingViolation tells whether the message reports a pacing violation.
+// 162 also carries other historical data errors, its text tells them apart.
+func (e *IBError) IsPacingViolation() bool {
+	switch e.Code {
+	case 100, 420:
+		return true
+	case 162:
+		return strings.Contains(strings.ToLower(e.Msg), "pacing violation")
+	}
+	return false
+}
+
+// Info returns the catalog entry of the error code.
+func (e *IBError) Info() ErrorInfo {
+	info, _ := LookupError(e.Code)
+	return info
+}
+
+func sentinel(code int64) *IBError {
+	info, _ := LookupError(code)
+	return &IBError{ReqID: NO_VALID_ID, Code: code, Msg: info.Description, Severity: info.Severity, Category: info.Category}
+}
+
+// Sentinels for errors.Is.
+var (
+	ErrMaxRateExceeded                    = sentinel(100)
+	ErrMaxTickersReached                  = sentinel(101)
+	ErrDuplicateTickerID                  = sentinel(102)
+	ErrDuplicateOrderID                   = sentinel(103)
+	ErrOrderNotFound                      = sentinel(135)
+	ErrOrderNotCancellable                = sentinel(161)
+	ErrHistoricalDataService              = sentinel(162)
+	ErrNoSecurityDefinition               = sentinel(200)
+	ErrOrderRejected                      = sentinel(201)
+	ErrOrderCancelled                     = sentinel(202)
+	ErrTickerIDNotFound                   = sentinel(300)
+	ErrMaxMarketDepthRequests             = sentinel(309)
+	ErrMarketDepthNotFound                = sentinel(310)
+	ErrMarketDepthReset                   = sentinel(317)
+	ErrClientIDInUse                      = sentinel(326)
+	ErrNoMarketDataPermissions            = sentinel(354)
+	ErrHistoricalQueryNotFound            = sentinel(366)
+	ErrOrderWarning                       = sentinel(399)
+	ErrInvalidRealTimeQuery               = sentinel(420)
+	ErrConnectivityLost                   = sentinel(1100)
+	ErrConnectivityRestoredDataLost       = sentinel(1101)
+	ErrConnectivityRestoredDataMaintained = sentinel(1102)
+	ErrSocketPortReset                    = sentinel(1300)
+	ErrMarketDataFarmDisconnected         = sentinel(2103)
+	ErrMarketDataFarmConnected            = sentinel(2104)
+	ErrHistoricalDataFarmDisconnected     = sentinel(2105)
+	ErrHistoricalDataFarmConnected        = sentinel(2106)
+	ErrConnectivityBroken                 = sentinel(2110)
+	ErrSecDefFarmDisconnected             = sentinel(2157)
+	ErrSecDefFarmConnected                = sentinel(2158)
+	ErrMarketDataNotSubscribedDelayed     = sentinel(10167)
+	ErrDelayedMarketDataNotEnabled        = sentinel(10168)
+
+	// ErrPacingViolation matches 100, 420 and the 162 pacing violation messages.
+	ErrPacingViolation = &IBError{ReqID: NO_VALID_ID, Code: 162, Msg: "Pacing violation.", Severity: SeverityRequestFatal, Category: CategoryPacing}
+)
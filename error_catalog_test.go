@@ -0,0 +1,72 @@
+package ibapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupError(t *testing.T) {
+	tests := []struct {
+		code     int64
+		known    bool
+		severity ErrorSeverity
+		category ErrorCategory
+	}{
+		{162, true, SeverityRequestFatal, CategoryHistoricalData},
+		{354, true, SeverityRequestFatal, CategoryMarketData},
+		{10167, true, SeverityWarning, CategoryMarketData},
+		{2104, true, SeverityInfo, CategoryFarm},
+		{2106, true, SeverityInfo, CategoryFarm},
+		{2158, true, SeverityInfo, CategoryFarm},
+		{1100, true, SeverityWarning, CategoryConnectivity},
+		{1102, true, SeverityInfo, CategoryConnectivity},
+		{326, true, SeverityConnectionFatal, CategoryConnectivity},
+		{CONNECT_FAIL.Code, true, SeverityConnectionFatal, CategoryClient},
+		{2199, false, SeverityWarning, CategoryGeneral},
+		{123456, false, SeverityRequestFatal, CategoryGeneral},
+	}
+	for _, tt := range tests {
+		info, known := LookupError(tt.code)
+		if known != tt.known || info.Severity != tt.severity || info.Category != tt.category {
+			t.Errorf("%d: expected %v %v %v, got %v %v %v", tt.code, tt.known, tt.severity, tt.category, known, info.Severity, info.Category)
+		}
+	}
+
+	// An unknown code without request does not end anything
+	if err := NewIBError(NO_VALID_ID, 0, 123456, "", ""); err.Severity != SeverityWarning {
+		t.Errorf("expected a warning, got %v", err.Severity)
+	}
+	if err := NewIBError(7, 0, 123456, "", ""); err.Severity != SeverityRequestFatal {
+		t.Errorf("expected a request-fatal error, got %v", err.Severity)
+	}
+}
+
+func TestIBErrorIs(t *testing.T) {
+	err := error(NewIBError(7, 1792230000000, 354, "Requested market data is not subscribed.", ""))
+
+	if !errors.Is(err, ErrNoMarketDataPermissions) {
+		t.Error("expected ErrNoMarketDataPermissions")
+	}
+	if errors.Is(err, ErrNoSecurityDefinition) {
+		t.Error("unexpected ErrNoSecurityDefinition")
+	}
+	var ibErr *IBError
+	if !errors.As(err, &ibErr) || ibErr.ReqID != 7 || ibErr.Severity != SeverityRequestFatal || ibErr.Time.IsZero() {
+		t.Errorf("unexpected typed error %+v", ibErr)
+	}
+
+	if !errors.Is(NewIBError(NO_VALID_ID, 0, 502, "", ""), CONNECT_FAIL) {
+		t.Error("expected a match with the CONNECT_FAIL code")
+	}
+
+	pacing := NewIBError(3, 0, 162, "Historical Market Data Service error message:API historical data query cancelled: pacing violation", "")
+	if !errors.Is(pacing, ErrPacingViolation) || !errors.Is(pacing, ErrHistoricalDataService) {
+		t.Error("expected a 162 pacing violation")
+	}
+	if errors.Is(NewIBError(3, 0, 162, "Historical Market Data Service error message:HMDS query returned no data", ""), ErrPacingViolation) {
+		t.Error("unexpected pacing violation")
+	}
+	if !errors.Is(NewIBError(3, 0, 420, "Invalid Real-time Query", ""), ErrPacingViolation) {
+		t.Error("expected 420 as a pacing violation")
+	}
+}
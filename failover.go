@@ -0,0 +1,215 @@
+package ibapi
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Endpoint is a TWS/IBGW the client can connect to.
+type Endpoint struct {
+	Host string
+	Port int
+	// ClientID is the client id used on this endpoint, the same id may already be taken on another gateway.
+	ClientID int64
+}
+
+func (e Endpoint) String() string {
+	return fmt.Sprintf("%s/%d", net.JoinHostPort(e.Host, strconv.Itoa(e.Port)), e.ClientID)
+}
+
+// FailoverPolicy configures the failover between several TWS/IBGW.
+// Endpoints are tried in turn on connect with ConnectFailover and on session loss.
+// While connected to a standby endpoint, the preferred one is probed so that the client fails back to it when it recovers.
+// Failovers are reported through OnFailover and through the FAILING_OVER connection state during a failback.
+type FailoverPolicy struct {
+	// Endpoints is the list of endpoints, most preferred first.
+	Endpoints []Endpoint
+	// ProbeInterval is the period of the preferred endpoint probe, zero disables the failback.
+	ProbeInterval time.Duration
+	// OnFailover is called when the session moves from one endpoint to another.
+	OnFailover func(from, to Endpoint)
+}
+
+// index returns the position of ep in the endpoint list, -1 if absent.
+func (f *FailoverPolicy) index(ep Endpoint) int {
+	if f == nil {
+		return -1
+	}
+	return slices.Index(f.Endpoints, ep)
+}
+
+// candidates returns the endpoints to try in order after leaving from.
+func (f *FailoverPolicy) candidates(from Endpoint) []Endpoint {
+	if f == nil || len(f.Endpoints) == 0 {
+		return []Endpoint{from}
+	}
+	if f.index(from) < 0 {
+		// Connected with Connect to an endpoint outside of the list, try it first
+		return append([]Endpoint{from}, f.Endpoints...)
+	}
+	return slices.Clone(f.Endpoints)
+}
+
+func (f *FailoverPolicy) notify(from, to Endpoint) {
+	log.Warn().Stringer("from", from).Stringer("to", to).Msg("session failed over")
+	if f != nil && f.OnFailover != nil {
+		f.OnFailover(from, to)
+	}
+}
+
+// SetFailoverPolicy enables the failover between several endpoints, nil disables it.
+// It must be called before Connect. The failover on session loss rides on the session-level reconnect,
+// the DefaultReconnectPolicy is set if none was.
+func (c *EClient) SetFailoverPolicy(policy *FailoverPolicy) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	c.failover = policy
+	if policy != nil && c.reconnectPolicy == nil {
+		c.SetReconnectPolicy(DefaultReconnectPolicy())
+	}
+}
+
+// FailoverPolicy returns the failover policy, nil when disabled.
+func (c *EClient) FailoverPolicy() *FailoverPolicy {
+	return c.failover
+}
+
+// Endpoint returns the endpoint of the current session.
+func (c *EClient) Endpoint() Endpoint {
+	c.sessionMu.RLock()
+	defer c.sessionMu.RUnlock()
+	return Endpoint{Host: c.host, Port: c.port, ClientID: c.clientID}
+}
+
+// ConnectFailover connects to the first reachable endpoint of the failover policy, in order.
+func (c *EClient) ConnectFailover(ctx context.Context) error {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return ALREADY_CONNECTED
+	}
+
+	if c.failover == nil || len(c.failover.Endpoints) == 0 {
+		return errors.New("no failover endpoint")
+	}
+
+	var errs []error
+	for _, ep := range c.failover.Endpoints {
+		err := c.ConnectContext(ctx, ep.Host, ep.Port, ep.ClientID)
+		if err == nil {
+			return nil
+		}
+		log.Warn().Err(err).Stringer("endpoint", ep).Msg("failover endpoint unreachable")
+		errs = append(errs, fmt.Errorf("%v: %w", ep, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// probedConn is a connection that passed the handshake, ready to start a session.
+type probedConn struct {
+	net.Conn
+	serverVersion Version
+	connTime      string
+}
+
+// probePreferred returns a connection to the preferred endpoint that passed the handshake,
+// nil when the session is not on a standby endpoint or when the preferred one does not answer.
+func (c *EClient) probePreferred(abort context.Context) *probedConn {
+	c.sessionMu.RLock()
+	standby := c.endpointIndex > 0
+	c.sessionMu.RUnlock()
+	if c.failover == nil || !standby {
+		return nil
+	}
+
+	ctx, cancel := abort, context.CancelFunc(func() {})
+	if c.reconnectPolicy != nil && c.reconnectPolicy.HandshakeTimeout > 0 {
+		ctx, cancel = context.WithTimeout(abort, c.reconnectPolicy.HandshakeTimeout)
+	}
+	defer cancel()
+
+	preferred := c.failover.Endpoints[0]
+	conn, err := c.probe(ctx, preferred)
+	if err != nil {
+		log.Trace().Err(err).Stringer("endpoint", preferred).Msg("preferred endpoint probe failed")
+		return nil
+	}
+	return conn
+}
+
+// probe dials ep and checks that it answers the handshake with a supported server version, without starting the API.
+// The connection is left open for the session to start on it.
+func (c *EClient) probe(ctx context.Context, ep Endpoint) (_ *probedConn, err error) {
+	conn, err := c.conn.dial(ctx, ep.Host, ep.Port)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			conn.Close()
+		}
+	}()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+
+	serverInfo, err := c.probeHandshake(conn)
+	if !stop() {
+		// ctx is done, the socket deadline may already be gone
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v, _ := strconv.Atoi(string(serverInfo[0]))
+	if Version(v) < MIN_SERVER_VER_SUPPORTED {
+		return nil, UNSUPPORTED_VERSION
+	}
+	probed := &probedConn{Conn: conn, serverVersion: Version(v)}
+	if len(serverInfo) > 1 {
+		probed.connTime = string(serverInfo[1])
+	}
+	return probed, nil
+}
+
+// probeHandshake sends the handshake header on conn and returns the server info answered.
+func (c *EClient) probeHandshake(conn net.Conn) ([][]byte, error) {
+	if _, err := conn.Write(c.handshakeHeader()); err != nil {
+		return nil, err
+	}
+
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(conn, size); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size)
+	if int(n) > MAX_MSG_LEN {
+		return nil, BAD_LENGTH
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return nil, err
+	}
+
+	serverInfo := splitMsgBytes(payload)
+	if len(serverInfo) == 0 {
+		return nil, BAD_MESSAGE
+	}
+	return serverInfo, nil
+}
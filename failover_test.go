@@ -0,0 +1,128 @@
+package ibapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type failoverRecorder struct {
+	mu     sync.Mutex
+	events [][2]Endpoint
+}
+
+func (r *failoverRecorder) record(from, to Endpoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, [2]Endpoint{from, to})
+}
+
+func (r *failoverRecorder) last() ([2]Endpoint, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.events) == 0 {
+		return [2]Endpoint{}, 0
+	}
+	return r.events[len(r.events)-1], len(r.events)
+}
+
+func newFailoverPair(t *testing.T) (primary, standby *fakeGateway, endpoints []Endpoint) {
+	primary, standby = newFakeGateway(t), newFakeGateway(t)
+	pHost, pPort := primary.address()
+	sHost, sPort := standby.address()
+	return primary, standby, []Endpoint{
+		{Host: pHost, Port: pPort, ClientID: 1},
+		{Host: sHost, Port: sPort, ClientID: 2},
+	}
+}
+
+func TestFailoverConnectAndFailback(t *testing.T) {
+	primary, standby, endpoints := newFailoverPair(t)
+	primary.refuse.Store(true)
+
+	rec := &failoverRecorder{}
+	ib := NewEClient(nil)
+	ib.SetReconnectPolicy(&ReconnectPolicy{InitialDelay: 10 * time.Millisecond, HandshakeTimeout: time.Second})
+	ib.SetFailoverPolicy(&FailoverPolicy{Endpoints: endpoints, ProbeInterval: 50 * time.Millisecond, OnFailover: rec.record})
+
+	if err := ib.ConnectFailover(context.Background()); err != nil {
+		t.Fatalf("connect failover: %v", err)
+	}
+	defer ib.Disconnect()
+
+	fields := standby.nextRequest(t, START_API)
+	if string(fields[2]) != "2" {
+		t.Errorf("expected standby clientID 2, got %s", fields[2])
+	}
+	if ib.Endpoint() != endpoints[1] {
+		t.Errorf("expected standby endpoint, got %v", ib.Endpoint())
+	}
+
+	// The primary recovers
+	primary.refuse.Store(false)
+
+	fields = primary.nextRequest(t, START_API)
+	if string(fields[2]) != "1" {
+		t.Errorf("expected primary clientID 1, got %s", fields[2])
+	}
+	waitFor(t, "failback", func() bool {
+		event, n := rec.last()
+		return n == 1 && event == [2]Endpoint{endpoints[1], endpoints[0]} && ib.IsConnected()
+	})
+	if ib.Endpoint() != endpoints[0] {
+		t.Errorf("expected primary endpoint, got %v", ib.Endpoint())
+	}
+	// The session started on the probed connection
+	if n := len(primary.sessions); n != 1 {
+		t.Errorf("expected a single primary connection, got %d", n)
+	}
+}
+
+func TestFailbackWithoutReconnectPolicy(t *testing.T) {
+	primary, _, endpoints := newFailoverPair(t)
+	primary.refuse.Store(true)
+
+	rec := &failoverRecorder{}
+	ib := NewEClient(nil)
+	ib.SetFailoverPolicy(&FailoverPolicy{Endpoints: endpoints, ProbeInterval: 50 * time.Millisecond, OnFailover: rec.record})
+	ib.SetReconnectPolicy(nil)
+
+	if err := ib.ConnectFailover(context.Background()); err != nil {
+		t.Fatalf("connect failover: %v", err)
+	}
+	defer ib.Disconnect()
+
+	primary.refuse.Store(false)
+	primary.nextRequest(t, START_API)
+	waitFor(t, "failback", func() bool {
+		_, n := rec.last()
+		return n == 1 && ib.IsConnected()
+	})
+}
+
+func TestFailoverOnSessionLoss(t *testing.T) {
+	primary, standby, endpoints := newFailoverPair(t)
+
+	rec := &failoverRecorder{}
+	ib := NewEClient(nil)
+	ib.SetReconnectPolicy(&ReconnectPolicy{InitialDelay: 10 * time.Millisecond, HandshakeTimeout: time.Second})
+	ib.SetFailoverPolicy(&FailoverPolicy{Endpoints: endpoints, OnFailover: rec.record})
+
+	if err := ib.ConnectFailover(context.Background()); err != nil {
+		t.Fatalf("connect failover: %v", err)
+	}
+	defer ib.Disconnect()
+	session := primary.nextSession(t)
+	primary.nextRequest(t, START_API)
+
+	// The primary goes down
+	primary.refuse.Store(true)
+	session.Close()
+
+	standby.nextRequest(t, START_API)
+	waitFor(t, "failover", func() bool {
+		event, n := rec.last()
+		return n == 1 && event == [2]Endpoint{endpoints[0], endpoints[1]} && ib.IsConnected()
+	})
+}
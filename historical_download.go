@@ -0,0 +1,254 @@
+package ibapi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBarChunks is the longest duration requested at once per bar size, within the limits of ReqHistoricalData.
+var maxBarChunks = map[string]time.Duration{
+	"1 secs":  30 * time.Minute,
+	"5 secs":  time.Hour,
+	"10 secs": 4 * time.Hour,
+	"15 secs": 4 * time.Hour,
+	"30 secs": 8 * time.Hour,
+	"1 min":   24 * time.Hour,
+	"2 mins":  2 * 24 * time.Hour,
+	"3 mins":  7 * 24 * time.Hour,
+	"5 mins":  7 * 24 * time.Hour,
+	"10 mins": 7 * 24 * time.Hour,
+	"15 mins": 14 * 24 * time.Hour,
+	"20 mins": 30 * 24 * time.Hour,
+	"30 mins": 30 * 24 * time.Hour,
+	"1 hour":  30 * 24 * time.Hour,
+	"2 hours": 30 * 24 * time.Hour,
+	"3 hours": 30 * 24 * time.Hour,
+	"4 hours": 30 * 24 * time.Hour,
+	"8 hours": 30 * 24 * time.Hour,
+	"1 day":   365 * 24 * time.Hour,
+	"1 week":  365 * 24 * time.Hour,
+	"1 month": 365 * 24 * time.Hour,
+}
+
+// HistoricalDownload describes the historical bars to download with DownloadHistoricalBars.
+type HistoricalDownload struct {
+	Contract   *Contract
+	Start      time.Time // clamped to the head timestamp of the contract
+	End        time.Time // now when zero
+	BarSize    string    // one of the ReqHistoricalData bar sizes, "1 min", "1 hour", "1 day"...
+	WhatToShow string
+	UseRTH     bool
+	// Concurrency is the number of chunks requested at once, 1 when zero.
+	Concurrency int
+	// MaxRetries is the number of retries of a chunk after a pacing violation, 5 when zero.
+	MaxRetries int
+	// RetryDelay is the wait after the first pacing violation of a chunk, doubled after each retry. 15s when zero.
+	RetryDelay time.Duration
+}
+
+// historicalChunk is a single ReqHistoricalData of a download.
+type historicalChunk struct {
+	end      time.Time
+	duration string
+}
+
+// daily tells whether the bars are daily or longer, dated yyyymmdd.
+func (d *HistoricalDownload) daily() bool {
+	return d.BarSize == "1 day" || d.BarSize == "1 week" || d.BarSize == "1 month"
+}
+
+// chunks splits [start, end) into legal requests, the most recent first.
+func (d *HistoricalDownload) chunks(start, end time.Time) ([]historicalChunk, error) {
+	span, ok := maxBarChunks[d.BarSize]
+	if !ok {
+		return nil, fmt.Errorf("unsupported bar size %q", d.BarSize)
+	}
+	// Daily and longer bars need a duration in days
+	daily := d.daily()
+	var chunks []historicalChunk
+	for chunkEnd := end; chunkEnd.After(start); chunkEnd = chunkEnd.Add(-span) {
+		duration := min(span, chunkEnd.Sub(start))
+		if daily {
+			duration = max(duration, 24*time.Hour)
+		}
+		chunks = append(chunks, historicalChunk{end: chunkEnd, duration: durationString(duration)})
+	}
+	return chunks, nil
+}
+
+// durationString formats d as a ReqHistoricalData duration, rounded up.
+func durationString(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < day:
+		return strconv.FormatInt(int64((d+time.Second-1)/time.Second), 10) + " S"
+	case d%(365*day) == 0:
+		return strconv.FormatInt(int64(d/(365*day)), 10) + " Y"
+	default:
+		return strconv.FormatInt(int64((d+day-1)/day), 10) + " D"
+	}
+}
+
+// parseBarTime parses the dates of formatDate 2: epoch seconds, or yyyymmdd for daily and longer bars.
+func parseBarTime(s string) (time.Time, error) {
+	if len(s) == 8 {
+		return time.ParseInLocation("20060102", s, time.UTC)
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected bar date %q", s)
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// isNoData tells whether err is the 162 answered for a period without data.
+func isNoData(err error) bool {
+	var ibErr *IBError
+	return errors.As(err, &ibErr) && ibErr.Code == 162 && strings.Contains(strings.ToLower(ibErr.Msg), "returned no data")
+}
+
+// DownloadHistoricalBars downloads the bars of d.Contract between d.Start and d.End, beyond the duration limit of a single ReqHistoricalData.
+// The range is split into chunks requested from the most recent one, with a backoff after pacing violations.
+// The bars are returned in chronological order without duplicates, their Date in epoch seconds (formatDate 2).
+func (c *EClient) DownloadHistoricalBars(ctx context.Context, d HistoricalDownload) ([]Bar, error) {
+	end := d.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	head, err := c.HeadTimestamp(ctx, d.Contract, d.WhatToShow, d.UseRTH, 2)
+	if err != nil {
+		return nil, err
+	}
+	start := d.Start
+	if headTime, err := parseBarTime(head); err == nil && headTime.After(start) {
+		start = headTime
+	}
+	// Daily bars are dated at midnight UTC, the head timestamp and d.Start are not
+	if d.daily() {
+		y, m, day := start.UTC().Date()
+		start = time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	}
+	chunks, err := d.chunks(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := fanOut(ctx, chunks, d.Concurrency, func(ctx context.Context, chunk historicalChunk) ([]Bar, error) {
+		return c.downloadChunk(ctx, &d, chunk)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type timedBar struct {
+		t   time.Time
+		bar Bar
+	}
+	seen := make(map[string]bool)
+	var bars []timedBar
+	for _, chunk := range results {
+		for _, bar := range chunk {
+			t, err := parseBarTime(bar.Date)
+			if err != nil {
+				return nil, err
+			}
+			if seen[bar.Date] || t.Before(start) || !t.Before(end) {
+				continue
+			}
+			seen[bar.Date] = true
+			bars = append(bars, timedBar{t, bar})
+		}
+	}
+	slices.SortFunc(bars, func(a, b timedBar) int { return a.t.Compare(b.t) })
+	out := make([]Bar, len(bars))
+	for i, b := range bars {
+		out[i] = b.bar
+	}
+	return out, nil
+}
+
+// downloadChunk requests a chunk, retrying it after pacing violations.
+func (c *EClient) downloadChunk(ctx context.Context, d *HistoricalDownload, chunk historicalChunk) ([]Bar, error) {
+	endDateTime := chunk.end.UTC().Format("20060102-15:04:05")
+	bars, err := retryPacing(ctx, d.MaxRetries, d.RetryDelay, func() ([]Bar, error) {
+		return c.HistoricalBars(ctx, d.Contract, endDateTime, chunk.duration, d.BarSize, d.WhatToShow, d.UseRTH, 2)
+	})
+	if isNoData(err) {
+		return nil, nil
+	}
+	return bars, err
+}
+
+// retryPacing calls fn again after the pacing violations it returns, up to retries times (5 when zero).
+// It waits delay (15s when zero) after the first violation, twice longer after each other,
+// or the wait told by the historical data governor.
+func retryPacing[T any](ctx context.Context, retries int, delay time.Duration, fn func() (T, error)) (T, error) {
+	retries = cmp.Or(retries, 5)
+	delay = cmp.Or(delay, 15*time.Second)
+	for attempt := 0; ; attempt++ {
+		res, err := fn()
+		if err == nil || !errors.Is(err, ErrPacingViolation) || attempt >= retries {
+			return res, err
+		}
+		wait := delay
+		// The governor knows when the request is allowed
+		var governed *HistoricalPacingError
+		if errors.As(err, &governed) {
+			wait = governed.Wait
+		} else {
+			delay *= 2
+		}
+		log.Warn().Int("attempt", attempt+1).Dur("delay", wait).Msg("historical data pacing violation, retrying")
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return res, ctx.Err()
+		}
+	}
+}
+
+// fanOut calls fn on the items, concurrency at a time (1 when zero), and returns the results in the order of items.
+// The first error cancels the calls in flight and is returned.
+func fanOut[I, R any](ctx context.Context, items []I, concurrency int, fn func(context.Context, I) (R, error)) ([]R, error) {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			results[i], errs[i] = fn(ctx, item)
+			if errs[i] != nil {
+				cancel()
+			}
+		})
+	}
+	wg.Wait()
+	// The first error is the cause, the others the cancellation of their calls
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+	}
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
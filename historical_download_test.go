@@ -0,0 +1,130 @@
+package ibapi
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestHistoricalDownloadChunks(t *testing.T) {
+	end := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	d := HistoricalDownload{BarSize: "1 min"}
+	chunks, err := d.chunks(end.Add(-36*time.Hour), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 2 || chunks[0].duration != "1 D" || chunks[1].duration != "43200 S" || !chunks[1].end.Equal(end.Add(-24*time.Hour)) {
+		t.Errorf("unexpected chunks %+v", chunks)
+	}
+
+	d.BarSize = "1 day"
+	if chunks, _ := d.chunks(end.Add(-400*24*time.Hour), end); len(chunks) != 2 || chunks[0].duration != "1 Y" || chunks[1].duration != "35 D" {
+		t.Errorf("unexpected daily chunks %+v", chunks)
+	}
+	if chunks, _ := d.chunks(end.Add(-time.Hour), end); len(chunks) != 1 || chunks[0].duration != "1 D" {
+		t.Errorf("unexpected short daily chunks %+v", chunks)
+	}
+
+	d.BarSize = "7 mins"
+	if _, err := d.chunks(end.Add(-time.Hour), end); err == nil {
+		t.Error("unsupported bar size accepted")
+	}
+}
+
+func TestHistoricalDownload(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	end := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	head := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	epoch := func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }
+
+	ch := async(func() ([]Bar, error) {
+		return ib.DownloadHistoricalBars(context.Background(), HistoricalDownload{
+			Contract:   &Contract{Symbol: "IBM"},
+			Start:      end.Add(-72 * time.Hour),
+			End:        end,
+			BarSize:    "1 min",
+			WhatToShow: "TRADES",
+			RetryDelay: 10 * time.Millisecond,
+		})
+	})
+
+	req := gw.nextRequest(t, REQ_HEAD_TIMESTAMP)
+	writeFrame(conn, HEAD_TIMESTAMP, string(req[1]), epoch(head))
+
+	// The most recent chunk first, retried after a pacing violation
+	req = gw.nextRequest(t, REQ_HISTORICAL_DATA)
+	if endDateTime := string(req[15]); endDateTime != "20261016-00:00:00" {
+		t.Errorf("unexpected endDateTime %s", endDateTime)
+	}
+	writeFrame(conn, ERR_MSG, string(req[1]), 162, "Historical Market Data Service error message:API historical data query cancelled: pacing violation", "", 0)
+	req = gw.nextRequest(t, REQ_HISTORICAL_DATA)
+	writeFrame(conn, HISTORICAL_DATA, string(req[1]), 3,
+		epoch(end.Add(-24*time.Hour)), 1, 1, 1, 1, 10, 1, 1,
+		epoch(end.Add(-12*time.Hour)), 2, 2, 2, 2, 10, 2, 1,
+		epoch(end.Add(-time.Minute)), 3, 3, 3, 3, 10, 3, 1)
+	writeFrame(conn, HISTORICAL_DATA_END, string(req[1]), "", "")
+
+	req = gw.nextRequest(t, REQ_HISTORICAL_DATA)
+	if endDateTime, duration := string(req[15]), string(req[17]); endDateTime != "20261015-00:00:00" || duration != "43200 S" {
+		t.Errorf("unexpected chunk %s %s", endDateTime, duration)
+	}
+	writeFrame(conn, HISTORICAL_DATA, string(req[1]), 3,
+		epoch(head.Add(-time.Hour)), 0, 0, 0, 0, 10, 0, 1,
+		epoch(head.Add(time.Hour)), 0.5, 0.5, 0.5, 0.5, 10, 0.5, 1,
+		epoch(end.Add(-24*time.Hour)), 1, 1, 1, 1, 10, 1, 1)
+	writeFrame(conn, HISTORICAL_DATA_END, string(req[1]), "", "")
+
+	bars, err := awaitResult(t, ch)
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	want := []float64{0.5, 1, 2, 3}
+	if len(bars) != len(want) {
+		t.Fatalf("unexpected bars %+v", bars)
+	}
+	for i, close := range want {
+		if bars[i].Close != close {
+			t.Errorf("bar %d closes at %v, want %v", i, bars[i].Close, close)
+		}
+	}
+}
+
+func TestHistoricalDownloadDaily(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	end := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	// The head timestamp of formatDate 2 is intraday, the daily bars are dated at midnight
+	head := time.Date(2026, 10, 14, 13, 30, 0, 0, time.UTC)
+
+	ch := async(func() ([]Bar, error) {
+		return ib.DownloadHistoricalBars(context.Background(), HistoricalDownload{
+			Contract:   &Contract{Symbol: "IBM"},
+			End:        end,
+			BarSize:    "1 day",
+			WhatToShow: "TRADES",
+		})
+	})
+
+	req := gw.nextRequest(t, REQ_HEAD_TIMESTAMP)
+	writeFrame(conn, HEAD_TIMESTAMP, string(req[1]), strconv.FormatInt(head.Unix(), 10))
+
+	req = gw.nextRequest(t, REQ_HISTORICAL_DATA)
+	if duration := string(req[17]); duration != "3 D" {
+		t.Errorf("unexpected duration %s", duration)
+	}
+	writeFrame(conn, HISTORICAL_DATA, string(req[1]), 3,
+		"20261014", 1, 1, 1, 1, 10, 1, 1,
+		"20261015", 2, 2, 2, 2, 10, 2, 1,
+		"20261016", 3, 3, 3, 3, 10, 3, 1)
+	writeFrame(conn, HISTORICAL_DATA_END, string(req[1]), "", "")
+
+	bars, err := awaitResult(t, ch)
+	if err != nil {
+		t.Fatalf("download: %v", err)
+	}
+	if len(bars) != 3 || bars[0].Date != "20261014" {
+		t.Errorf("unexpected bars %+v", bars)
+	}
+}
@@ -0,0 +1,277 @@
+package ibapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// HistoricalPacingMode tells what the governor does with a request that would break the historical data pacing rules.
+type HistoricalPacingMode int
+
+const (
+	HistoricalPacingDelay  HistoricalPacingMode = iota // hold the request until it is allowed
+	HistoricalPacingReject                             // return a *HistoricalPacingError
+)
+
+func (m HistoricalPacingMode) String() string {
+	switch m {
+	case HistoricalPacingDelay:
+		return "delay"
+	case HistoricalPacingReject:
+		return "reject"
+	default:
+		return "unknown historical pacing mode"
+	}
+}
+
+// HistoricalPacingConfig configures the governor of the historical data requests:
+// ReqHistoricalData, ReqHistoricalTicks, ReqHeadTimeStamp and ReqHistogramData.
+type HistoricalPacingConfig struct {
+	Mode HistoricalPacingMode
+	// MaxWait rejects the delayed requests that would wait longer. No limit when zero.
+	MaxWait time.Duration
+	// IdenticalInterval is the minimum interval between identical requests.
+	IdenticalInterval time.Duration
+	// ContractRequests requests for the same contract, exchange and data type are allowed within ContractWindow.
+	ContractRequests int
+	ContractWindow   time.Duration
+	// Requests requests are allowed within Window.
+	Requests int
+	Window   time.Duration
+}
+
+// DefaultHistoricalPacingConfig returns the IB pacing rules: no identical request within 15 seconds,
+// at most 6 requests for the same contract, exchange and data type within 2 seconds and 60 requests within 10 minutes.
+func DefaultHistoricalPacingConfig() *HistoricalPacingConfig {
+	return &HistoricalPacingConfig{
+		Mode:              HistoricalPacingDelay,
+		IdenticalInterval: 15 * time.Second,
+		ContractRequests:  6,
+		ContractWindow:    2 * time.Second,
+		Requests:          60,
+		Window:            10 * time.Minute,
+	}
+}
+
+// HistoricalPacingError rejects a historical data request that would break a pacing rule.
+// It matches ErrPacingViolation with errors.Is.
+type HistoricalPacingError struct {
+	ReqID int64
+	Rule  string        // "identical", "contract" or "window"
+	Wait  time.Duration // until the request is allowed
+}
+
+func (e *HistoricalPacingError) Error() string {
+	return fmt.Sprintf("historical data request %d would break the %s pacing rule, allowed in %s", e.ReqID, e.Rule, e.Wait)
+}
+
+// Is reports whether target is ErrPacingViolation.
+func (e *HistoricalPacingError) Is(target error) bool {
+	return target == ErrPacingViolation
+}
+
+// HistoricalPacingStats are the metrics of the historical data governor.
+type HistoricalPacingStats struct {
+	Requests  int64         // requests let through
+	Delayed   int64         // requests held before being sent
+	Rejected  int64         // requests rejected with a HistoricalPacingError
+	TotalWait time.Duration // cumulated wait of the delayed requests
+	MaxWait   time.Duration // longest wait of a delayed request
+	Pending   int           // requests sent within the last Window, or waiting
+}
+
+// historicalPacer reserves send times for the historical data requests so that none breaks the pacing rules.
+type historicalPacer struct {
+	config HistoricalPacingConfig
+
+	mu         sync.Mutex
+	identical  map[string]time.Time   // last send time per request
+	byContract map[string][]time.Time // sorted send times per contract, exchange and data type
+	all        []time.Time            // sorted send times
+	stats      HistoricalPacingStats
+	now        func() time.Time
+}
+
+func newHistoricalPacer(config *HistoricalPacingConfig) *historicalPacer {
+	return &historicalPacer{
+		config:     *config,
+		identical:  make(map[string]time.Time),
+		byContract: make(map[string][]time.Time),
+		now:        time.Now,
+	}
+}
+
+// historicalReservation is the send time reserved for a request.
+type historicalReservation struct {
+	identity    string
+	contractKey string
+	at          time.Time
+	prev        time.Time // previous send time of the identical request
+}
+
+// reserve returns the send time reserved for the request, or the error rejecting it.
+func (p *historicalPacer) reserve(reqID int64, identity string, contractKey string) (historicalReservation, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	p.prune(now)
+
+	at, rule := now, ""
+	if last, ok := p.identical[identity]; ok && p.config.IdenticalInterval > 0 {
+		if t := last.Add(p.config.IdenticalInterval); t.After(at) {
+			at, rule = t, "identical"
+		}
+	}
+	// Send times only leave the windows as time goes by: the latest time allowed by each rule is allowed by all
+	if times := p.byContract[contractKey]; p.config.ContractRequests > 0 && len(times) >= p.config.ContractRequests {
+		if t := times[len(times)-p.config.ContractRequests].Add(p.config.ContractWindow); t.After(at) {
+			at, rule = t, "contract"
+		}
+	}
+	if p.config.Requests > 0 && len(p.all) >= p.config.Requests {
+		if t := p.all[len(p.all)-p.config.Requests].Add(p.config.Window); t.After(at) {
+			at, rule = t, "window"
+		}
+	}
+
+	wait := at.Sub(now)
+	if wait > 0 && (p.config.Mode == HistoricalPacingReject || p.config.MaxWait > 0 && wait > p.config.MaxWait) {
+		p.stats.Rejected++
+		return historicalReservation{}, &HistoricalPacingError{ReqID: reqID, Rule: rule, Wait: wait}
+	}
+
+	r := historicalReservation{identity: identity, contractKey: contractKey, at: at, prev: p.identical[identity]}
+	p.identical[identity] = at
+	p.byContract[contractKey] = insertSorted(p.byContract[contractKey], at)
+	p.all = insertSorted(p.all, at)
+	p.stats.Requests++
+	if wait > 0 {
+		p.stats.Delayed++
+		p.stats.TotalWait += wait
+		p.stats.MaxWait = max(p.stats.MaxWait, wait)
+	}
+	return r, nil
+}
+
+// release forgets the reservation of a request given up before being sent.
+func (p *historicalPacer) release(r historicalReservation) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.identical[r.identity].Equal(r.at) {
+		if r.prev.IsZero() {
+			delete(p.identical, r.identity)
+		} else {
+			p.identical[r.identity] = r.prev
+		}
+	}
+	p.byContract[r.contractKey] = removeSorted(p.byContract[r.contractKey], r.at)
+	p.all = removeSorted(p.all, r.at)
+	p.stats.Requests--
+}
+
+// prune drops the send times out of every window.
+func (p *historicalPacer) prune(now time.Time) {
+	for identity, t := range p.identical {
+		if now.Sub(t) >= p.config.IdenticalInterval {
+			delete(p.identical, identity)
+		}
+	}
+	for key, times := range p.byContract {
+		i := 0
+		for i < len(times) && now.Sub(times[i]) >= p.config.ContractWindow {
+			i++
+		}
+		if i == len(times) {
+			delete(p.byContract, key)
+		} else {
+			p.byContract[key] = times[i:]
+		}
+	}
+	i := 0
+	for i < len(p.all) && now.Sub(p.all[i]) >= p.config.Window {
+		i++
+	}
+	p.all = p.all[i:]
+}
+
+func (p *historicalPacer) snapshot() HistoricalPacingStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prune(p.now())
+	stats := p.stats
+	stats.Pending = len(p.all)
+	return stats
+}
+
+func insertSorted(times []time.Time, t time.Time) []time.Time {
+	i, _ := slices.BinarySearchFunc(times, t, time.Time.Compare)
+	return slices.Insert(times, i, t)
+}
+
+func removeSorted(times []time.Time, t time.Time) []time.Time {
+	if i, found := slices.BinarySearchFunc(times, t, time.Time.Compare); found {
+		return slices.Delete(times, i, i+1)
+	}
+	return times
+}
+
+// paceHistorical holds a historical data request until the pacing rules allow it, or rejects it.
+// identity lists the request parameters, reqID aside.
+// The request must call release if it is not sent after all, so that its slot goes to the next ones.
+func (c *EClient) paceHistorical(ctx context.Context, reqID int64, msgID OUT, contract *Contract, whatToShow string, identity ...any) (release func(), err error) {
+	p := c.historicalPacer
+	if p == nil || !c.IsConnected() {
+		return func() {}, nil
+	}
+	contractKey := fmt.Sprintf("%d|%s|%s|%s|%s|%s|%s|%s", contract.ConID, contract.Symbol, contract.SecType,
+		contract.LastTradeDateOrContractMonth, contract.Exchange, contract.Currency, contract.LocalSymbol, whatToShow)
+	key := fmt.Sprint(append([]any{msgID, contractKey}, identity...)...)
+	r, err := p.reserve(reqID, key, contractKey)
+	if err != nil {
+		return nil, err
+	}
+	release = func() { p.release(r) }
+	wait := time.Until(r.at)
+	if wait <= 0 {
+		return release, nil
+	}
+	log.Debug().Int64("reqID", reqID).Dur("wait", wait).Msg("historical data request delayed by the pacing governor")
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return release, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// SetHistoricalPacing turns on the governor of the historical data requests, or off with a nil config.
+// It must be called before Connect.
+// The governor holds or rejects the ReqHistoricalData, ReqHistoricalTicks, ReqHeadTimeStamp and ReqHistogramData
+// that TWS would answer with a 162 pacing violation. The delayed requests wait in their Context variant, or in the caller goroutine.
+func (c *EClient) SetHistoricalPacing(config *HistoricalPacingConfig) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	if config == nil {
+		c.historicalPacer = nil
+		return
+	}
+	c.historicalPacer = newHistoricalPacer(config)
+}
+
+// HistoricalPacingStats returns the metrics of the historical data governor, zero values when it is off.
+func (c *EClient) HistoricalPacingStats() HistoricalPacingStats {
+	if c.historicalPacer == nil {
+		return HistoricalPacingStats{}
+	}
+	return c.historicalPacer.snapshot()
+}
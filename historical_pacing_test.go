@@ -0,0 +1,128 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHistoricalPacerRules(t *testing.T) {
+	now := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	p := newHistoricalPacer(DefaultHistoricalPacingConfig())
+	p.now = func() time.Time { return now }
+
+	// Identical requests 15s apart
+	if r, _ := p.reserve(1, "a", "IBM"); !r.at.Equal(now) {
+		t.Errorf("first request delayed to %v", r.at)
+	}
+	if r, _ := p.reserve(2, "a", "IBM"); !r.at.Equal(now.Add(15 * time.Second)) {
+		t.Errorf("identical request at %v", r.at)
+	}
+
+	// 6 requests per contract within 2s: 2 are already reserved for IBM
+	for i := range 4 {
+		if r, _ := p.reserve(int64(3+i), string(rune('b'+i)), "IBM"); !r.at.Equal(now) {
+			t.Errorf("request %d delayed to %v", 3+i, r.at)
+		}
+	}
+	r, _ := p.reserve(7, "x", "IBM")
+	if !r.at.Equal(now.Add(2 * time.Second)) {
+		t.Errorf("seventh contract request at %v", r.at)
+	}
+	p.release(r)
+	if r, _ := p.reserve(8, "y", "AAPL"); !r.at.Equal(now) {
+		t.Errorf("other contract delayed to %v", r.at)
+	}
+
+	// 60 requests within 10 minutes
+	now = now.Add(time.Minute)
+	for i := range 53 {
+		p.reserve(int64(100+i), string(rune(1000+i)), string(rune(2000+i)))
+	}
+	if r, _ := p.reserve(200, "z", "MSFT"); !r.at.Equal(now.Add(-time.Minute).Add(10 * time.Minute)) {
+		t.Errorf("request over the window at %v", r.at)
+	}
+
+	stats := p.snapshot()
+	if stats.Requests != 61 || stats.Delayed != 3 || stats.Pending != 61 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestHistoricalPacerReject(t *testing.T) {
+	config := DefaultHistoricalPacingConfig()
+	config.Mode = HistoricalPacingReject
+	p := newHistoricalPacer(config)
+
+	p.reserve(1, "a", "IBM")
+	_, err := p.reserve(2, "a", "IBM")
+	var pacingErr *HistoricalPacingError
+	if !errors.As(err, &pacingErr) || pacingErr.Rule != "identical" || pacingErr.Wait <= 14*time.Second {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !errors.Is(err, ErrPacingViolation) {
+		t.Error("not a pacing violation")
+	}
+	if stats := p.snapshot(); stats.Rejected != 1 || stats.Requests != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestHistoricalPacingReleasedOnError(t *testing.T) {
+	ib := NewEClient(nil)
+	ib.SetHistoricalPacing(DefaultHistoricalPacingConfig())
+	// Connected to a server too old for head timestamps: the request is refused after being paced
+	client, server := net.Pipe()
+	defer server.Close()
+	ib.conn.attach(client)
+	atomic.StoreInt32(&ib.connState, int32(CONNECTED))
+
+	err := ib.ReqHeadTimeStampContext(context.Background(), 1, &Contract{Symbol: "IBM"}, "TRADES", true, 1)
+	if !errors.Is(err, UPDATE_TWS) {
+		t.Fatalf("expected UPDATE_TWS, got %v", err)
+	}
+	if stats := ib.historicalPacer.snapshot(); stats.Requests != 0 || stats.Pending != 0 {
+		t.Errorf("the refused request kept its slot: %+v", stats)
+	}
+}
+
+func TestHistoricalPacingSession(t *testing.T) {
+	gw := newFakeGateway(t)
+	ib := NewEClient(nil)
+	config := DefaultHistoricalPacingConfig()
+	config.IdenticalInterval = 100 * time.Millisecond
+	ib.SetHistoricalPacing(config)
+	host, port := gw.address()
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { ib.Disconnect() })
+
+	contract := &Contract{Symbol: "IBM"}
+	ctx := context.Background()
+	if err := ib.ReqHeadTimeStampContext(ctx, 1, contract, "TRADES", true, 1); err != nil {
+		t.Fatal(err)
+	}
+	gw.nextRequest(t, REQ_HEAD_TIMESTAMP)
+	start := time.Now()
+	if err := ib.ReqHeadTimeStampContext(ctx, 2, contract, "TRADES", true, 1); err != nil {
+		t.Fatal(err)
+	}
+	gw.nextRequest(t, REQ_HEAD_TIMESTAMP)
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("identical request sent after %v", elapsed)
+	}
+
+	// A cancelled wait frees its slot
+	cancelled, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := ib.ReqHeadTimeStampContext(cancelled, 3, contract, "TRADES", true, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if stats := ib.HistoricalPacingStats(); stats.Requests != 2 || stats.Delayed != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
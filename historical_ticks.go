@@ -0,0 +1,107 @@
+package ibapi
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// historicalTicksPage is the number of ticks requested per ReqHistoricalTicks, the most TWS returns.
+const historicalTicksPage = 1000
+
+// HistoricalTicksQuery describes the historical ticks iterated by HistoricalTradeTicks, HistoricalBidAskTicks and HistoricalMidPointTicks.
+type HistoricalTicksQuery struct {
+	Contract    *Contract
+	Start       time.Time
+	End         time.Time // until the last tick when zero
+	UseRTH      bool
+	IgnoreSize  bool // BID_ASK only
+	MiscOptions []TagValue
+	// MaxRetries is the number of retries of a page after a pacing violation, 5 when zero.
+	MaxRetries int
+	// RetryDelay is the wait after the first pacing violation of a page, doubled after each retry. 15s when zero.
+	RetryDelay time.Duration
+}
+
+// HistoricalTradeTicks iterates over the TRADES ticks of q, in chronological order.
+// The ticks are requested by pages of 1000 while iterating. An error ends the iteration.
+func (c *EClient) HistoricalTradeTicks(ctx context.Context, q HistoricalTicksQuery) iter.Seq2[HistoricalTickLast, error] {
+	return historicalTicks(ctx, c, &q, "TRADES", func(t HistoricalTickLast) int64 { return t.Time })
+}
+
+// HistoricalBidAskTicks iterates over the BID_ASK ticks of q, in chronological order.
+// The ticks are requested by pages of 1000 while iterating. An error ends the iteration.
+func (c *EClient) HistoricalBidAskTicks(ctx context.Context, q HistoricalTicksQuery) iter.Seq2[HistoricalTickBidAsk, error] {
+	return historicalTicks(ctx, c, &q, "BID_ASK", func(t HistoricalTickBidAsk) int64 { return t.Time })
+}
+
+// HistoricalMidPointTicks iterates over the MIDPOINT ticks of q, in chronological order.
+// The ticks are requested by pages of 1000 while iterating. An error ends the iteration.
+func (c *EClient) HistoricalMidPointTicks(ctx context.Context, q HistoricalTicksQuery) iter.Seq2[HistoricalTick, error] {
+	return historicalTicks(ctx, c, &q, "MIDPOINT", func(t HistoricalTick) int64 { return t.Time })
+}
+
+// historicalTicks pages forward from q.Start. Each page starts at the last second of the previous one:
+// the ticks of that second already yielded are skipped, TWS sends them again in the same order.
+func historicalTicks[T any](ctx context.Context, c *EClient, q *HistoricalTicksQuery, whatToShow string, timeOf func(T) int64) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := q.Start.Unix()
+		end := int64(-1)
+		if !q.End.IsZero() {
+			end = q.End.Unix()
+		}
+		var seen int // ticks at cursor already yielded
+		for {
+			start := time.Unix(cursor, 0).UTC().Format("20060102-15:04:05")
+			page, err := retryPacing(ctx, q.MaxRetries, q.RetryDelay, func() ([]T, error) {
+				return call[T](ctx, c, func(reqID int64) error {
+					return c.ReqHistoricalTicksContext(ctx, reqID, q.Contract, start, "", historicalTicksPage, whatToShow, q.UseRTH, q.IgnoreSize, q.MiscOptions)
+				}, func(reqID int64) {
+					if c.ServerVersion() >= MIN_SERVER_VER_CANCEL_CONTRACT_DATA {
+						c.CancelHistoricalTicks(reqID)
+					}
+				})
+			})
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			skip := seen
+			last, lastCount := cursor, seen
+			for _, tick := range page {
+				t := timeOf(tick)
+				if t == cursor && skip > 0 {
+					skip--
+					continue
+				}
+				if t < cursor {
+					continue
+				}
+				if end >= 0 && t >= end {
+					return
+				}
+				if !yield(tick, nil) {
+					return
+				}
+				if t == last {
+					lastCount++
+				} else {
+					last, lastCount = t, 1
+				}
+			}
+
+			switch {
+			case len(page) < historicalTicksPage:
+				// TWS has no more ticks
+				return
+			case last == cursor && lastCount == seen:
+				// A full page without a new tick, more than a page in a second: move on to the next one
+				cursor, seen = cursor+1, 0
+			default:
+				cursor, seen = last, lastCount
+			}
+		}
+	}
+}
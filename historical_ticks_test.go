@@ -0,0 +1,94 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// writeTicksLast answers a REQ_HISTORICAL_TICKS with a page of TRADES ticks at the given times, priced by prices.
+func writeTicksLast(t *testing.T, gw *fakeGateway, conn net.Conn, wantStart string, times []int64, prices []float64) {
+	t.Helper()
+	req := gw.nextRequest(t, REQ_HISTORICAL_TICKS)
+	// startDateTime precedes endDateTime and numberOfTicks
+	i := slices.IndexFunc(req, func(f []byte) bool { return string(f) == strconv.Itoa(historicalTicksPage) })
+	if i < 2 || string(req[i-2]) != wantStart {
+		t.Errorf("unexpected page request %q", req)
+	}
+	fields := []any{HISTORICAL_TICKS_LAST, string(req[1]), len(times)}
+	for j, tm := range times {
+		fields = append(fields, tm, 0, prices[j], 1, "NYSE", "")
+	}
+	writeFrame(conn, append(fields, 1)...)
+}
+
+func TestHistoricalTradeTicks(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	t0 := time.Date(2026, 10, 16, 13, 30, 0, 0, time.UTC)
+	ch := async(func() ([]float64, error) {
+		var prices []float64
+		for tick, err := range ib.HistoricalTradeTicks(context.Background(), HistoricalTicksQuery{
+			Contract: &Contract{Symbol: "IBM"},
+			Start:    t0,
+			End:      t0.Add(10 * time.Second),
+		}) {
+			if err != nil {
+				return prices, err
+			}
+			prices = append(prices, tick.Price)
+		}
+		return prices, nil
+	})
+
+	at := func(sec int64, n int) []int64 { return slices.Repeat([]int64{t0.Unix() + sec}, n) }
+	seq := func(from, n int) []float64 {
+		p := make([]float64, n)
+		for i := range p {
+			p[i] = float64(from + i)
+		}
+		return p
+	}
+
+	// A page ending in a busy second
+	writeTicksLast(t, gw, conn, "20261016-13:30:00", append(at(0, 1), at(5, 999)...), seq(0, 1000))
+	// The busy second again with one more tick
+	writeTicksLast(t, gw, conn, "20261016-13:30:05", at(5, 1000), seq(1, 1000))
+	// Nothing new in a full page
+	writeTicksLast(t, gw, conn, "20261016-13:30:05", at(5, 1000), seq(1, 1000))
+	// The last page runs past the end
+	writeTicksLast(t, gw, conn, "20261016-13:30:06", append(at(6, 1), append(at(7, 1), at(10, 1)...)...), []float64{1001, 1002, 1003})
+
+	prices, err := awaitResult(t, ch)
+	if err != nil {
+		t.Fatalf("iteration: %v", err)
+	}
+	if want := seq(0, 1003); !slices.Equal(prices, want) {
+		t.Errorf("got %d ticks %v..., want %d", len(prices), prices[:min(len(prices), 5)], len(want))
+	}
+}
+
+func TestHistoricalTicksError(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ch := async(func() (int, error) {
+		n := 0
+		for _, err := range ib.HistoricalMidPointTicks(context.Background(), HistoricalTicksQuery{Contract: &Contract{Symbol: "IBM"}, Start: time.Now()}) {
+			if err != nil {
+				return n, err
+			}
+			n++
+		}
+		return n, nil
+	})
+	req := gw.nextRequest(t, REQ_HISTORICAL_TICKS)
+	writeFrame(conn, ERR_MSG, string(req[1]), 200, "No security definition has been found for the request", "", 0)
+
+	if _, err := awaitResult(t, ch); !errors.Is(err, ErrNoSecurityDefinition) {
+		t.Errorf("expected ErrNoSecurityDefinition, got %v", err)
+	}
+}
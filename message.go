@@ -184,6 +184,93 @@ const (
 	UPDATE_CONFIG                 OUT = 109
 )
 
+// outMsgNames names the outgoing msg id's.
+var outMsgNames = map[OUT]string{
+	REQ_MKT_DATA:                  "REQ_MKT_DATA",
+	CANCEL_MKT_DATA:               "CANCEL_MKT_DATA",
+	PLACE_ORDER:                   "PLACE_ORDER",
+	CANCEL_ORDER:                  "CANCEL_ORDER",
+	REQ_OPEN_ORDERS:               "REQ_OPEN_ORDERS",
+	REQ_ACCT_DATA:                 "REQ_ACCT_DATA",
+	REQ_EXECUTIONS:                "REQ_EXECUTIONS",
+	REQ_IDS:                       "REQ_IDS",
+	REQ_CONTRACT_DATA:             "REQ_CONTRACT_DATA",
+	REQ_MKT_DEPTH:                 "REQ_MKT_DEPTH",
+	CANCEL_MKT_DEPTH:              "CANCEL_MKT_DEPTH",
+	REQ_NEWS_BULLETINS:            "REQ_NEWS_BULLETINS",
+	CANCEL_NEWS_BULLETINS:         "CANCEL_NEWS_BULLETINS",
+	SET_SERVER_LOGLEVEL:           "SET_SERVER_LOGLEVEL",
+	REQ_AUTO_OPEN_ORDERS:          "REQ_AUTO_OPEN_ORDERS",
+	REQ_ALL_OPEN_ORDERS:           "REQ_ALL_OPEN_ORDERS",
+	REQ_MANAGED_ACCTS:             "REQ_MANAGED_ACCTS",
+	REQ_FA:                        "REQ_FA",
+	REPLACE_FA:                    "REPLACE_FA",
+	REQ_HISTORICAL_DATA:           "REQ_HISTORICAL_DATA",
+	EXERCISE_OPTIONS:              "EXERCISE_OPTIONS",
+	REQ_SCANNER_SUBSCRIPTION:      "REQ_SCANNER_SUBSCRIPTION",
+	CANCEL_SCANNER_SUBSCRIPTION:   "CANCEL_SCANNER_SUBSCRIPTION",
+	REQ_SCANNER_PARAMETERS:        "REQ_SCANNER_PARAMETERS",
+	CANCEL_HISTORICAL_DATA:        "CANCEL_HISTORICAL_DATA",
+	REQ_CURRENT_TIME:              "REQ_CURRENT_TIME",
+	REQ_REAL_TIME_BARS:            "REQ_REAL_TIME_BARS",
+	CANCEL_REAL_TIME_BARS:         "CANCEL_REAL_TIME_BARS",
+	REQ_CALC_IMPLIED_VOLAT:        "REQ_CALC_IMPLIED_VOLAT",
+	REQ_CALC_OPTION_PRICE:         "REQ_CALC_OPTION_PRICE",
+	CANCEL_CALC_IMPLIED_VOLAT:     "CANCEL_CALC_IMPLIED_VOLAT",
+	CANCEL_CALC_OPTION_PRICE:      "CANCEL_CALC_OPTION_PRICE",
+	REQ_GLOBAL_CANCEL:             "REQ_GLOBAL_CANCEL",
+	REQ_MARKET_DATA_TYPE:          "REQ_MARKET_DATA_TYPE",
+	REQ_POSITIONS:                 "REQ_POSITIONS",
+	REQ_ACCOUNT_SUMMARY:           "REQ_ACCOUNT_SUMMARY",
+	CANCEL_ACCOUNT_SUMMARY:        "CANCEL_ACCOUNT_SUMMARY",
+	CANCEL_POSITIONS:              "CANCEL_POSITIONS",
+	VERIFY_REQUEST:                "VERIFY_REQUEST",
+	VERIFY_MESSAGE:                "VERIFY_MESSAGE",
+	QUERY_DISPLAY_GROUPS:          "QUERY_DISPLAY_GROUPS",
+	SUBSCRIBE_TO_GROUP_EVENTS:     "SUBSCRIBE_TO_GROUP_EVENTS",
+	UPDATE_DISPLAY_GROUP:          "UPDATE_DISPLAY_GROUP",
+	UNSUBSCRIBE_FROM_GROUP_EVENTS: "UNSUBSCRIBE_FROM_GROUP_EVENTS",
+	START_API:                     "START_API",
+	VERIFY_AND_AUTH_REQUEST:       "VERIFY_AND_AUTH_REQUEST",
+	VERIFY_AND_AUTH_MESSAGE:       "VERIFY_AND_AUTH_MESSAGE",
+	REQ_POSITIONS_MULTI:           "REQ_POSITIONS_MULTI",
+	CANCEL_POSITIONS_MULTI:        "CANCEL_POSITIONS_MULTI",
+	REQ_ACCOUNT_UPDATES_MULTI:     "REQ_ACCOUNT_UPDATES_MULTI",
+	CANCEL_ACCOUNT_UPDATES_MULTI:  "CANCEL_ACCOUNT_UPDATES_MULTI",
+	REQ_SEC_DEF_OPT_PARAMS:        "REQ_SEC_DEF_OPT_PARAMS",
+	REQ_SOFT_DOLLAR_TIERS:         "REQ_SOFT_DOLLAR_TIERS",
+	REQ_FAMILY_CODES:              "REQ_FAMILY_CODES",
+	REQ_MATCHING_SYMBOLS:          "REQ_MATCHING_SYMBOLS",
+	REQ_MKT_DEPTH_EXCHANGES:       "REQ_MKT_DEPTH_EXCHANGES",
+	REQ_SMART_COMPONENTS:          "REQ_SMART_COMPONENTS",
+	REQ_NEWS_ARTICLE:              "REQ_NEWS_ARTICLE",
+	REQ_NEWS_PROVIDERS:            "REQ_NEWS_PROVIDERS",
+	REQ_HISTORICAL_NEWS:           "REQ_HISTORICAL_NEWS",
+	REQ_HEAD_TIMESTAMP:            "REQ_HEAD_TIMESTAMP",
+	REQ_HISTOGRAM_DATA:            "REQ_HISTOGRAM_DATA",
+	CANCEL_HISTOGRAM_DATA:         "CANCEL_HISTOGRAM_DATA",
+	CANCEL_HEAD_TIMESTAMP:         "CANCEL_HEAD_TIMESTAMP",
+	REQ_MARKET_RULE:               "REQ_MARKET_RULE",
+	REQ_PNL:                       "REQ_PNL",
+	CANCEL_PNL:                    "CANCEL_PNL",
+	REQ_PNL_SINGLE:                "REQ_PNL_SINGLE",
+	CANCEL_PNL_SINGLE:             "CANCEL_PNL_SINGLE",
+	REQ_HISTORICAL_TICKS:          "REQ_HISTORICAL_TICKS",
+	REQ_TICK_BY_TICK_DATA:         "REQ_TICK_BY_TICK_DATA",
+	CANCEL_TICK_BY_TICK_DATA:      "CANCEL_TICK_BY_TICK_DATA",
+	REQ_COMPLETED_ORDERS:          "REQ_COMPLETED_ORDERS",
+	REQ_WSH_META_DATA:             "REQ_WSH_META_DATA",
+	CANCEL_WSH_META_DATA:          "CANCEL_WSH_META_DATA",
+	REQ_WSH_EVENT_DATA:            "REQ_WSH_EVENT_DATA",
+	CANCEL_WSH_EVENT_DATA:         "CANCEL_WSH_EVENT_DATA",
+	REQ_USER_INFO:                 "REQ_USER_INFO",
+	REQ_CURRENT_TIME_IN_MILLIS:    "REQ_CURRENT_TIME_IN_MILLIS",
+	CANCEL_CONTRACT_DATA:          "CANCEL_CONTRACT_DATA",
+	CANCEL_HISTORICAL_TICKS:       "CANCEL_HISTORICAL_TICKS",
+	REQ_CONFIG:                    "REQ_CONFIG",
+	UPDATE_CONFIG:                 "UPDATE_CONFIG",
+}
+
 // TWS New Bulletins constants
 const NEWS_MSG int64 = 1             // standard IB news bulleting message
 const EXCHANGE_AVAIL_MSG int64 = 2   // control message specifying that an exchange is available for trading
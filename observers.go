@@ -0,0 +1,50 @@
+package ibapi
+
+import "sync"
+
+// observerList holds callbacks called in registration order.
+type observerList[T any] struct {
+	mu     sync.Mutex
+	nextID int
+	fns    []observerFunc[T]
+}
+
+type observerFunc[T any] struct {
+	id int
+	fn func(T)
+}
+
+// add registers fn and returns the function unregistering it.
+func (o *observerList[T]) add(fn func(T)) (remove func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.nextID++
+	id := o.nextID
+	o.fns = append(o.fns, observerFunc[T]{id: id, fn: fn})
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		for i, obs := range o.fns {
+			if obs.id == id {
+				o.fns = append(o.fns[:i:i], o.fns[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify calls the observers with each event, the lock of the notifier must not be held.
+func (o *observerList[T]) notify(events ...T) {
+	if len(events) == 0 {
+		return
+	}
+	o.mu.Lock()
+	fns := o.fns
+	o.mu.Unlock()
+	for _, ev := range events {
+		for _, obs := range fns {
+			obs.fn(ev)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package ibapi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+)
+
+// OptionChain is the options of an underlying for a trading class and multiplier, merged across exchanges.
+type OptionChain struct {
+	Underlying   Contract // the qualified underlying
+	TradingClass string
+	Multiplier   string
+	Exchanges    []string
+	Expirations  []time.Time // dates at midnight UTC, in order
+	Strikes      []float64   // in order
+}
+
+// OptionChainFilter selects options of a chain. Zero fields do not filter.
+type OptionChainFilter struct {
+	Rights []string // "C" and/or "P", both when empty
+	// RefPrice is the reference price of the moneyness filters, usually the price of the underlying.
+	RefPrice float64
+	// Moneyness keeps the strikes within RefPrice * (1 ± Moneyness), 0.1 for ±10%.
+	Moneyness float64
+	// StrikesAround keeps the StrikesAround strikes closest to RefPrice.
+	StrikesAround int
+	// MinDTE and MaxDTE bound the days to expiration, counted from Today.
+	MinDTE int
+	MaxDTE int
+	Today  time.Time // now when zero
+	// Exchange of the option contracts. When empty: SMART for options, the exchange of the chain for futures options.
+	Exchange string
+}
+
+// FilterExpirations returns the expirations of the chain within the DTE range of f.
+func (oc OptionChain) FilterExpirations(f OptionChainFilter) []time.Time {
+	today := cmp.Or(f.Today, time.Now())
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	var expirations []time.Time
+	for _, exp := range oc.Expirations {
+		dte := int(exp.Sub(today).Hours() / 24)
+		if dte < f.MinDTE || f.MaxDTE > 0 && dte > f.MaxDTE {
+			continue
+		}
+		expirations = append(expirations, exp)
+	}
+	return expirations
+}
+
+// FilterStrikes returns the strikes of the chain within the moneyness of f, in order.
+func (oc OptionChain) FilterStrikes(f OptionChainFilter) []float64 {
+	strikes := slices.Clone(oc.Strikes)
+	if f.RefPrice <= 0 {
+		return strikes
+	}
+	if f.Moneyness > 0 {
+		low, high := f.RefPrice*(1-f.Moneyness), f.RefPrice*(1+f.Moneyness)
+		strikes = slices.DeleteFunc(strikes, func(s float64) bool { return s < low || s > high })
+	}
+	if f.StrikesAround > 0 && len(strikes) > f.StrikesAround {
+		// The closest strikes form a window of the sorted strikes
+		start := 0
+		for start+f.StrikesAround < len(strikes) && math.Abs(strikes[start]-f.RefPrice) > math.Abs(strikes[start+f.StrikesAround]-f.RefPrice) {
+			start++
+		}
+		strikes = strikes[start : start+f.StrikesAround]
+	}
+	return strikes
+}
+
+// Contracts returns the option contracts of the chain selected by f, by expiration, strike then right.
+// They are not qualified: some combinations may not exist, see QualifyOptions.
+func (oc OptionChain) Contracts(f OptionChainFilter) []*Contract {
+	rights := f.Rights
+	if len(rights) == 0 {
+		rights = []string{"C", "P"}
+	}
+	secType, exchange := "OPT", cmp.Or(f.Exchange, "SMART")
+	if oc.Underlying.SecType == "FUT" {
+		// Futures options are not routed by SMART
+		secType = "FOP"
+		exchange = f.Exchange
+		if exchange == "" && len(oc.Exchanges) > 0 {
+			exchange = oc.Exchanges[0]
+		}
+		exchange = cmp.Or(exchange, oc.Underlying.Exchange)
+	}
+	var contracts []*Contract
+	strikes := oc.FilterStrikes(f)
+	for _, exp := range oc.FilterExpirations(f) {
+		for _, strike := range strikes {
+			for _, right := range rights {
+				contract := NewContract()
+				contract.Symbol = oc.Underlying.Symbol
+				contract.SecType = secType
+				contract.Exchange = exchange
+				contract.Currency = oc.Underlying.Currency
+				contract.LastTradeDateOrContractMonth = exp.Format("20060102")
+				contract.Strike = strike
+				contract.Right = right
+				contract.Multiplier = oc.Multiplier
+				contract.TradingClass = oc.TradingClass
+				contracts = append(contracts, contract)
+			}
+		}
+	}
+	return contracts
+}
+
+// OptionChains returns the option chains of underlying, one per trading class and multiplier.
+// The underlying is qualified first when its ConID is not set.
+func (c *EClient) OptionChains(ctx context.Context, underlying *Contract) ([]OptionChain, error) {
+	qualified := *underlying
+	if qualified.ConID == 0 {
+		details, err := c.ContractDetails(ctx, underlying)
+		if err != nil {
+			return nil, err
+		}
+		if len(details) != 1 {
+			return nil, NewIBError(NO_VALID_ID, currentTimeMillis(), ErrNoSecurityDefinition.Code, "ambiguous or unknown underlying", "")
+		}
+		qualified = details[0].Contract
+	}
+	futFopExchange := ""
+	if qualified.SecType == "FUT" {
+		futFopExchange = qualified.Exchange
+	}
+	params, err := c.SecDefOptParams(ctx, qualified.Symbol, futFopExchange, qualified.SecType, qualified.ConID)
+	if err != nil {
+		return nil, err
+	}
+
+	type chainKey struct{ tradingClass, multiplier string }
+	byKey := make(map[chainKey]*OptionChain)
+	var chains []*OptionChain
+	for _, p := range params {
+		key := chainKey{p.TradingClass, p.Multiplier}
+		oc := byKey[key]
+		if oc == nil {
+			oc = &OptionChain{Underlying: qualified, TradingClass: p.TradingClass, Multiplier: p.Multiplier}
+			byKey[key] = oc
+			chains = append(chains, oc)
+		}
+		oc.Exchanges = append(oc.Exchanges, p.Exchange)
+		for _, s := range p.Expirations {
+			if exp, err := time.Parse("20060102", s); err == nil {
+				oc.Expirations = append(oc.Expirations, exp)
+			}
+		}
+		oc.Strikes = append(oc.Strikes, p.Strikes...)
+	}
+
+	out := make([]OptionChain, 0, len(chains))
+	for _, oc := range chains {
+		slices.Sort(oc.Exchanges)
+		slices.SortFunc(oc.Expirations, time.Time.Compare)
+		oc.Expirations = slices.CompactFunc(oc.Expirations, time.Time.Equal)
+		slices.Sort(oc.Strikes)
+		oc.Strikes = slices.Compact(oc.Strikes)
+		out = append(out, *oc)
+	}
+	return out, nil
+}
+
+// QualifyOptions returns the contract details of the options, requested concurrency at a time (1 when zero).
+// The options that do not exist are skipped, the details are in the order of contracts.
+func (c *EClient) QualifyOptions(ctx context.Context, contracts []*Contract, concurrency int) ([]ContractDetails, error) {
+	results, err := fanOut(ctx, contracts, concurrency, func(ctx context.Context, contract *Contract) ([]ContractDetails, error) {
+		details, err := c.ContractDetails(ctx, contract)
+		if errors.Is(err, ErrNoSecurityDefinition) {
+			return nil, nil
+		}
+		return details, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var details []ContractDetails
+	for _, r := range results {
+		details = append(details, r...)
+	}
+	return details, nil
+}
@@ -0,0 +1,122 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOptionChainFilter(t *testing.T) {
+	date := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	oc := OptionChain{
+		Underlying:   Contract{Symbol: "IBM", SecType: "STK", Currency: "USD"},
+		TradingClass: "IBM",
+		Multiplier:   "100",
+		Expirations:  []time.Time{date(16), date(23), date(30)},
+		Strikes:      []float64{80, 90, 95, 100, 105, 110, 120},
+	}
+	f := OptionChainFilter{RefPrice: 101, Moneyness: 0.1, MinDTE: 1, MaxDTE: 10, Today: time.Date(2026, 10, 16, 15, 0, 0, 0, time.Local)}
+
+	if exps := oc.FilterExpirations(f); len(exps) != 1 || !exps[0].Equal(date(23)) {
+		t.Errorf("unexpected expirations %v", exps)
+	}
+	if strikes := oc.FilterStrikes(f); !slices.Equal(strikes, []float64{95, 100, 105, 110}) {
+		t.Errorf("unexpected strikes %v", strikes)
+	}
+	f.StrikesAround = 2
+	if strikes := oc.FilterStrikes(f); !slices.Equal(strikes, []float64{100, 105}) {
+		t.Errorf("unexpected strikes around %v", strikes)
+	}
+
+	f.Rights = []string{"P"}
+	contracts := oc.Contracts(f)
+	if len(contracts) != 2 {
+		t.Fatalf("unexpected contracts %v", contracts)
+	}
+	if c := contracts[1]; c.SecType != "OPT" || c.Exchange != "SMART" || c.LastTradeDateOrContractMonth != "20261023" || c.Strike != 105 || c.Right != "P" || c.Multiplier != "100" {
+		t.Errorf("unexpected contract %+v", c)
+	}
+}
+
+func TestOptionChains(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ch := async(func() ([]OptionChain, error) {
+		return ib.OptionChains(context.Background(), &Contract{ConID: 8314, Symbol: "IBM", SecType: "STK", Currency: "USD"})
+	})
+	req := gw.nextRequest(t, REQ_SEC_DEF_OPT_PARAMS)
+	reqID := string(req[1])
+	writeFrame(conn, SECURITY_DEFINITION_OPTION_PARAMETER, reqID, "CBOE", 8314, "IBM", "100", 2, "20261120", "20261023", 2, 100, 95)
+	writeFrame(conn, SECURITY_DEFINITION_OPTION_PARAMETER, reqID, "AMEX", 8314, "IBM", "100", 1, "20261023", 2, 105, 100)
+	writeFrame(conn, SECURITY_DEFINITION_OPTION_PARAMETER, reqID, "CBOE", 8314, "2IBM", "10", 1, "20261023", 1, 100)
+	writeFrame(conn, SECURITY_DEFINITION_OPTION_PARAMETER_END, reqID)
+
+	chains, err := awaitResult(t, ch)
+	if err != nil {
+		t.Fatalf("OptionChains: %v", err)
+	}
+	if len(chains) != 2 {
+		t.Fatalf("unexpected chains %+v", chains)
+	}
+	ibm := chains[0]
+	if ibm.TradingClass != "IBM" || !slices.Equal(ibm.Exchanges, []string{"AMEX", "CBOE"}) || !slices.Equal(ibm.Strikes, []float64{95, 100, 105}) {
+		t.Errorf("unexpected chain %+v", ibm)
+	}
+	if len(ibm.Expirations) != 2 || ibm.Expirations[0].Format("20060102") != "20261023" {
+		t.Errorf("unexpected expirations %v", ibm.Expirations)
+	}
+
+	// Futures options are listed on the exchange of the future
+	ch = async(func() ([]OptionChain, error) {
+		return ib.OptionChains(context.Background(), &Contract{ConID: 495512563, Symbol: "ES", SecType: "FUT", Exchange: "CME", Currency: "USD"})
+	})
+	req = gw.nextRequest(t, REQ_SEC_DEF_OPT_PARAMS)
+	if string(req[3]) != "CME" || string(req[4]) != "FUT" {
+		t.Errorf("unexpected request %q", req)
+	}
+	reqID = string(req[1])
+	writeFrame(conn, SECURITY_DEFINITION_OPTION_PARAMETER, reqID, "CME", 495512563, "ES", "50", 1, "20261120", 2, 5000, 5100)
+	writeFrame(conn, SECURITY_DEFINITION_OPTION_PARAMETER_END, reqID)
+	chains, err = awaitResult(t, ch)
+	if err != nil || len(chains) != 1 {
+		t.Fatalf("unexpected chains %+v %v", chains, err)
+	}
+	contracts := chains[0].Contracts(OptionChainFilter{Rights: []string{"C"}, Today: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)})
+	if len(contracts) != 2 {
+		t.Fatalf("unexpected contracts %v", contracts)
+	}
+	if c := contracts[0]; c.SecType != "FOP" || c.Exchange != "CME" || c.Multiplier != "50" || c.Strike != 5000 {
+		t.Errorf("unexpected contract %+v", c)
+	}
+}
+
+func TestQualifyOptions(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	contracts := []*Contract{{Symbol: "IBM", Strike: 100}, {Symbol: "IBM", Strike: 101}, {Symbol: "IBM", Strike: 102}}
+	ch := async(func() ([]ContractDetails, error) {
+		return ib.QualifyOptions(context.Background(), contracts, 1)
+	})
+	req := gw.nextRequest(t, REQ_CONTRACT_DATA)
+	writeFrame(conn, CONTRACT_DATA_END, 1, string(req[2]))
+	// A combination that does not exist is skipped
+	req = gw.nextRequest(t, REQ_CONTRACT_DATA)
+	writeFrame(conn, ERR_MSG, string(req[2]), 200, "No security definition has been found for the request", "", 0)
+	req = gw.nextRequest(t, REQ_CONTRACT_DATA)
+	writeFrame(conn, CONTRACT_DATA_END, 1, string(req[2]))
+	if details, err := awaitResult(t, ch); err != nil || len(details) != 0 {
+		t.Errorf("unexpected result %v %v", details, err)
+	}
+
+	ch = async(func() ([]ContractDetails, error) {
+		return ib.QualifyOptions(context.Background(), contracts, 1)
+	})
+	req = gw.nextRequest(t, REQ_CONTRACT_DATA)
+	writeFrame(conn, ERR_MSG, string(req[2]), 321, "Error validating request", "", 0)
+	var ibErr *IBError
+	if _, err := awaitResult(t, ch); !errors.As(err, &ibErr) || ibErr.Code != 321 {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package ibapi
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
 
 type AuctionStrategy = int64
 
@@ -327,6 +331,33 @@ func NewOrder() *Order {
 	return order
 }
 
+// clone returns a copy of the order sharing nothing mutable with it.
+func (o *Order) clone() *Order {
+	c := *o
+	c.AlgoParams = slices.Clone(o.AlgoParams)
+	c.SmartComboRoutingParams = slices.Clone(o.SmartComboRoutingParams)
+	c.OrderComboLegs = slices.Clone(o.OrderComboLegs)
+	c.OrderMiscOptions = slices.Clone(o.OrderMiscOptions)
+	if o.Conditions != nil {
+		c.Conditions = make([]OrderCondition, len(o.Conditions))
+		for i, cond := range o.Conditions {
+			c.Conditions[i] = cloneCondition(cond)
+		}
+	}
+	return &c
+}
+
+// cloneCondition returns a copy of the condition, all of them being pointers to flat structs.
+func cloneCondition(cond OrderCondition) OrderCondition {
+	v := reflect.ValueOf(cond)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return cond
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(OrderCondition)
+}
+
 func (o *Order) HasSameID(other *Order) bool {
 	if o.PermID != 0 && other.PermID != 0 {
 		return o.PermID == other.PermID
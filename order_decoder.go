@@ -1,5 +1,7 @@
 package ibapi
 
+import "fmt"
+
 // OrderDecoder .
 type OrderDecoder struct {
 	order         *Order
@@ -463,6 +465,11 @@ func (d *OrderDecoder) decodeConditions(msgBuf *MsgBuffer) {
 			var i int64
 			for i = 0; i < conditionsSize; i++ {
 				conditionType := msgBuf.decodeInt64()
+				switch conditionType {
+				case PriceOrderCondition, TimeOrderCondition, MarginOrderCondition, ExecutionOrderCondition, VolumeOrderCondition, PercentChangeOrderCondition:
+				default:
+					panic(decodeFault{msg: fmt.Sprintf("unknown order condition type %d", conditionType)})
+				}
 				cond := CreateOrderCondition(conditionType)
 				cond.decode(msgBuf)
 
@@ -0,0 +1,115 @@
+package ibapi
+
+import (
+	"context"
+	"sync"
+)
+
+// OrderIDs hands out order ids to concurrent goroutines.
+// The EClient seeds it from every NextValidID, on connect, after a reconnect and in answer to ReqIDs,
+// and bumps it past the ids TWS rejects as duplicates (error 103).
+// Ids are monotonically increasing: a seed lower than the ids already handed out is ignored.
+type OrderIDs struct {
+	mu        sync.Mutex
+	next      int64
+	seeded    chan struct{} // closed once the current session gave a NextValidID
+	resyncing bool          // a ReqIDs was sent after a duplicate id, until the next NextValidID
+}
+
+func newOrderIDs() *OrderIDs {
+	return &OrderIDs{seeded: make(chan struct{})}
+}
+
+// OrderIDs returns the order id allocator of the client.
+func (c *EClient) OrderIDs() *OrderIDs {
+	return c.orderIDs
+}
+
+// Next returns a new order id.
+// It waits for the NextValidID of the session when not yet received, until ctx is done.
+func (o *OrderIDs) Next(ctx context.Context) (int64, error) {
+	for {
+		o.mu.Lock()
+		seeded := o.seeded
+		select {
+		case <-seeded:
+			id := o.next
+			o.next++
+			o.mu.Unlock()
+			return id, nil
+		default:
+		}
+		o.mu.Unlock()
+
+		select {
+		case <-seeded:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// Peek returns the id the next call to Next would return and whether the allocator is seeded.
+func (o *OrderIDs) Peek() (int64, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	select {
+	case <-o.seeded:
+		return o.next, true
+	default:
+		return o.next, false
+	}
+}
+
+// seed records a NextValidID.
+func (o *OrderIDs) seed(id int64) {
+	if id == NO_VALID_ID {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.next = max(o.next, id)
+	o.resyncing = false
+	select {
+	case <-o.seeded:
+	default:
+		close(o.seeded)
+	}
+}
+
+// bump moves the allocator past an id rejected as duplicate.
+// It returns true when a ReqIDs must resync it with TWS, once for all the duplicates reported before the next NextValidID.
+func (o *OrderIDs) bump(id int64) (resync bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.next = max(o.next, id+1)
+	resync = !o.resyncing
+	o.resyncing = true
+	return resync
+}
+
+// unseed makes Next wait for the NextValidID of the next session, the ids already handed out stay used.
+func (o *OrderIDs) unseed() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.resyncing = false
+	select {
+	case <-o.seeded:
+		o.seeded = make(chan struct{})
+	default:
+	}
+}
+
+// PlaceNewOrder places order under a new id from OrderIDs and returns the id.
+// ctx bounds the wait for the id and for the request to be queued.
+func (c *EClient) PlaceNewOrder(ctx context.Context, contract *Contract, order *Order) (int64, error) {
+	if !c.IsConnected() {
+		return 0, NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, "")
+	}
+	orderID, err := c.orderIDs.Next(ctx)
+	if err != nil {
+		return 0, err
+	}
+	order.OrderID = orderID
+	return orderID, c.PlaceOrderContext(ctx, orderID, contract, order)
+}
@@ -0,0 +1,113 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOrderIDsConcurrent(t *testing.T) {
+	o := newOrderIDs()
+	o.seed(100)
+
+	const n = 50
+	ids := make(chan int64, n)
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(func() {
+			id, err := o.Next(context.Background())
+			if err != nil {
+				t.Error(err)
+			}
+			ids <- id
+		})
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int64]bool)
+	for id := range ids {
+		if id < 100 || id >= 100+n || seen[id] {
+			t.Fatalf("unexpected id %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestOrderIDsSeed(t *testing.T) {
+	o := newOrderIDs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := o.Next(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the unseeded allocator to wait, got %v", err)
+	}
+
+	o.seed(10)
+	o.Next(context.Background())
+	// A session resync never goes back
+	o.unseed()
+	if _, seeded := o.Peek(); seeded {
+		t.Fatal("still seeded")
+	}
+	o.seed(5)
+	if id, _ := o.Next(context.Background()); id != 11 {
+		t.Errorf("got %d, want 11", id)
+	}
+	o.seed(20)
+	if id, _ := o.Next(context.Background()); id != 20 {
+		t.Errorf("got %d, want 20", id)
+	}
+	o.bump(30)
+	if id, _ := o.Peek(); id != 31 {
+		t.Errorf("got %d, want 31", id)
+	}
+}
+
+func TestOrderIDsSession(t *testing.T) {
+	ib, gw, conn, w := connectSync(t)
+
+	writeFrame(conn, NEXT_VALID_ID, 1, 42)
+	order := &Order{Action: "BUY", OrderType: "MKT", TotalQuantity: StringToDecimal("1")}
+	id, err := ib.PlaceNewOrder(context.Background(), &Contract{Symbol: "IBM", SecType: "STK", Exchange: "SMART", Currency: "USD"}, order)
+	if err != nil || id != 42 || order.OrderID != 42 {
+		t.Fatalf("got id %d, order id %d, err %v", id, order.OrderID, err)
+	}
+	if req := gw.nextRequest(t, PLACE_ORDER); string(req[1]) != "42" {
+		t.Errorf("placed order id %s", req[1])
+	}
+
+	// PlaceOrder sends the id it is given, even zero
+	ib.PlaceOrder(0, &Contract{Symbol: "IBM", SecType: "STK", Exchange: "SMART", Currency: "USD"}, order)
+	if req := gw.nextRequest(t, PLACE_ORDER); string(req[1]) != "0" {
+		t.Errorf("placed order id %s", req[1])
+	}
+	if id, _ := ib.OrderIDs().Peek(); id != 43 {
+		t.Errorf("got %d, want 43", id)
+	}
+
+	// The user kept its own counter and used 50 already
+	writeFrame(conn, ERR_MSG, 50, 103, "Duplicate order id", "", 0)
+	gw.nextRequest(t, REQ_IDS)
+	waitFor(t, "duplicate id reported", func() bool { return len(w.recorded()) == 1 })
+	if id, _ := ib.OrderIDs().Peek(); id != 51 {
+		t.Errorf("got %d, want 51", id)
+	}
+	// The duplicates reported before the answer share its resync
+	writeFrame(conn, ERR_MSG, 51, 103, "Duplicate order id", "", 0)
+	waitFor(t, "duplicate id reported", func() bool { return len(w.recorded()) == 2 })
+	ib.ReqCurrentTime()
+	if req := gw.nextFrame(t); string(req[0]) != strconv.Itoa(int(REQ_CURRENT_TIME)) {
+		t.Errorf("unexpected request %s", req[0])
+	}
+	writeFrame(conn, NEXT_VALID_ID, 1, 60)
+	waitFor(t, "resync", func() bool {
+		id, _ := ib.OrderIDs().Peek()
+		return id == 60
+	})
+	writeFrame(conn, ERR_MSG, 60, 103, "Duplicate order id", "", 0)
+	gw.nextRequest(t, REQ_IDS)
+}
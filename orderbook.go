@@ -0,0 +1,290 @@
+package ibapi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/robaho/fixed"
+)
+
+// Market depth operations and sides of UpdateMktDepth and UpdateMktDepthL2.
+const (
+	DEPTH_INSERT int64 = 0
+	DEPTH_UPDATE int64 = 1
+	DEPTH_DELETE int64 = 2
+
+	DEPTH_ASK int64 = 0
+	DEPTH_BID int64 = 1
+)
+
+// ErrDepthSequence reports a market depth operation on a row that does not exist.
+var ErrDepthSequence = errors.New("market depth operation out of sequence")
+
+// DepthRow is a row of a side of an OrderBook.
+type DepthRow struct {
+	Price       float64
+	Size        Decimal
+	MarketMaker string // market maker, or exchange with SMART depth, empty for UpdateMktDepth
+}
+
+// PriceLevel aggregates the rows of a side at a price.
+type PriceLevel struct {
+	Price   float64
+	Size    Decimal
+	CumSize Decimal // size of this level and the better ones
+	Rows    int
+}
+
+// OrderBookSnapshot is the state of an OrderBook at a point in time.
+type OrderBookSnapshot struct {
+	ReqID           int64
+	Contract        *Contract
+	IsSmartDepth    bool
+	Bids            []DepthRow // by position, best first
+	Asks            []DepthRow // by position, best first
+	RerouteConID    int64      // set by RerouteMktDepthReq
+	RerouteExchange string
+	Anomalies       int // operations out of sequence since the request
+	Resets          int
+	Updated         time.Time
+}
+
+// BestBid returns the first bid row.
+func (s OrderBookSnapshot) BestBid() (DepthRow, bool) {
+	if len(s.Bids) == 0 {
+		return DepthRow{}, false
+	}
+	return s.Bids[0], true
+}
+
+// BestAsk returns the first ask row.
+func (s OrderBookSnapshot) BestAsk() (DepthRow, bool) {
+	if len(s.Asks) == 0 {
+		return DepthRow{}, false
+	}
+	return s.Asks[0], true
+}
+
+// BidLevels returns up to n bid levels aggregated by price, best first. n <= 0 returns them all.
+func (s OrderBookSnapshot) BidLevels(n int) []PriceLevel {
+	return aggregateLevels(s.Bids, n, func(a, b float64) int { return cmp.Compare(b, a) })
+}
+
+// AskLevels returns up to n ask levels aggregated by price, best first. n <= 0 returns them all.
+func (s OrderBookSnapshot) AskLevels(n int) []PriceLevel {
+	return aggregateLevels(s.Asks, n, cmp.Compare[float64])
+}
+
+// Imbalance returns (bid size - ask size) / (bid size + ask size) over the n best levels of each side, in [-1, 1].
+func (s OrderBookSnapshot) Imbalance(n int) float64 {
+	var bids, asks float64
+	if levels := s.BidLevels(n); len(levels) > 0 {
+		bids = levels[len(levels)-1].CumSize.Float()
+	}
+	if levels := s.AskLevels(n); len(levels) > 0 {
+		asks = levels[len(levels)-1].CumSize.Float()
+	}
+	if bids+asks == 0 {
+		return 0
+	}
+	return (bids - asks) / (bids + asks)
+}
+
+func aggregateLevels(rows []DepthRow, n int, compare func(a, b float64) int) []PriceLevel {
+	var levels []PriceLevel
+	for _, row := range rows {
+		i, found := slices.BinarySearchFunc(levels, row.Price, func(l PriceLevel, price float64) int { return compare(l.Price, price) })
+		if !found {
+			levels = slices.Insert(levels, i, PriceLevel{Price: row.Price, Size: ZERO})
+		}
+		levels[i].Size = Decimal(fixed.Fixed(levels[i].Size).Add(fixed.Fixed(row.Size)))
+		levels[i].Rows++
+	}
+	if n > 0 && len(levels) > n {
+		levels = levels[:n]
+	}
+	cum := fixed.ZERO
+	for i := range levels {
+		cum = cum.Add(fixed.Fixed(levels[i].Size))
+		levels[i].CumSize = Decimal(cum)
+	}
+	return levels
+}
+
+// OrderBookEventKind tells what changed in an order book.
+type OrderBookEventKind int
+
+const (
+	OrderBookInsert   OrderBookEventKind = iota // row inserted
+	OrderBookUpdate                             // row updated
+	OrderBookDelete                             // row deleted
+	OrderBookReset                              // book emptied, TWS sends it again
+	OrderBookAnomaly                            // operation out of sequence
+	OrderBookRerouted                           // request rerouted by RerouteMktDepthReq
+)
+
+func (k OrderBookEventKind) String() string {
+	switch k {
+	case OrderBookInsert:
+		return "Insert"
+	case OrderBookUpdate:
+		return "Update"
+	case OrderBookDelete:
+		return "Delete"
+	case OrderBookReset:
+		return "Reset"
+	case OrderBookAnomaly:
+		return "Anomaly"
+	case OrderBookRerouted:
+		return "Rerouted"
+	default:
+		return "unknown order book event kind"
+	}
+}
+
+// OrderBookEvent is a change of an order book.
+type OrderBookEvent struct {
+	Kind     OrderBookEventKind
+	Side     int64 // DEPTH_ASK or DEPTH_BID
+	Position int64
+	Row      DepthRow // new row, or the deleted one
+	Err      error    // for OrderBookAnomaly, wraps ErrDepthSequence
+}
+
+// OrderBook maintains the market depth of a contract requested with ReqOrderBook.
+// The book is emptied when TWS resets it (317), after a 1101 and when the session is rebuilt, TWS then sends it again.
+type OrderBook struct {
+	streamLifecycle
+
+	mu        sync.Mutex
+	snap      OrderBookSnapshot
+	observers observerList[OrderBookEvent]
+}
+
+func newOrderBook(reqID int64, contract *Contract, isSmartDepth bool, streams *streamRegistry, cancel func()) *OrderBook {
+	return &OrderBook{
+		streamLifecycle: streamLifecycle{ReqID: reqID, streams: streams, cancel: cancel, done: make(chan struct{})},
+		snap:            OrderBookSnapshot{ReqID: reqID, Contract: contract, IsSmartDepth: isSmartDepth},
+	}
+}
+
+// Snapshot returns a copy of the current state.
+func (b *OrderBook) Snapshot() OrderBookSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.snap
+	s.Bids = slices.Clone(b.snap.Bids)
+	s.Asks = slices.Clone(b.snap.Asks)
+	return s
+}
+
+// AddObserver registers fn to be called on every change of the book, in order.
+// fn runs synchronously in the decoder goroutine: it must return quickly. The returned function unregisters fn.
+func (b *OrderBook) AddObserver(fn func(OrderBookEvent)) (remove func()) {
+	return b.observers.add(fn)
+}
+
+// apply applies a depth operation by position.
+func (b *OrderBook) apply(position int64, marketMaker string, operation int64, side int64, price float64, size Decimal) {
+	row := DepthRow{Price: price, Size: size, MarketMaker: marketMaker}
+	ev := OrderBookEvent{Side: side, Position: position, Row: row}
+
+	b.mu.Lock()
+	rows := &b.snap.Asks
+	if side == DEPTH_BID {
+		rows = &b.snap.Bids
+	}
+	n := int64(len(*rows))
+	switch {
+	case position < 0:
+		ev.Kind = OrderBookAnomaly
+	case operation == DEPTH_INSERT && position <= n:
+		ev.Kind = OrderBookInsert
+		*rows = slices.Insert(*rows, int(position), row)
+	case operation == DEPTH_INSERT:
+		// Keep the row rather than lose it, the book is flagged inconsistent
+		ev.Kind = OrderBookAnomaly
+		*rows = append(*rows, row)
+	case operation == DEPTH_UPDATE && position < n:
+		ev.Kind = OrderBookUpdate
+		(*rows)[position] = row
+	case operation == DEPTH_DELETE && position < n:
+		ev.Kind = OrderBookDelete
+		ev.Row = (*rows)[position]
+		*rows = slices.Delete(*rows, int(position), int(position)+1)
+	default:
+		ev.Kind = OrderBookAnomaly
+	}
+	if ev.Kind == OrderBookAnomaly {
+		b.snap.Anomalies++
+		ev.Err = fmt.Errorf("%w: operation %d at position %d of %d rows", ErrDepthSequence, operation, position, n)
+	}
+	b.snap.Updated = time.Now()
+	b.mu.Unlock()
+
+	b.observers.notify(ev)
+}
+
+// reset empties the book.
+func (b *OrderBook) reset() {
+	b.mu.Lock()
+	b.snap.Bids, b.snap.Asks = nil, nil
+	b.snap.Resets++
+	b.snap.Updated = time.Now()
+	b.mu.Unlock()
+
+	b.observers.notify(OrderBookEvent{Kind: OrderBookReset})
+}
+
+func (b *OrderBook) rerouted(conID int64, exchange string) {
+	b.mu.Lock()
+	b.snap.RerouteConID, b.snap.RerouteExchange = conID, exchange
+	b.mu.Unlock()
+
+	b.observers.notify(OrderBookEvent{Kind: OrderBookRerouted})
+}
+
+// orderBook returns the order book fed by reqID, nil if there is none.
+func orderBook(r *streamRegistry, reqID int64) *OrderBook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, _ := r.byReqID[reqID].(*OrderBook)
+	return b
+}
+
+// resetOrderBooks empties every order book, TWS sends them again.
+func (r *streamRegistry) resetOrderBooks() {
+	if r == nil {
+		return
+	}
+	r.mu.RLock()
+	var books []*OrderBook
+	for _, s := range r.byReqID {
+		if b, ok := s.(*OrderBook); ok {
+			books = append(books, b)
+		}
+	}
+	r.mu.RUnlock()
+	for _, b := range books {
+		b.reset()
+	}
+}
+
+// ReqOrderBook requests the market depth of contract and maintains it in an OrderBook. See ReqMktDepth for the parameters.
+// The market depth is cancelled when ctx is done.
+func (c *EClient) ReqOrderBook(ctx context.Context, contract *Contract, numRows int, isSmartDepth bool) (*OrderBook, error) {
+	reqID := c.calls.allocReqID()
+	b := newOrderBook(reqID, contract, isSmartDepth, c.streams, func() { c.CancelMktDepth(reqID, isSmartDepth) })
+	err := b.start(ctx, b, func(reqID int64) error {
+		return c.ReqMktDepthContext(ctx, reqID, contract, numRows, isSmartDepth, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
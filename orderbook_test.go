@@ -0,0 +1,102 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOrderBookOperations(t *testing.T) {
+	b := newOrderBook(1, &Contract{Symbol: "IBM"}, true, newStreamRegistry(), nil)
+	var events []OrderBookEvent
+	b.AddObserver(func(ev OrderBookEvent) { events = append(events, ev) })
+
+	b.apply(0, "NYSE", DEPTH_INSERT, DEPTH_BID, 100, StringToDecimal("10"))
+	b.apply(1, "ARCA", DEPTH_INSERT, DEPTH_BID, 100, StringToDecimal("5"))
+	b.apply(2, "NYSE", DEPTH_INSERT, DEPTH_BID, 99.5, StringToDecimal("20"))
+	b.apply(0, "ARCA", DEPTH_INSERT, DEPTH_ASK, 100.5, StringToDecimal("7"))
+	b.apply(1, "NYSE", DEPTH_INSERT, DEPTH_ASK, 101, StringToDecimal("3"))
+	b.apply(1, "ARCA", DEPTH_UPDATE, DEPTH_BID, 100, StringToDecimal("6"))
+	b.apply(1, "NYSE", DEPTH_DELETE, DEPTH_ASK, 101, StringToDecimal("3"))
+	b.apply(5, "NYSE", DEPTH_UPDATE, DEPTH_ASK, 102, ONE)
+
+	s := b.Snapshot()
+	if len(s.Bids) != 3 || len(s.Asks) != 1 || s.Anomalies != 1 {
+		t.Fatalf("unexpected book %+v", s)
+	}
+	if best, _ := s.BestBid(); best.MarketMaker != "NYSE" || best.Price != 100 {
+		t.Errorf("unexpected best bid %+v", best)
+	}
+	levels := s.BidLevels(0)
+	if len(levels) != 2 || levels[0].Price != 100 || levels[0].Size != StringToDecimal("16") || levels[0].Rows != 2 {
+		t.Errorf("unexpected bid levels %+v", levels)
+	}
+	if levels[1].CumSize != StringToDecimal("36") {
+		t.Errorf("unexpected cumulative size %+v", levels[1])
+	}
+	if top := s.BidLevels(1); len(top) != 1 {
+		t.Errorf("unexpected top level %+v", top)
+	}
+	if imbalance := s.Imbalance(1); imbalance != (16.0-7)/(16+7) {
+		t.Errorf("unexpected imbalance %v", imbalance)
+	}
+
+	want := []OrderBookEventKind{
+		OrderBookInsert, OrderBookInsert, OrderBookInsert, OrderBookInsert, OrderBookInsert,
+		OrderBookUpdate, OrderBookDelete, OrderBookAnomaly,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i := range want {
+		if events[i].Kind != want[i] {
+			t.Fatalf("event %d is %s, want %s", i, events[i].Kind, want[i])
+		}
+	}
+	if deleted := events[6].Row; deleted.Price != 101 {
+		t.Errorf("unexpected deleted row %+v", deleted)
+	}
+	if !errors.Is(events[7].Err, ErrDepthSequence) {
+		t.Errorf("unexpected anomaly error %v", events[7].Err)
+	}
+
+	b.reset()
+	if s := b.Snapshot(); len(s.Bids) != 0 || len(s.Asks) != 0 || s.Resets != 1 {
+		t.Errorf("book not reset %+v", s)
+	}
+}
+
+func TestOrderBookSession(t *testing.T) {
+	ib, gw, conn, w := connectSync(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b, err := ib.ReqOrderBook(ctx, &Contract{Symbol: "IBM"}, 5, true)
+	if err != nil {
+		t.Fatalf("ReqOrderBook: %v", err)
+	}
+	gw.nextRequest(t, REQ_MKT_DEPTH)
+	writeFrame(conn, MARKET_DEPTH_L2, 1, b.ReqID, 0, "NYSE", DEPTH_INSERT, DEPTH_BID, 100, 10, 1)
+	writeFrame(conn, MARKET_DEPTH, 1, b.ReqID, 0, DEPTH_INSERT, DEPTH_ASK, 100.5, 7)
+	writeFrame(conn, REROUTE_MKT_DEPTH_REQ, b.ReqID, 8314, "NYSE")
+	waitFor(t, "book updated", func() bool {
+		s := b.Snapshot()
+		return len(s.Bids) == 1 && len(s.Asks) == 1 && s.RerouteConID == 8314
+	})
+
+	writeFrame(conn, ERR_MSG, b.ReqID, 317, "Market depth data has been RESET. Please empty deep book contents before applying any new entries.", "", 0)
+	waitFor(t, "book reset", func() bool {
+		s := b.Snapshot()
+		return s.Resets == 1 && len(s.Bids) == 0
+	})
+	waitFor(t, "reset forwarded", func() bool {
+		codes := w.recorded()
+		return len(codes) == 1 && codes[0] == 317
+	})
+
+	cancel()
+	<-b.Done()
+	if b.Err() != nil {
+		t.Errorf("unexpected error %v", b.Err())
+	}
+	gw.nextRequest(t, CANCEL_MKT_DEPTH)
+}
@@ -0,0 +1,374 @@
+package ibapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MsgPriority is the lane an outgoing message waits in when the pacer is on.
+type MsgPriority int
+
+const (
+	PriorityUrgent MsgPriority = iota
+	PriorityNormal
+	PriorityBulk
+	numPriorities
+)
+
+func (mp MsgPriority) String() string {
+	switch mp {
+	case PriorityUrgent:
+		return "urgent"
+	case PriorityNormal:
+		return "normal"
+	case PriorityBulk:
+		return "bulk"
+	default:
+		return "unknown priority"
+	}
+}
+
+// DefaultMsgPriorities lets order cancellations jump ahead of bulk data requests.
+// Any message not listed here is sent with PriorityNormal.
+// A cancellation never overtakes the requests it cancels, see cancelledRequests.
+var DefaultMsgPriorities = map[OUT]MsgPriority{
+	CANCEL_ORDER:             PriorityUrgent,
+	REQ_GLOBAL_CANCEL:        PriorityUrgent,
+	REQ_MKT_DATA:             PriorityBulk,
+	REQ_CONTRACT_DATA:        PriorityBulk,
+	REQ_MKT_DEPTH:            PriorityBulk,
+	REQ_HISTORICAL_DATA:      PriorityBulk,
+	REQ_REAL_TIME_BARS:       PriorityBulk,
+	REQ_TICK_BY_TICK_DATA:    PriorityBulk,
+	REQ_HISTORICAL_TICKS:     PriorityBulk,
+	REQ_HEAD_TIMESTAMP:       PriorityBulk,
+	REQ_HISTOGRAM_DATA:       PriorityBulk,
+	REQ_SEC_DEF_OPT_PARAMS:   PriorityBulk,
+	REQ_MATCHING_SYMBOLS:     PriorityBulk,
+	REQ_SCANNER_SUBSCRIPTION: PriorityBulk,
+	REQ_HISTORICAL_NEWS:      PriorityBulk,
+	REQ_NEWS_ARTICLE:         PriorityBulk,
+}
+
+// cancelledRequests lists the requests cancelled by each cancellation message.
+// When a cancellation is queued, the pending requests it may cancel move to its lane ahead of it,
+// so that TWS never receives a cancellation before its request.
+var cancelledRequests = map[OUT][]OUT{
+	CANCEL_MKT_DATA:              {REQ_MKT_DATA},
+	CANCEL_ORDER:                 {PLACE_ORDER},
+	REQ_GLOBAL_CANCEL:            {PLACE_ORDER},
+	CANCEL_MKT_DEPTH:             {REQ_MKT_DEPTH},
+	CANCEL_NEWS_BULLETINS:        {REQ_NEWS_BULLETINS},
+	CANCEL_SCANNER_SUBSCRIPTION:  {REQ_SCANNER_SUBSCRIPTION},
+	CANCEL_HISTORICAL_DATA:       {REQ_HISTORICAL_DATA},
+	CANCEL_REAL_TIME_BARS:        {REQ_REAL_TIME_BARS},
+	CANCEL_CALC_IMPLIED_VOLAT:    {REQ_CALC_IMPLIED_VOLAT},
+	CANCEL_CALC_OPTION_PRICE:     {REQ_CALC_OPTION_PRICE},
+	CANCEL_ACCOUNT_SUMMARY:       {REQ_ACCOUNT_SUMMARY},
+	CANCEL_POSITIONS:             {REQ_POSITIONS},
+	CANCEL_POSITIONS_MULTI:       {REQ_POSITIONS_MULTI},
+	CANCEL_ACCOUNT_UPDATES_MULTI: {REQ_ACCOUNT_UPDATES_MULTI},
+	CANCEL_HISTOGRAM_DATA:        {REQ_HISTOGRAM_DATA},
+	CANCEL_HEAD_TIMESTAMP:        {REQ_HEAD_TIMESTAMP},
+	CANCEL_PNL:                   {REQ_PNL},
+	CANCEL_PNL_SINGLE:            {REQ_PNL_SINGLE},
+	CANCEL_TICK_BY_TICK_DATA:     {REQ_TICK_BY_TICK_DATA},
+	CANCEL_WSH_META_DATA:         {REQ_WSH_META_DATA},
+	CANCEL_WSH_EVENT_DATA:        {REQ_WSH_EVENT_DATA},
+	CANCEL_CONTRACT_DATA:         {REQ_CONTRACT_DATA},
+	CANCEL_HISTORICAL_TICKS:      {REQ_HISTORICAL_TICKS},
+}
+
+// PacerConfig configures the token bucket pacing the outgoing messages.
+// TWS disconnects clients sending more than 50 messages per second: at most Burst + Rate messages leave in any second.
+type PacerConfig struct {
+	// Rate is the number of messages per second.
+	Rate float64
+	// Burst is the number of messages that can be sent at once after an idle period.
+	Burst int
+	// Priorities overrides DefaultMsgPriorities for the listed messages.
+	Priorities map[OUT]MsgPriority
+	// MaxQueued is the number of messages waiting in the lanes, 100 when zero.
+	// Once reached, the requester stops taking requests and the request methods block until ctx is done.
+	MaxQueued int
+}
+
+// DefaultPacerConfig returns a pacer config that never exceeds 50 messages in a second.
+func DefaultPacerConfig() *PacerConfig {
+	return &PacerConfig{
+		Rate:      40,
+		Burst:     10,
+		MaxQueued: 100,
+	}
+}
+
+// PacerStats are the queue depth and throttling metrics of the pacer.
+type PacerStats struct {
+	Queued     [numPriorities]int // messages waiting per lane, indexed by MsgPriority
+	Sent       int64              // messages sent through the pacer
+	Throttled  int64              // messages that had to wait for a token
+	TotalDelay time.Duration      // cumulated wait of the throttled messages
+	MaxDelay   time.Duration      // longest wait of a throttled message
+}
+
+// QueueDepth returns the number of messages waiting in all lanes.
+func (ps PacerStats) QueueDepth() int {
+	var n int
+	for _, q := range ps.Queued {
+		n += q
+	}
+	return n
+}
+
+type pacedMsg struct {
+	req    []byte
+	msgID  OUT
+	queued time.Time
+}
+
+// pacer is a token bucket with one FIFO lane per priority.
+type pacer struct {
+	mu         sync.Mutex
+	rate       float64
+	burst      float64
+	maxQueued  int
+	priorities map[OUT]MsgPriority
+	lanes      [numPriorities][]pacedMsg
+	tokens     float64
+	last       time.Time
+	starvedAt  time.Time // last time a message had to wait for a token
+	stats      PacerStats
+}
+
+func newPacer(config *PacerConfig) *pacer {
+	p := &pacer{
+		rate:       config.Rate,
+		burst:      float64(config.Burst),
+		maxQueued:  config.MaxQueued,
+		priorities: DefaultMsgPriorities,
+	}
+	if p.rate <= 0 {
+		p.rate = DefaultPacerConfig().Rate
+	}
+	if p.burst < 1 {
+		p.burst = 1
+	}
+	if p.maxQueued <= 0 {
+		p.maxQueued = DefaultPacerConfig().MaxQueued
+	}
+	if len(config.Priorities) > 0 {
+		p.priorities = make(map[OUT]MsgPriority, len(DefaultMsgPriorities)+len(config.Priorities))
+		for id, prio := range DefaultMsgPriorities {
+			p.priorities[id] = prio
+		}
+		for id, prio := range config.Priorities {
+			p.priorities[id] = prio
+		}
+	}
+	return p
+}
+
+// start drops the messages of a previous session and fills the bucket.
+func (p *pacer) start(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.lanes {
+		p.lanes[i] = nil
+	}
+	p.tokens = p.burst
+	p.last = now
+	p.starvedAt = time.Time{}
+}
+
+// refill adds the tokens earned since the last refill, up to the burst size.
+func (p *pacer) refill(now time.Time) {
+	p.tokens = min(p.burst, p.tokens+now.Sub(p.last).Seconds()*p.rate)
+	p.last = now
+}
+
+func (p *pacer) pending() int {
+	var n int
+	for _, lane := range p.lanes {
+		n += len(lane)
+	}
+	return n
+}
+
+// full tells whether the lanes hold MaxQueued messages.
+func (p *pacer) full() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pending() >= p.maxQueued
+}
+
+// push queues a request in the lane of its message id.
+// A cancellation first promotes to its lane the pending requests it may cancel.
+func (p *pacer) push(req []byte, msgID OUT, now time.Time) {
+	prio, ok := p.priorities[msgID]
+	if !ok {
+		prio = PriorityNormal
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if cancelled := cancelledRequests[msgID]; len(cancelled) > 0 {
+		p.promote(cancelled, prio)
+	}
+	p.lanes[prio] = append(p.lanes[prio], pacedMsg{req: req, msgID: msgID, queued: now})
+}
+
+// promote moves the queued messages of the given ids from the less urgent lanes to the end of lane prio, in their queued order.
+func (p *pacer) promote(msgIDs []OUT, prio MsgPriority) {
+	var promoted []pacedMsg
+	for i := prio + 1; i < numPriorities; i++ {
+		p.lanes[i] = slices.DeleteFunc(p.lanes[i], func(m pacedMsg) bool {
+			if slices.Contains(msgIDs, m.msgID) {
+				promoted = append(promoted, m)
+				return true
+			}
+			return false
+		})
+	}
+	slices.SortStableFunc(promoted, func(a, b pacedMsg) int { return a.queued.Compare(b.queued) })
+	p.lanes[prio] = append(p.lanes[prio], promoted...)
+}
+
+// pop returns the oldest request of the most urgent lane if a token is available.
+// Otherwise it returns the delay until the next token, or zero if there is nothing to send.
+func (p *pacer) pop(now time.Time) ([]byte, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pending() == 0 {
+		return nil, 0
+	}
+	p.refill(now)
+	if p.tokens < 1 {
+		p.starvedAt = now
+		return nil, time.Duration((1 - p.tokens) / p.rate * float64(time.Second))
+	}
+	p.tokens--
+	for i, lane := range p.lanes {
+		if len(lane) == 0 {
+			continue
+		}
+		m := lane[0]
+		lane[0] = pacedMsg{}
+		p.lanes[i] = lane[1:]
+		p.stats.Sent++
+		// queued before the bucket ran dry
+		if !m.queued.After(p.starvedAt) {
+			delay := now.Sub(m.queued)
+			p.stats.Throttled++
+			p.stats.TotalDelay += delay
+			p.stats.MaxDelay = max(p.stats.MaxDelay, delay)
+		}
+		return m.req, 0
+	}
+	return nil, 0
+}
+
+func (p *pacer) snapshot() PacerStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	for i, lane := range p.lanes {
+		stats.Queued[i] = len(lane)
+	}
+	return stats
+}
+
+// outMsgID extracts the message id of an encoded request.
+func outMsgID(req []byte, serverVersion Version) OUT {
+	if len(req) < 4+RAW_INT_LEN {
+		return -1
+	}
+	payload := req[4:]
+	var msgID int64
+	if serverVersion >= MIN_SERVER_VER_PROTOBUF {
+		msgID = int64(binary.BigEndian.Uint32(payload[:RAW_INT_LEN]))
+	} else {
+		field, _, _ := bytes.Cut(payload, []byte{delim})
+		var err error
+		if msgID, err = strconv.ParseInt(string(field), 10, 64); err != nil {
+			return -1
+		}
+	}
+	if msgID >= PROTOBUF_MSG_ID {
+		msgID -= PROTOBUF_MSG_ID
+	}
+	return msgID
+}
+
+// pacedRequest is the requester loop when the pacer is on.
+// It moves the incoming requests to their lane and sends them as tokens become available.
+func (c *EClient) pacedRequest() {
+	p := c.pacer
+	p.start(time.Now())
+
+	// Timers are not drained: since Go 1.23 Stop and Reset discard any pending tick
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		var wait <-chan time.Time
+		for {
+			req, delay := p.pop(time.Now())
+			if req != nil {
+				if !c.send(req) {
+					return
+				}
+				continue
+			}
+			if delay > 0 {
+				timer.Reset(delay)
+				wait = timer.C
+			}
+			break
+		}
+
+		// Full lanes leave the requests in reqChan: enqueue blocks the callers until there is room
+		var incoming <-chan []byte
+		if !p.full() {
+			incoming = c.reqChan
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case req := <-incoming:
+			p.push(req, outMsgID(req, c.ServerVersion()), time.Now())
+			timer.Stop()
+		case <-wait:
+		}
+	}
+}
+
+// SetPacer turns on the outgoing message pacer with the given config, nil turns it off.
+// It must be called before Connect.
+// The pacer runs inside the requester goroutine: messages wait in priority lanes and leave at the configured rate,
+// so that bursts of data requests do not trip the TWS limit of 50 messages per second and cancellations are sent first.
+func (c *EClient) SetPacer(config *PacerConfig) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	if config == nil {
+		c.pacer = nil
+		return
+	}
+	c.pacer = newPacer(config)
+}
+
+// PacerStats returns the queue depth and throttling metrics of the pacer, zero values when the pacer is off.
+func (c *EClient) PacerStats() PacerStats {
+	if c.pacer == nil {
+		return PacerStats{}
+	}
+	return c.pacer.snapshot()
+}
@@ -0,0 +1,138 @@
+package ibapi
+
+import (
+	"testing"
+	"time"
+)
+
+func encodeTestMsg(c *EClient, msgID OUT, fields ...any) []byte {
+	me := NewMsgEncoder(len(fields)+1, c)
+	me.encodeMsgID(msgID).encodeFields(fields...)
+	return me.Bytes()
+}
+
+func TestOutMsgID(t *testing.T) {
+	c := NewEClient(nil)
+	for _, version := range []Version{MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS, MIN_SERVER_VER_PROTOBUF} {
+		c.serverVersion = version
+		if id := outMsgID(encodeTestMsg(c, REQ_MKT_DATA, 11, 1), version); id != REQ_MKT_DATA {
+			t.Errorf("server version %d: expected %d, got %d", version, REQ_MKT_DATA, id)
+		}
+		if id := outMsgID(encodeTestMsg(c, CANCEL_ORDER+PROTOBUF_MSG_ID), version); id != CANCEL_ORDER {
+			t.Errorf("server version %d: expected %d, got %d", version, CANCEL_ORDER, id)
+		}
+	}
+}
+
+func TestPacerPriorityAndRate(t *testing.T) {
+	p := newPacer(&PacerConfig{Rate: 10, Burst: 2})
+	now := time.Now()
+	p.start(now)
+
+	p.push([]byte("mkt1"), REQ_MKT_DATA, now)
+	p.push([]byte("mkt2"), REQ_MKT_DATA, now)
+	p.push([]byte("mkt3"), REQ_MKT_DATA, now)
+	p.push([]byte("time"), REQ_CURRENT_TIME, now)
+	p.push([]byte("cancel"), CANCEL_ORDER, now)
+
+	stats := p.snapshot()
+	if stats.Queued[PriorityBulk] != 3 || stats.Queued[PriorityNormal] != 1 || stats.Queued[PriorityUrgent] != 1 || stats.QueueDepth() != 5 {
+		t.Fatalf("unexpected queue depth: %+v", stats)
+	}
+
+	// The burst goes to the most urgent lanes first
+	for _, want := range []string{"cancel", "time"} {
+		req, _ := p.pop(now)
+		if string(req) != want {
+			t.Fatalf("expected %s, got %s", want, req)
+		}
+	}
+
+	// Bucket is empty, next token in 1/Rate
+	req, wait := p.pop(now)
+	if req != nil || wait != 100*time.Millisecond {
+		t.Fatalf("expected to wait 100ms, got %s and %v", req, wait)
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	if req, _ := p.pop(now); string(req) != "mkt1" {
+		t.Fatalf("expected mkt1, got %s", req)
+	}
+
+	stats = p.snapshot()
+	if stats.Sent != 3 || stats.Throttled != 1 || stats.MaxDelay != 100*time.Millisecond {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// The previous session queue is dropped
+	p.start(now)
+	if req, wait := p.pop(now); req != nil || wait != 0 {
+		t.Errorf("expected an empty pacer, got %s and %v", req, wait)
+	}
+}
+
+func TestPacerCancelAfterRequest(t *testing.T) {
+	p := newPacer(&PacerConfig{Rate: 10, Burst: 10})
+	now := time.Now()
+	p.start(now)
+
+	p.push([]byte("mkt"), REQ_MKT_DATA, now)
+	p.push([]byte("hist"), REQ_HISTORICAL_DATA, now)
+	p.push([]byte("order"), PLACE_ORDER, now)
+	p.push([]byte("cancel mkt"), CANCEL_MKT_DATA, now)
+	p.push([]byte("cancel order"), CANCEL_ORDER, now)
+
+	// Each cancellation leaves after its request, the other requests keep their lane
+	for _, want := range []string{"order", "cancel order", "mkt", "cancel mkt", "hist"} {
+		if req, _ := p.pop(now); string(req) != want {
+			t.Fatalf("expected %s, got %s", want, req)
+		}
+	}
+}
+
+func TestPacerMaxQueued(t *testing.T) {
+	p := newPacer(&PacerConfig{Rate: 10, Burst: 1, MaxQueued: 2})
+	now := time.Now()
+	p.start(now)
+
+	p.push([]byte("time1"), REQ_CURRENT_TIME, now)
+	if p.full() {
+		t.Fatal("pacer full with 1 message")
+	}
+	p.push([]byte("time2"), REQ_CURRENT_TIME, now)
+	if !p.full() {
+		t.Fatal("pacer not full with 2 messages")
+	}
+	p.pop(now)
+	if p.full() {
+		t.Error("pacer still full after a message left")
+	}
+}
+
+func TestPacedSession(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	ib := NewEClient(&sessionWrapper{})
+	ib.SetPacer(&PacerConfig{Rate: 20, Burst: 1})
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+	gw.nextSession(t)
+	gw.nextRequest(t, START_API)
+
+	start := time.Now()
+	for range 5 {
+		ib.ReqCurrentTime()
+	}
+	for range 5 {
+		gw.nextRequest(t, REQ_CURRENT_TIME)
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("5 messages at 20/s with a burst of 1 were sent in %v", elapsed)
+	}
+	if stats := ib.PacerStats(); stats.Sent != 5 || stats.Throttled == 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
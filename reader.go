@@ -32,7 +32,7 @@ func EReader(ctx context.Context, cancel context.CancelFunc, scanner *bufio.Scan
 				if !ok {
 					return
 				}
-				decoder.parseAndProcessMsg(msg) // single worker and no go here!!
+				decoder.processMsg(msg) // single worker and no go here!!
 			}
 		}
 	}()
@@ -0,0 +1,88 @@
+package ibapi
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// SessionReady holds what TWS/IBGW sends unsolicited once the API is started.
+type SessionReady struct {
+	NextValidID     int64
+	ManagedAccounts []string
+}
+
+// readiness records the first NextValidID and ManagedAccounts of a session.
+type readiness struct {
+	mu          sync.Mutex
+	ready       SessionReady
+	hasID       bool
+	hasAccounts bool
+	done        chan struct{}
+}
+
+func newReadiness() *readiness {
+	return &readiness{done: make(chan struct{})}
+}
+
+func (r *readiness) setNextValidID(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hasID {
+		return
+	}
+	r.ready.NextValidID = id
+	r.hasID = true
+	r.check()
+}
+
+func (r *readiness) setManagedAccounts(accounts []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hasAccounts {
+		return
+	}
+	r.ready.ManagedAccounts = slices.Clone(accounts)
+	r.hasAccounts = true
+	r.check()
+}
+
+// check closes done once both values are known, r.mu must be held.
+func (r *readiness) check() {
+	if r.hasID && r.hasAccounts {
+		close(r.done)
+	}
+}
+
+func (r *readiness) get() *SessionReady {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &SessionReady{
+		NextValidID:     r.ready.NextValidID,
+		ManagedAccounts: slices.Clone(r.ready.ManagedAccounts),
+	}
+}
+
+// ConnectAndWait connects like ConnectContext, then blocks until the first NextValidID and ManagedAccounts are decoded and returns them.
+// The callbacks still reach the EWrapper.
+// If ctx is done or the session ends before, the client is disconnected and the error is returned.
+func (c *EClient) ConnectAndWait(ctx context.Context, host string, port int, clientID int64) (*SessionReady, error) {
+	// The session state is swapped on failure or reconnect, keep the one being connected
+	_, ready, sessionCtx := c.session()
+	sessionDone := sessionCtx.Done()
+
+	if err := c.ConnectContext(ctx, host, port, clientID); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ready.done:
+		return ready.get(), nil
+	case <-ctx.Done():
+		c.Disconnect()
+		return nil, ctx.Err()
+	case <-sessionDone:
+		c.Disconnect()
+		return nil, NOT_CONNECTED
+	}
+}
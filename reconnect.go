@@ -0,0 +1,264 @@
+package ibapi
+
+import (
+	"context"
+	"time"
+)
+
+// ReconnectPolicy configures the session-level reconnect of an EClient.
+// When the socket to TWS/IBGW is lost, the whole session is rebuilt:
+// dial, handshake, server version, decoder, reader, requester and startAPI.
+type ReconnectPolicy struct {
+	// MaxAttempts is the number of attempts per outage. Zero or less means retry until Disconnect is called.
+	MaxAttempts int
+	// InitialDelay is the wait before the first attempt.
+	InitialDelay time.Duration
+	// MaxDelay caps the wait between two attempts.
+	MaxDelay time.Duration
+	// Multiplier grows the delay after each failed attempt.
+	Multiplier float64
+	// HandshakeTimeout bounds the dial and the handshake with a gateway that accepts the socket but never answers, e.g. stuck at its login screen.
+	HandshakeTimeout time.Duration
+}
+
+// DefaultReconnectPolicy returns a policy retrying forever with an exponential backoff from 1s to 30s.
+func DefaultReconnectPolicy() *ReconnectPolicy {
+	return &ReconnectPolicy{
+		MaxAttempts:      0,
+		InitialDelay:     1 * time.Second,
+		MaxDelay:         30 * time.Second,
+		Multiplier:       2,
+		HandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// backoff returns the delay to wait before the given attempt, starting at 1.
+func (p *ReconnectPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialDelay
+	for i := 1; i < attempt; i++ {
+		if p.Multiplier > 1 {
+			delay = time.Duration(float64(delay) * p.Multiplier)
+		}
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// SetReconnectPolicy enables the session-level reconnect with the given policy, nil disables it.
+// It must be called before Connect.
+// Progress is reported through the connection state and EWrapper: ConnectionClosed when the session is lost,
+// ConnectAck each time it is rebuilt.
+func (c *EClient) SetReconnectPolicy(policy *ReconnectPolicy) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	c.reconnectPolicy = policy
+	conn, _, _ := c.session()
+	conn.sessionManaged = policy != nil
+}
+
+// ReconnectPolicy returns the session-level reconnect policy, nil when disabled.
+func (c *EClient) ReconnectPolicy() *ReconnectPolicy {
+	return c.reconnectPolicy
+}
+
+// abortReconnect tells the supervisor that the session end was requested by Disconnect.
+func (c *EClient) abortReconnect() {
+	if c.abortReconnectFunc != nil {
+		c.abortReconnectFunc()
+	}
+}
+
+// supervise waits for the end of the session bound to ctx.
+// Without a reconnect policy the client is disconnected, otherwise the session is rebuilt.
+// With a failover policy probing the preferred endpoint, it also fails back to it once it is reachable again.
+func (c *EClient) supervise(ctx context.Context, abort context.Context, done chan<- struct{}) {
+	log.Debug().Msg("supervisor started")
+	defer log.Debug().Msg("supervisor ended")
+	defer close(done)
+
+	var probe <-chan time.Time
+	if f := c.failover; f != nil && f.ProbeInterval > 0 {
+		ticker := time.NewTicker(f.ProbeInterval)
+		defer ticker.Stop()
+		probe = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+		case <-probe:
+			conn := c.probePreferred(abort)
+			if conn == nil {
+				continue
+			}
+			if !c.compareAndSetConnState(CONNECTED, FAILING_OVER, CauseFailback, nil) {
+				conn.Close()
+				continue
+			}
+			if !c.failback(abort, conn) {
+				return
+			}
+			ctx = c.ctx
+			continue
+		}
+
+		if abort.Err() != nil {
+			// Disconnect was called
+			return
+		}
+
+		cause := c.sessionEndError()
+		if c.reconnectPolicy == nil || !c.leaveConnected(RECONNECTING, CauseSessionLost, cause) {
+			if err := c.disconnect(CauseSessionLost, cause); err != nil {
+				log.Error().Err(err).Msg("Disconnect error in supervisor")
+			}
+			return
+		}
+
+		if !c.reconnect(abort, RECONNECTING) {
+			return
+		}
+		ctx = c.ctx
+	}
+}
+
+// stopSession ends the current session: it stops the requester and the reader, closes the socket and drops unsent requests.
+func (c *EClient) stopSession() {
+	c.cancel()
+	if err := c.conn.disconnect(); err != nil {
+		log.Debug().Err(err).Msg("error closing session socket")
+	}
+	c.wg.Wait()
+	c.dropRequests()
+}
+
+// teardownSession drops what the session leaves behind when the client moves to the state to, out of CONNECTED.
+func (c *EClient) teardownSession(to ConnState) {
+	if to != DISCONNECTED && to != RECONNECTING && to != FAILING_OVER {
+		return
+	}
+	// The answers to the blocking calls of the lost session will never come
+	c.calls.failAll(NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, ""))
+	c.orderIDs.unseed()
+	// Streams outlive the session only when the subscription registry replays them on the next one
+	if to == DISCONNECTED || c.subscriptions == nil {
+		c.streams.failAll(NewIBError(NO_VALID_ID, currentTimeMillis(), NOT_CONNECTED.Code, NOT_CONNECTED.Msg, ""))
+	} else {
+		c.streams.resetOrderBooks()
+	}
+}
+
+// reconnect tears down the current session and builds a new one following the reconnect policy,
+// trying the failover endpoints in turn if any. It returns false if the client ended up disconnected.
+func (c *EClient) reconnect(abort context.Context, state ConnState) bool {
+	from := c.Endpoint()
+	log.Warn().Stringer("endpoint", from).Msg("session lost, rebuilding it")
+
+	c.stopSession()
+	c.wrapper.ConnectionClosed()
+
+	return c.rebuild(abort, state, from)
+}
+
+// failback moves the session from the standby endpoint to the preferred one, on conn that already passed the handshake.
+// The standby session is only torn down then. If the session does not start on conn,
+// the endpoints are tried in turn as after a session loss. It returns false if the client ended up disconnected.
+func (c *EClient) failback(abort context.Context, conn *probedConn) bool {
+	from := c.Endpoint()
+	preferred := c.failover.Endpoints[0]
+	log.Info().Stringer("endpoint", preferred).Msg("preferred endpoint is back, failing back to it")
+
+	c.stopSession()
+	c.wrapper.ConnectionClosed()
+
+	c.initSession()
+	c.setEndpoint(preferred, 0)
+	c.conn.attach(conn.Conn)
+	c.setServerInfo(conn.serverVersion, conn.connTime)
+	if err := c.startServices(); err != nil {
+		log.Warn().Err(err).Stringer("endpoint", preferred).Msg("failback failed")
+		c.stopSession()
+		return c.rebuild(abort, FAILING_OVER, from)
+	}
+	return c.sessionRebuilt(FAILING_OVER, from, preferred)
+}
+
+// rebuild builds a new session after the one on from was torn down, following the reconnect policy.
+// state is RECONNECTING after a session loss, FAILING_OVER on failback. It returns false if the client ended up disconnected.
+func (c *EClient) rebuild(abort context.Context, state ConnState, from Endpoint) bool {
+	endpoints := c.failover.candidates(from)
+	p := c.reconnectPolicy
+	if p == nil {
+		// Failback without reconnect policy: a single round over the endpoints
+		p = &ReconnectPolicy{MaxAttempts: 1}
+	}
+	for attempt := 1; p.MaxAttempts <= 0 || attempt <= p.MaxAttempts; attempt++ {
+		delay := p.backoff(attempt)
+		if state == FAILING_OVER && attempt == 1 {
+			delay = 0
+		}
+		log.Info().Int("attempt", attempt).Int("maxAttempts", p.MaxAttempts).Dur("delay", delay).Msg("Attempting to rebuild session")
+
+		select {
+		case <-abort.Done():
+			c.reset()
+			return false
+		case <-time.After(delay):
+		}
+
+		for _, ep := range endpoints {
+			if err := c.rebuildSession(abort, ep); err != nil {
+				log.Warn().Err(err).Int("attempt", attempt).Stringer("endpoint", ep).Msg("session rebuild failed")
+				c.stopSession()
+				continue
+			}
+			return c.sessionRebuilt(state, from, ep)
+		}
+	}
+
+	log.Error().Int("maxAttempts", p.MaxAttempts).Msg("failed to rebuild session, giving up")
+	c.compareAndSetConnState(state, DISCONNECTED, CauseReconnectFailed, nil)
+	c.reset()
+	return false
+}
+
+// sessionRebuilt marks the session rebuilt on ep as connected, replays the subscriptions and reports the failover from the previous endpoint.
+// It returns false if Disconnect was called while the session was being rebuilt.
+func (c *EClient) sessionRebuilt(state ConnState, from, ep Endpoint) bool {
+	if !c.compareAndSetConnState(state, CONNECTED, CauseSessionStarted, nil) {
+		c.stopSession()
+		c.reset()
+		return false
+	}
+
+	log.Info().Stringer("endpoint", ep).Int("serverVersion", c.ServerVersion()).Msg("Session rebuilt")
+	c.wrapper.ConnectAck()
+	c.subscriptions.replay(c)
+	if ep != from {
+		c.failover.notify(from, ep)
+	}
+	return true
+}
+
+// rebuildSession dials and handshakes a new session with ep, the handshake is bounded by the policy HandshakeTimeout.
+func (c *EClient) rebuildSession(abort context.Context, ep Endpoint) error {
+	c.initSession()
+	c.setEndpoint(ep, c.failover.index(ep))
+
+	ctx, cancel := abort, context.CancelFunc(func() {})
+	if c.reconnectPolicy != nil && c.reconnectPolicy.HandshakeTimeout > 0 {
+		ctx, cancel = context.WithTimeout(abort, c.reconnectPolicy.HandshakeTimeout)
+	}
+	defer cancel()
+
+	if err := c.conn.connectContext(ctx, ep.Host, ep.Port); err != nil {
+		return err
+	}
+	return c.startSessionContext(ctx)
+}
@@ -0,0 +1,294 @@
+package ibapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeGatewayVersion is below MIN_SERVER_VER_PROTOBUF so that message ids travel as text fields.
+const fakeGatewayVersion = MIN_SERVER_VER_PARAMETRIZED_DAYS_OF_EXECUTIONS
+
+// fakeGateway is a minimal TWS/IBGW speaking the handshake and recording the requests it receives.
+type fakeGateway struct {
+	listener net.Listener
+	sessions chan net.Conn
+	frames   chan [][]byte
+	refuse   atomic.Bool // closes new connections right away, like a gateway being down
+}
+
+func newFakeGateway(t *testing.T) *fakeGateway {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create fake gateway: %v", err)
+	}
+	g := newFakeGatewayOn(listener)
+	go g.serve()
+	t.Cleanup(func() { g.listener.Close() })
+	return g
+}
+
+// newFakeGatewayOn returns a gateway for the given listener, nil when connections are handed to handle directly.
+func newFakeGatewayOn(listener net.Listener) *fakeGateway {
+	return &fakeGateway{
+		listener: listener,
+		sessions: make(chan net.Conn, 10),
+		frames:   make(chan [][]byte, 1000),
+	}
+}
+
+func (g *fakeGateway) address() (string, int) {
+	addr := g.listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func (g *fakeGateway) serve() {
+	for {
+		conn, err := g.listener.Accept()
+		if err != nil {
+			return
+		}
+		go g.handle(conn)
+	}
+}
+
+func (g *fakeGateway) handle(conn net.Conn) {
+	if g.refuse.Load() {
+		conn.Close()
+		return
+	}
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(conn, head); err != nil || !bytes.Equal(head, []byte("API\x00")) {
+		conn.Close()
+		return
+	}
+	if _, err := readFrame(conn); err != nil {
+		conn.Close()
+		return
+	}
+	writeFrame(conn, fakeGatewayVersion, "20261017 10:00:00 UTC")
+	g.sessions <- conn
+	for {
+		payload, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+		g.frames <- splitMsgBytes(payload)
+	}
+}
+
+// nextSession waits for the next handshaked connection.
+func (g *fakeGateway) nextSession(t *testing.T) net.Conn {
+	t.Helper()
+	select {
+	case conn := <-g.sessions:
+		return conn
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for a session")
+		return nil
+	}
+}
+
+// nextRequest waits for the next request with the given message id.
+func (g *fakeGateway) nextRequest(t *testing.T, msgID OUT) [][]byte {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case fields := <-g.frames:
+			if id, _ := strconv.ParseInt(string(fields[0]), 10, 64); id == msgID {
+				return fields
+			}
+		case <-timeout:
+			t.Fatalf("timeout waiting for request %d", msgID)
+			return nil
+		}
+	}
+}
+
+// nextFrame returns the next request received by the gateway, whatever it is.
+func (g *fakeGateway) nextFrame(t *testing.T) [][]byte {
+	t.Helper()
+	select {
+	case fields := <-g.frames:
+		return fields
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for a request")
+		return nil
+	}
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(r, size); err != nil {
+		return nil, err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(size))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func writeFrame(w io.Writer, fields ...any) error {
+	var payload bytes.Buffer
+	for _, f := range fields {
+		payload.WriteString(fmt.Sprint(f))
+		payload.WriteByte(delim)
+	}
+	frame := make([]byte, 4, 4+payload.Len())
+	binary.BigEndian.PutUint32(frame, uint32(payload.Len()))
+	_, err := w.Write(append(frame, payload.Bytes()...))
+	return err
+}
+
+type sessionWrapper struct {
+	Wrapper
+	acks   atomic.Int32
+	closed atomic.Int32
+}
+
+func (w *sessionWrapper) ConnectAck()       { w.acks.Add(1) }
+func (w *sessionWrapper) ConnectionClosed() { w.closed.Add(1) }
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReconnectPolicyBackoff(t *testing.T) {
+	p := &ReconnectPolicy{InitialDelay: time.Second, MaxDelay: 5 * time.Second, Multiplier: 2}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := p.backoff(i + 1); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestSessionReconnect(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	w := &sessionWrapper{}
+	ib := NewEClient(w)
+	ib.SetReconnectPolicy(&ReconnectPolicy{InitialDelay: 10 * time.Millisecond, HandshakeTimeout: time.Second})
+
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	first := gw.nextSession(t)
+	gw.nextRequest(t, START_API)
+
+	// Gateway restart
+	first.Close()
+
+	second := gw.nextSession(t)
+	defer second.Close()
+	gw.nextRequest(t, START_API)
+
+	waitFor(t, "session rebuilt", func() bool { return w.acks.Load() == 2 && ib.IsConnected() })
+	if w.closed.Load() != 1 {
+		t.Errorf("expected 1 ConnectionClosed, got %d", w.closed.Load())
+	}
+
+	// The rebuilt session must carry requests
+	ib.ReqCurrentTime()
+	gw.nextRequest(t, REQ_CURRENT_TIME)
+
+	if err := ib.Disconnect(); err != nil {
+		t.Fatalf("disconnect: %v", err)
+	}
+	if ib.IsConnected() {
+		t.Error("client still connected after Disconnect")
+	}
+}
+
+func TestRequestsDuringReconnect(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	w := &sessionWrapper{}
+	ib := NewEClient(w)
+	ib.SetReconnectPolicy(&ReconnectPolicy{InitialDelay: 10 * time.Millisecond, HandshakeTimeout: time.Second})
+
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	session := gw.nextSession(t)
+	gw.nextRequest(t, START_API)
+
+	// The session state is swapped under the requests of the user, run with -race
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(time.Millisecond):
+				ib.ReqCurrentTimeContext(context.Background())
+				ib.Endpoint()
+			}
+		}
+	})
+
+	for i := range 3 {
+		session.Close()
+		session = gw.nextSession(t)
+		gw.nextRequest(t, START_API)
+		waitFor(t, "session rebuilt", func() bool { return w.acks.Load() == int32(i+2) && ib.IsConnected() })
+	}
+	close(stop)
+	wg.Wait()
+	session.Close()
+
+	if err := ib.Disconnect(); err != nil {
+		t.Fatalf("disconnect: %v", err)
+	}
+}
+
+func TestDisconnectAbortsReconnect(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	w := &sessionWrapper{}
+	ib := NewEClient(w)
+	ib.SetReconnectPolicy(&ReconnectPolicy{InitialDelay: time.Hour})
+
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	gw.nextSession(t).Close()
+
+	waitFor(t, "reconnecting state", func() bool { return ConnState(atomic.LoadInt32(&ib.connState)) == RECONNECTING })
+
+	done := make(chan error)
+	go func() { done <- ib.Disconnect() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("disconnect: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Disconnect did not abort the reconnect")
+	}
+	if ConnState(atomic.LoadInt32(&ib.connState)) != DISCONNECTED {
+		t.Error("expected disconnected state")
+	}
+}
@@ -0,0 +1,95 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/scmhub/ibapi/protobuf"
+)
+
+type errorRecorder struct {
+	Wrapper
+	mu    sync.Mutex
+	codes []int64
+}
+
+func (w *errorRecorder) Error(reqID int64, errTime int64, errCode int64, errString string, advancedOrderRejectJson string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.codes = append(w.codes, errCode)
+}
+
+func (w *errorRecorder) recorded() []int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]int64(nil), w.codes...)
+}
+
+func TestRequestContextNotConnected(t *testing.T) {
+	w := &errorRecorder{}
+	ib := NewEClient(w)
+
+	err := ib.ReqMktDataContext(context.Background(), 7, &Contract{Symbol: "IBM"}, "", false, false, nil)
+	if !errors.Is(err, NOT_CONNECTED) {
+		t.Fatalf("expected NOT_CONNECTED, got %v", err)
+	}
+	var ibErr *IBError
+	if !errors.As(err, &ibErr) || ibErr.ReqID != 7 {
+		t.Errorf("expected an IBError for request 7, got %#v", err)
+	}
+	if codes := w.recorded(); len(codes) != 0 {
+		t.Errorf("the error was also reported to EWrapper.Error: %v", codes)
+	}
+
+	// The plain variant still reports through EWrapper.Error
+	ib.ReqMktData(7, &Contract{Symbol: "IBM"}, "", false, false, nil)
+	if codes := w.recorded(); len(codes) != 1 || codes[0] != NOT_CONNECTED.Code {
+		t.Errorf("expected NOT_CONNECTED to be reported, got %v", codes)
+	}
+}
+
+func TestRequestContextSession(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	w := &errorRecorder{}
+	ib := NewEClient(w)
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+	defer gw.nextSession(t).Close()
+
+	if err := ib.ReqCurrentTimeContext(context.Background()); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	gw.nextRequest(t, REQ_CURRENT_TIME)
+
+	// The fake gateway is too old for config requests
+	err := ib.ReqConfigProtoBufContext(context.Background(), &protobuf.ConfigRequest{})
+	if !errors.Is(err, UPDATE_TWS) {
+		t.Errorf("expected UPDATE_TWS, got %v", err)
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	ib := NewEClient(nil)
+	ib.reqChan = make(chan []byte) // nobody sends, like a blocked requester
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	me := NewMsgEncoder(2, ib)
+	me.encodeMsgID(REQ_CURRENT_TIME).encodeInt(1)
+	if err := ib.enqueue(ctx, me); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	me = NewMsgEncoder(2, ib)
+	me.encodeString(strings.Repeat("x", MAX_MSG_LEN))
+	if err := ib.enqueue(context.Background(), me); !errors.Is(err, BAD_LENGTH) {
+		t.Errorf("expected BAD_LENGTH, got %v", err)
+	}
+}
@@ -0,0 +1,955 @@
+package ibapi
+
+import (
+	"sync"
+
+	"github.com/scmhub/ibapi/protobuf"
+)
+
+// Router is an EWrapper dispatching each callback to the handler registered for its reqID, its orderID or its message kind.
+// Callbacks without handler fall through to the default EWrapper.
+// Handlers embed Wrapper, or any EWrapper, and override the callbacks they care about.
+//
+//	router := ibapi.NewRouter(ibapi.Wrapper{})
+//	ib := ibapi.NewEClient(router)
+//	route := router.Handle(reqID, &myHandler{})
+//	ib.ReqContractDetails(reqID, contract)
+//	<-route.Done()
+type Router struct {
+	fallback EWrapper
+
+	mu     sync.RWMutex
+	reqs   map[int64]*Route
+	orders map[int64]*Route
+	kinds  map[IN]*Route
+	execs  map[string]EWrapper // handler of the executions of the routed orders, to route their commission reports after the order is filled
+}
+
+// NewRouter returns a Router falling through to fallback, Wrapper if nil.
+func NewRouter(fallback EWrapper) *Router {
+	if fallback == nil {
+		fallback = &Wrapper{}
+	}
+	return &Router{
+		fallback: fallback,
+		reqs:     make(map[int64]*Route),
+		orders:   make(map[int64]*Route),
+		kinds:    make(map[IN]*Route),
+		execs:    make(map[string]EWrapper),
+	}
+}
+
+var _ EWrapper = (*Router)(nil)
+
+// Route is the registration of a handler.
+type Route struct {
+	handler EWrapper
+	remove  func()
+	stream  bool
+	done    chan struct{}
+	err     error
+	once    sync.Once
+}
+
+// Remove unregisters the handler.
+func (rt *Route) Remove() {
+	rt.close(nil)
+}
+
+// Done is closed once the handler is unregistered.
+func (rt *Route) Done() <-chan struct{} {
+	return rt.done
+}
+
+// Err returns the request-fatal error that unregistered the handler, nil otherwise.
+// It must be called after Done is closed.
+func (rt *Route) Err() error {
+	return rt.err
+}
+
+func (rt *Route) close(err error) {
+	rt.once.Do(func() {
+		rt.remove()
+		rt.err = err
+		close(rt.done)
+	})
+}
+
+// register adds a route to table, replacing the one registered under the same key.
+func register[K comparable](r *Router, table map[K]*Route, key K, handler EWrapper, stream bool) *Route {
+	rt := &Route{handler: handler, stream: stream, done: make(chan struct{})}
+	rt.remove = func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if table[key] == rt {
+			delete(table, key)
+		}
+	}
+	r.mu.Lock()
+	old := table[key]
+	table[key] = rt
+	r.mu.Unlock()
+	if old != nil {
+		old.close(nil)
+	}
+	return rt
+}
+
+// Handle routes the callbacks of the request reqID to handler.
+// The route is removed by the terminal message of the request, like ContractDetailsEnd, or a request-fatal error.
+func (r *Router) Handle(reqID int64, handler EWrapper) *Route {
+	return register(r, r.reqs, reqID, handler, false)
+}
+
+// HandleStream routes the callbacks of the subscription reqID to handler.
+// Unlike Handle, the route outlives the ...End messages, it is removed by a request-fatal error.
+// Use it for keepUpToDate historical data, account summaries or scanner subscriptions.
+func (r *Router) HandleStream(reqID int64, handler EWrapper) *Route {
+	return register(r, r.reqs, reqID, handler, true)
+}
+
+// HandleOrder routes the callbacks of the order orderID to handler: open orders, order statuses, executions and their commission reports.
+// The route is removed once the order is done: filled, cancelled or inactive, or rejected by the errors 201 or 202.
+// The other errors of the order, even request-fatal ones like a refused modification, leave it in place.
+func (r *Router) HandleOrder(orderID int64, handler EWrapper) *Route {
+	return register(r, r.orders, orderID, handler, true)
+}
+
+// HandleKind routes the callbacks of the message kind to handler, unless routed by reqID or orderID.
+// The route stays until removed.
+func (r *Router) HandleKind(kind IN, handler EWrapper) *Route {
+	return register(r, r.kinds, kind, handler, true)
+}
+
+func (r *Router) byReq(reqID int64, kind IN) EWrapper {
+	r.mu.RLock()
+	rt := r.reqs[reqID]
+	r.mu.RUnlock()
+	if rt != nil {
+		return rt.handler
+	}
+	return r.byKind(kind)
+}
+
+func (r *Router) byOrder(orderID int64, kind IN) EWrapper {
+	r.mu.RLock()
+	rt := r.orders[orderID]
+	r.mu.RUnlock()
+	if rt != nil {
+		return rt.handler
+	}
+	return r.byKind(kind)
+}
+
+func (r *Router) byKind(kind IN) EWrapper {
+	r.mu.RLock()
+	rt := r.kinds[kind]
+	r.mu.RUnlock()
+	if rt != nil {
+		return rt.handler
+	}
+	return r.fallback
+}
+
+// end removes the route of reqID after its terminal message.
+func (r *Router) end(reqID int64) {
+	r.mu.RLock()
+	rt := r.reqs[reqID]
+	r.mu.RUnlock()
+	if rt != nil && !rt.stream {
+		rt.close(nil)
+	}
+}
+
+// routeOf returns the route an error with the given id belongs to, requests first, and whether it is an order route.
+func (r *Router) routeOf(id int64) (*Route, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if rt := r.reqs[id]; rt != nil {
+		return rt, false
+	}
+	rt := r.orders[id]
+	return rt, rt != nil
+}
+
+func optionalID(id *int32) int64 {
+	if id == nil {
+		return NO_VALID_ID
+	}
+	return int64(*id)
+}
+
+func (r *Router) Error(reqID int64, errTime int64, errCode int64, errString string, advancedOrderRejectJson string) {
+	rt, order := r.routeOf(reqID)
+	if rt == nil {
+		r.byKind(ERR_MSG).Error(reqID, errTime, errCode, errString, advancedOrderRejectJson)
+		return
+	}
+	rt.handler.Error(reqID, errTime, errCode, errString, advancedOrderRejectJson)
+	err := NewIBError(reqID, errTime, errCode, errString, advancedOrderRejectJson)
+	switch {
+	case order && errCode == ErrOrderCancelled.Code:
+		rt.close(nil)
+	case order && errCode == ErrOrderRejected.Code, !order && err.Severity >= SeverityRequestFatal:
+		rt.close(err)
+	}
+}
+
+func (r *Router) ErrorProtoBuf(errorProto *protobuf.ErrorMessage) {
+	if rt, _ := r.routeOf(optionalID(errorProto.Id)); rt != nil {
+		rt.handler.ErrorProtoBuf(errorProto)
+		return
+	}
+	r.byKind(ERR_MSG).ErrorProtoBuf(errorProto)
+}
+
+func (r *Router) OrderStatus(orderID int64, status string, filled Decimal, remaining Decimal, avgFillPrice float64, permID int64, parentID int64, lastFillPrice float64, clientID int64, whyHeld string, mktCapPrice float64) {
+	r.byOrder(orderID, ORDER_STATUS).OrderStatus(orderID, status, filled, remaining, avgFillPrice, permID, parentID, lastFillPrice, clientID, whyHeld, mktCapPrice)
+	if OrderStatusFromString(status).IsTerminal() {
+		r.mu.RLock()
+		rt := r.orders[orderID]
+		r.mu.RUnlock()
+		if rt != nil {
+			rt.close(nil)
+		}
+	}
+}
+
+// execHandler returns the handler of an execution, routed by the reqID of ReqExecutions first, then by its order.
+func (r *Router) execHandler(reqID int64, orderID int64, execID string) EWrapper {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rt := r.reqs[reqID]; rt != nil {
+		return rt.handler
+	}
+	if rt := r.orders[orderID]; rt != nil {
+		r.execs[execID] = rt.handler
+		return rt.handler
+	}
+	if rt := r.kinds[EXECUTION_DATA]; rt != nil {
+		return rt.handler
+	}
+	return r.fallback
+}
+
+func (r *Router) ExecDetails(reqID int64, contract *Contract, execution *Execution) {
+	r.execHandler(reqID, execution.OrderID, execution.ExecID).ExecDetails(reqID, contract, execution)
+}
+
+func (r *Router) ExecDetailsProtoBuf(executionDetailsProto *protobuf.ExecutionDetails) {
+	execution := executionDetailsProto.GetExecution()
+	r.execHandler(optionalID(executionDetailsProto.ReqId), optionalID(execution.OrderId), execution.GetExecId()).ExecDetailsProtoBuf(executionDetailsProto)
+}
+
+// commissionHandler returns the handler of the commission report of an execution.
+func (r *Router) commissionHandler(execID string, last bool) EWrapper {
+	r.mu.Lock()
+	handler, ok := r.execs[execID]
+	if ok && last {
+		delete(r.execs, execID)
+	}
+	r.mu.Unlock()
+	if ok {
+		return handler
+	}
+	return r.byKind(COMMISSION_AND_FEES_REPORT)
+}
+
+func (r *Router) CommissionAndFeesReport(commissionAndFeesReport CommissionAndFeesReport) {
+	r.commissionHandler(commissionAndFeesReport.ExecID, true).CommissionAndFeesReport(commissionAndFeesReport)
+}
+
+func (r *Router) CommissionAndFeesReportProtoBuf(commissionAndFeesReportProto *protobuf.CommissionAndFeesReport) {
+	r.commissionHandler(commissionAndFeesReportProto.GetExecId(), false).CommissionAndFeesReportProtoBuf(commissionAndFeesReportProto)
+}
+
+func (r *Router) CompletedOrder(contract *Contract, order *Order, orderState *OrderState) {
+	r.byOrder(order.OrderID, COMPLETED_ORDER).CompletedOrder(contract, order, orderState)
+}
+
+func (r *Router) TickPrice(reqID int64, tickType TickType, price float64, attrib TickAttrib) {
+	r.byReq(reqID, TICK_PRICE).TickPrice(reqID, tickType, price, attrib)
+}
+
+func (r *Router) TickSize(reqID int64, tickType TickType, size Decimal) {
+	r.byReq(reqID, TICK_SIZE).TickSize(reqID, tickType, size)
+}
+
+func (r *Router) TickOptionComputation(reqID int64, tickType TickType, tickAttrib int64, impliedVol float64, delta float64, optPrice float64, pvDividend float64, gamma float64, vega float64, theta float64, undPrice float64) {
+	r.byReq(reqID, TICK_OPTION_COMPUTATION).TickOptionComputation(reqID, tickType, tickAttrib, impliedVol, delta, optPrice, pvDividend, gamma, vega, theta, undPrice)
+}
+
+func (r *Router) TickGeneric(reqID int64, tickType TickType, value float64) {
+	r.byReq(reqID, TICK_GENERIC).TickGeneric(reqID, tickType, value)
+}
+
+func (r *Router) TickString(reqID int64, tickType TickType, value string) {
+	r.byReq(reqID, TICK_STRING).TickString(reqID, tickType, value)
+}
+
+func (r *Router) TickEFP(reqID int64, tickType TickType, basisPoints float64, formattedBasisPoints string, totalDividends float64, holdDays int64, futureLastTradeDate string, dividendImpact float64, dividendsToLastTradeDate float64) {
+	r.byReq(reqID, TICK_EFP).TickEFP(reqID, tickType, basisPoints, formattedBasisPoints, totalDividends, holdDays, futureLastTradeDate, dividendImpact, dividendsToLastTradeDate)
+}
+
+func (r *Router) OpenOrder(orderID int64, contract *Contract, order *Order, orderState *OrderState) {
+	r.byOrder(orderID, OPEN_ORDER).OpenOrder(orderID, contract, order, orderState)
+}
+
+func (r *Router) OpenOrderEnd() {
+	r.byKind(OPEN_ORDER_END).OpenOrderEnd()
+}
+
+func (r *Router) WinError(text string, lastError int64) {
+	r.fallback.WinError(text, lastError)
+}
+
+func (r *Router) ConnectionClosed() {
+	// The commission reports of the lost session will not come
+	r.mu.Lock()
+	clear(r.execs)
+	r.mu.Unlock()
+	r.fallback.ConnectionClosed()
+}
+
+func (r *Router) UpdateAccountValue(tag string, val string, currency string, accountName string) {
+	r.byKind(ACCT_VALUE).UpdateAccountValue(tag, val, currency, accountName)
+}
+
+func (r *Router) UpdatePortfolio(contract *Contract, position Decimal, marketPrice float64, marketValue float64, averageCost float64, unrealizedPNL float64, realizedPNL float64, accountName string) {
+	r.byKind(PORTFOLIO_VALUE).UpdatePortfolio(contract, position, marketPrice, marketValue, averageCost, unrealizedPNL, realizedPNL, accountName)
+}
+
+func (r *Router) UpdateAccountTime(timeStamp string) {
+	r.byKind(ACCT_UPDATE_TIME).UpdateAccountTime(timeStamp)
+}
+
+func (r *Router) AccountDownloadEnd(accountName string) {
+	r.byKind(ACCT_DOWNLOAD_END).AccountDownloadEnd(accountName)
+}
+
+func (r *Router) NextValidID(reqID int64) {
+	r.byKind(NEXT_VALID_ID).NextValidID(reqID)
+}
+
+func (r *Router) ContractDetails(reqID int64, contractDetails *ContractDetails) {
+	r.byReq(reqID, CONTRACT_DATA).ContractDetails(reqID, contractDetails)
+}
+
+func (r *Router) BondContractDetails(reqID int64, contractDetails *ContractDetails) {
+	r.byReq(reqID, BOND_CONTRACT_DATA).BondContractDetails(reqID, contractDetails)
+}
+
+func (r *Router) ContractDetailsEnd(reqID int64) {
+	r.byReq(reqID, CONTRACT_DATA_END).ContractDetailsEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) ExecDetailsEnd(reqID int64) {
+	r.byReq(reqID, EXECUTION_DATA_END).ExecDetailsEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) UpdateMktDepth(reqID int64, position int64, operation int64, side int64, price float64, size Decimal) {
+	r.byReq(reqID, MARKET_DEPTH).UpdateMktDepth(reqID, position, operation, side, price, size)
+}
+
+func (r *Router) UpdateMktDepthL2(reqID int64, position int64, marketMaker string, operation int64, side int64, price float64, size Decimal, isSmartDepth bool) {
+	r.byReq(reqID, MARKET_DEPTH_L2).UpdateMktDepthL2(reqID, position, marketMaker, operation, side, price, size, isSmartDepth)
+}
+
+func (r *Router) UpdateNewsBulletin(msgID int64, msgType int64, newsMessage string, originExch string) {
+	r.byKind(NEWS_BULLETINS).UpdateNewsBulletin(msgID, msgType, newsMessage, originExch)
+}
+
+func (r *Router) ManagedAccounts(accountsList []string) {
+	r.byKind(MANAGED_ACCTS).ManagedAccounts(accountsList)
+}
+
+func (r *Router) ReceiveFA(faDataType FaDataType, cxml string) {
+	r.byKind(RECEIVE_FA).ReceiveFA(faDataType, cxml)
+}
+
+func (r *Router) HistoricalData(reqID int64, bar *Bar) {
+	r.byReq(reqID, HISTORICAL_DATA).HistoricalData(reqID, bar)
+}
+
+func (r *Router) HistoricalDataEnd(reqID int64, startDateStr string, endDateStr string) {
+	r.byReq(reqID, HISTORICAL_DATA_END).HistoricalDataEnd(reqID, startDateStr, endDateStr)
+	r.end(reqID)
+}
+
+func (r *Router) ScannerParameters(xml string) {
+	r.byKind(SCANNER_PARAMETERS).ScannerParameters(xml)
+}
+
+func (r *Router) ScannerData(reqID int64, rank int64, contractDetails *ContractDetails, distance string, benchmark string, projection string, legsStr string) {
+	r.byReq(reqID, SCANNER_DATA).ScannerData(reqID, rank, contractDetails, distance, benchmark, projection, legsStr)
+}
+
+func (r *Router) ScannerDataEnd(reqID int64) {
+	r.byReq(reqID, SCANNER_DATA).ScannerDataEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) RealtimeBar(reqID int64, time int64, open float64, high float64, low float64, close float64, volume Decimal, wap Decimal, count int64) {
+	r.byReq(reqID, REAL_TIME_BARS).RealtimeBar(reqID, time, open, high, low, close, volume, wap, count)
+}
+
+func (r *Router) CurrentTime(t int64) {
+	r.byKind(CURRENT_TIME).CurrentTime(t)
+}
+
+func (r *Router) DeltaNeutralValidation(reqID int64, deltaNeutralContract DeltaNeutralContract) {
+	r.byReq(reqID, DELTA_NEUTRAL_VALIDATION).DeltaNeutralValidation(reqID, deltaNeutralContract)
+}
+
+func (r *Router) TickSnapshotEnd(reqID int64) {
+	r.byReq(reqID, TICK_SNAPSHOT_END).TickSnapshotEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) MarketDataType(reqID int64, marketDataType int64) {
+	r.byReq(reqID, MARKET_DATA_TYPE).MarketDataType(reqID, marketDataType)
+}
+
+func (r *Router) Position(account string, contract *Contract, position Decimal, avgCost float64) {
+	r.byKind(POSITION_DATA).Position(account, contract, position, avgCost)
+}
+
+func (r *Router) PositionEnd() {
+	r.byKind(POSITION_END).PositionEnd()
+}
+
+func (r *Router) AccountSummary(reqID int64, account string, tag string, value string, currency string) {
+	r.byReq(reqID, ACCOUNT_SUMMARY).AccountSummary(reqID, account, tag, value, currency)
+}
+
+func (r *Router) AccountSummaryEnd(reqID int64) {
+	r.byReq(reqID, ACCOUNT_SUMMARY_END).AccountSummaryEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) VerifyMessageAPI(apiData string) {
+	r.byKind(VERIFY_MESSAGE_API).VerifyMessageAPI(apiData)
+}
+
+func (r *Router) VerifyCompleted(isSuccessful bool, errorText string) {
+	r.byKind(VERIFY_COMPLETED).VerifyCompleted(isSuccessful, errorText)
+}
+
+func (r *Router) DisplayGroupList(reqID int64, groups string) {
+	r.byReq(reqID, DISPLAY_GROUP_LIST).DisplayGroupList(reqID, groups)
+	r.end(reqID)
+}
+
+func (r *Router) DisplayGroupUpdated(reqID int64, contractInfo string) {
+	r.byReq(reqID, DISPLAY_GROUP_UPDATED).DisplayGroupUpdated(reqID, contractInfo)
+}
+
+func (r *Router) VerifyAndAuthMessageAPI(apiData string, xyzChallange string) {
+	r.byKind(VERIFY_AND_AUTH_MESSAGE_API).VerifyAndAuthMessageAPI(apiData, xyzChallange)
+}
+
+func (r *Router) VerifyAndAuthCompleted(isSuccessful bool, errorText string) {
+	r.byKind(VERIFY_AND_AUTH_COMPLETED).VerifyAndAuthCompleted(isSuccessful, errorText)
+}
+
+func (r *Router) ConnectAck() {
+	r.fallback.ConnectAck()
+}
+
+func (r *Router) PositionMulti(reqID int64, account string, modelCode string, contract *Contract, pos Decimal, avgCost float64) {
+	r.byReq(reqID, POSITION_MULTI).PositionMulti(reqID, account, modelCode, contract, pos, avgCost)
+}
+
+func (r *Router) PositionMultiEnd(reqID int64) {
+	r.byReq(reqID, POSITION_MULTI_END).PositionMultiEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) AccountUpdateMulti(reqID int64, account string, modleCode string, key string, value string, currency string) {
+	r.byReq(reqID, ACCOUNT_UPDATE_MULTI).AccountUpdateMulti(reqID, account, modleCode, key, value, currency)
+}
+
+func (r *Router) AccountUpdateMultiEnd(reqID int64) {
+	r.byReq(reqID, ACCOUNT_UPDATE_MULTI_END).AccountUpdateMultiEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) SecurityDefinitionOptionParameter(reqID int64, exchange string, underlyingConID int64, tradingClass string, multiplier string, expirations []string, strikes []float64) {
+	r.byReq(reqID, SECURITY_DEFINITION_OPTION_PARAMETER).SecurityDefinitionOptionParameter(reqID, exchange, underlyingConID, tradingClass, multiplier, expirations, strikes)
+}
+
+func (r *Router) SecurityDefinitionOptionParameterEnd(reqID int64) {
+	r.byReq(reqID, SECURITY_DEFINITION_OPTION_PARAMETER_END).SecurityDefinitionOptionParameterEnd(reqID)
+	r.end(reqID)
+}
+
+func (r *Router) SoftDollarTiers(reqID int64, tiers []SoftDollarTier) {
+	r.byReq(reqID, SOFT_DOLLAR_TIERS).SoftDollarTiers(reqID, tiers)
+	r.end(reqID)
+}
+
+func (r *Router) FamilyCodes(familyCodes []FamilyCode) {
+	r.byKind(FAMILY_CODES).FamilyCodes(familyCodes)
+}
+
+func (r *Router) SymbolSamples(reqID int64, contractDescriptions []ContractDescription) {
+	r.byReq(reqID, SYMBOL_SAMPLES).SymbolSamples(reqID, contractDescriptions)
+	r.end(reqID)
+}
+
+func (r *Router) MktDepthExchanges(depthMktDataDescriptions []DepthMktDataDescription) {
+	r.byKind(MKT_DEPTH_EXCHANGES).MktDepthExchanges(depthMktDataDescriptions)
+}
+
+func (r *Router) TickNews(reqID int64, timeStamp int64, providerCode string, articleID string, headline string, extraData string) {
+	r.byReq(reqID, TICK_NEWS).TickNews(reqID, timeStamp, providerCode, articleID, headline, extraData)
+}
+
+func (r *Router) SmartComponents(reqID int64, smartComponents []SmartComponent) {
+	r.byReq(reqID, SMART_COMPONENTS).SmartComponents(reqID, smartComponents)
+	r.end(reqID)
+}
+
+func (r *Router) TickReqParams(reqID int64, minTick float64, bboExchange string, snapshotPermissions int64) {
+	r.byReq(reqID, TICK_REQ_PARAMS).TickReqParams(reqID, minTick, bboExchange, snapshotPermissions)
+}
+
+func (r *Router) NewsProviders(newsProviders []NewsProvider) {
+	r.byKind(NEWS_PROVIDERS).NewsProviders(newsProviders)
+}
+
+func (r *Router) NewsArticle(requestID int64, articleType int64, articleText string) {
+	r.byReq(requestID, NEWS_ARTICLE).NewsArticle(requestID, articleType, articleText)
+	r.end(requestID)
+}
+
+func (r *Router) HistoricalNews(requestID int64, time string, providerCode string, articleID string, headline string) {
+	r.byReq(requestID, HISTORICAL_NEWS).HistoricalNews(requestID, time, providerCode, articleID, headline)
+}
+
+func (r *Router) HistoricalNewsEnd(requestID int64, hasMore bool) {
+	r.byReq(requestID, HISTORICAL_NEWS_END).HistoricalNewsEnd(requestID, hasMore)
+	r.end(requestID)
+}
+
+func (r *Router) HeadTimestamp(reqID int64, headTimestamp string) {
+	r.byReq(reqID, HEAD_TIMESTAMP).HeadTimestamp(reqID, headTimestamp)
+	r.end(reqID)
+}
+
+func (r *Router) HistogramData(reqID int64, data []HistogramData) {
+	r.byReq(reqID, HISTOGRAM_DATA).HistogramData(reqID, data)
+	r.end(reqID)
+}
+
+func (r *Router) HistoricalDataUpdate(reqID int64, bar *Bar) {
+	r.byReq(reqID, HISTORICAL_DATA_UPDATE).HistoricalDataUpdate(reqID, bar)
+}
+
+func (r *Router) RerouteMktDataReq(reqID int64, conID int64, exchange string) {
+	r.byReq(reqID, REROUTE_MKT_DATA_REQ).RerouteMktDataReq(reqID, conID, exchange)
+}
+
+func (r *Router) RerouteMktDepthReq(reqID int64, conID int64, exchange string) {
+	r.byReq(reqID, REROUTE_MKT_DEPTH_REQ).RerouteMktDepthReq(reqID, conID, exchange)
+}
+
+func (r *Router) MarketRule(marketRuleID int64, priceIncrements []PriceIncrement) {
+	r.byKind(MARKET_RULE).MarketRule(marketRuleID, priceIncrements)
+}
+
+func (r *Router) Pnl(reqID int64, dailyPnL float64, unrealizedPnL float64, realizedPnL float64) {
+	r.byReq(reqID, PNL).Pnl(reqID, dailyPnL, unrealizedPnL, realizedPnL)
+}
+
+func (r *Router) PnlSingle(reqID int64, pos Decimal, dailyPnL float64, unrealizedPnL float64, realizedPnL float64, value float64) {
+	r.byReq(reqID, PNL_SINGLE).PnlSingle(reqID, pos, dailyPnL, unrealizedPnL, realizedPnL, value)
+}
+
+func (r *Router) HistoricalTicks(reqID int64, ticks []HistoricalTick, done bool) {
+	r.byReq(reqID, HISTORICAL_TICKS).HistoricalTicks(reqID, ticks, done)
+	if done {
+		r.end(reqID)
+	}
+}
+
+func (r *Router) HistoricalTicksBidAsk(reqID int64, ticks []HistoricalTickBidAsk, done bool) {
+	r.byReq(reqID, HISTORICAL_TICKS_BID_ASK).HistoricalTicksBidAsk(reqID, ticks, done)
+	if done {
+		r.end(reqID)
+	}
+}
+
+func (r *Router) HistoricalTicksLast(reqID int64, ticks []HistoricalTickLast, done bool) {
+	r.byReq(reqID, HISTORICAL_TICKS_LAST).HistoricalTicksLast(reqID, ticks, done)
+	if done {
+		r.end(reqID)
+	}
+}
+
+func (r *Router) TickByTickAllLast(reqID int64, tickType int64, time int64, price float64, size Decimal, tickAttribLast TickAttribLast, exchange string, specialConditions string) {
+	r.byReq(reqID, TICK_BY_TICK).TickByTickAllLast(reqID, tickType, time, price, size, tickAttribLast, exchange, specialConditions)
+}
+
+func (r *Router) TickByTickBidAsk(reqID int64, time int64, bidPrice float64, askPrice float64, bidSize Decimal, askSize Decimal, tickAttribBidAsk TickAttribBidAsk) {
+	r.byReq(reqID, TICK_BY_TICK).TickByTickBidAsk(reqID, time, bidPrice, askPrice, bidSize, askSize, tickAttribBidAsk)
+}
+
+func (r *Router) TickByTickMidPoint(reqID int64, time int64, midPoint float64) {
+	r.byReq(reqID, TICK_BY_TICK).TickByTickMidPoint(reqID, time, midPoint)
+}
+
+func (r *Router) OrderBound(permID int64, clientID int64, orderID int64) {
+	r.byOrder(orderID, ORDER_BOUND).OrderBound(permID, clientID, orderID)
+}
+
+func (r *Router) CompletedOrdersEnd() {
+	r.byKind(COMPLETED_ORDERS_END).CompletedOrdersEnd()
+}
+
+func (r *Router) ReplaceFAEnd(reqID int64, text string) {
+	r.byReq(reqID, REPLACE_FA_END).ReplaceFAEnd(reqID, text)
+	r.end(reqID)
+}
+
+func (r *Router) WshMetaData(reqID int64, dataJson string) {
+	r.byReq(reqID, WSH_META_DATA).WshMetaData(reqID, dataJson)
+	r.end(reqID)
+}
+
+func (r *Router) WshEventData(reqID int64, dataJson string) {
+	r.byReq(reqID, WSH_EVENT_DATA).WshEventData(reqID, dataJson)
+	r.end(reqID)
+}
+
+func (r *Router) HistoricalSchedule(reqID int64, startDarteTime, endDateTime, timeZone string, sessions []HistoricalSession) {
+	r.byReq(reqID, HISTORICAL_SCHEDULE).HistoricalSchedule(reqID, startDarteTime, endDateTime, timeZone, sessions)
+	r.end(reqID)
+}
+
+func (r *Router) UserInfo(reqID int64, whiteBrandingId string) {
+	r.byReq(reqID, USER_INFO).UserInfo(reqID, whiteBrandingId)
+	r.end(reqID)
+}
+
+func (r *Router) CurrentTimeInMillis(timeInMillis int64) {
+	r.byKind(CURRENT_TIME_IN_MILLIS).CurrentTimeInMillis(timeInMillis)
+}
+
+func (r *Router) ExecDetailsEndProtoBuf(executionDetailsEndProto *protobuf.ExecutionDetailsEnd) {
+	r.byReq(optionalID(executionDetailsEndProto.ReqId), EXECUTION_DATA_END).ExecDetailsEndProtoBuf(executionDetailsEndProto)
+}
+
+func (r *Router) OrderStatusProtoBuf(orderStatusProto *protobuf.OrderStatus) {
+	r.byOrder(optionalID(orderStatusProto.OrderId), ORDER_STATUS).OrderStatusProtoBuf(orderStatusProto)
+}
+
+func (r *Router) OpenOrderProtoBuf(openOrderProto *protobuf.OpenOrder) {
+	r.byOrder(optionalID(openOrderProto.OrderId), OPEN_ORDER).OpenOrderProtoBuf(openOrderProto)
+}
+
+func (r *Router) OpenOrdersEndProtoBuf(openOrdersEndProto *protobuf.OpenOrdersEnd) {
+	r.byKind(OPEN_ORDER_END).OpenOrdersEndProtoBuf(openOrdersEndProto)
+}
+
+func (r *Router) CompletedOrderProtoBuf(completedOrderProto *protobuf.CompletedOrder) {
+	r.byKind(COMPLETED_ORDER).CompletedOrderProtoBuf(completedOrderProto)
+}
+
+func (r *Router) CompletedOrdersEndProtoBuf(completedOrdersEndProto *protobuf.CompletedOrdersEnd) {
+	r.byKind(COMPLETED_ORDERS_END).CompletedOrdersEndProtoBuf(completedOrdersEndProto)
+}
+
+func (r *Router) OrderBoundProtoBuf(orderBoundProto *protobuf.OrderBound) {
+	r.byOrder(optionalID(orderBoundProto.OrderId), ORDER_BOUND).OrderBoundProtoBuf(orderBoundProto)
+}
+
+func (r *Router) ContractDataProtoBuf(contractDataProto *protobuf.ContractData) {
+	r.byReq(optionalID(contractDataProto.ReqId), CONTRACT_DATA).ContractDataProtoBuf(contractDataProto)
+}
+
+func (r *Router) BondContractDataProtoBuf(contractDataProto *protobuf.ContractData) {
+	r.byReq(optionalID(contractDataProto.ReqId), BOND_CONTRACT_DATA).BondContractDataProtoBuf(contractDataProto)
+}
+
+func (r *Router) ContractDataEndProtoBuf(contractDataEndProto *protobuf.ContractDataEnd) {
+	r.byReq(optionalID(contractDataEndProto.ReqId), CONTRACT_DATA_END).ContractDataEndProtoBuf(contractDataEndProto)
+}
+
+func (r *Router) TickPriceProtoBuf(tickPriceProto *protobuf.TickPrice) {
+	r.byReq(optionalID(tickPriceProto.ReqId), TICK_PRICE).TickPriceProtoBuf(tickPriceProto)
+}
+
+func (r *Router) TickSizeProtoBuf(tickSizeProto *protobuf.TickSize) {
+	r.byReq(optionalID(tickSizeProto.ReqId), TICK_SIZE).TickSizeProtoBuf(tickSizeProto)
+}
+
+func (r *Router) TickOptionComputationProtoBuf(tickOptionComputationProto *protobuf.TickOptionComputation) {
+	r.byReq(optionalID(tickOptionComputationProto.ReqId), TICK_OPTION_COMPUTATION).TickOptionComputationProtoBuf(tickOptionComputationProto)
+}
+
+func (r *Router) TickGenericProtoBuf(tickGenericProto *protobuf.TickGeneric) {
+	r.byReq(optionalID(tickGenericProto.ReqId), TICK_GENERIC).TickGenericProtoBuf(tickGenericProto)
+}
+
+func (r *Router) TickStringProtoBuf(tickStringProto *protobuf.TickString) {
+	r.byReq(optionalID(tickStringProto.ReqId), TICK_STRING).TickStringProtoBuf(tickStringProto)
+}
+
+func (r *Router) TickSnapshotEndProtoBuf(tickSnapshotEndProto *protobuf.TickSnapshotEnd) {
+	r.byReq(optionalID(tickSnapshotEndProto.ReqId), TICK_SNAPSHOT_END).TickSnapshotEndProtoBuf(tickSnapshotEndProto)
+}
+
+func (r *Router) UpdateMarketDepthProtoBuf(marketDepthProto *protobuf.MarketDepth) {
+	r.byReq(optionalID(marketDepthProto.ReqId), MARKET_DEPTH).UpdateMarketDepthProtoBuf(marketDepthProto)
+}
+
+func (r *Router) UpdateMarketDepthL2ProtoBuf(marketDepthL2Proto *protobuf.MarketDepthL2) {
+	r.byReq(optionalID(marketDepthL2Proto.ReqId), MARKET_DEPTH_L2).UpdateMarketDepthL2ProtoBuf(marketDepthL2Proto)
+}
+
+func (r *Router) MarketDataTypeProtoBuf(marketDataTypeProto *protobuf.MarketDataType) {
+	r.byReq(optionalID(marketDataTypeProto.ReqId), MARKET_DATA_TYPE).MarketDataTypeProtoBuf(marketDataTypeProto)
+}
+
+func (r *Router) TickReqParamsProtoBuf(tickReqParamsProto *protobuf.TickReqParams) {
+	r.byReq(optionalID(tickReqParamsProto.ReqId), TICK_REQ_PARAMS).TickReqParamsProtoBuf(tickReqParamsProto)
+}
+
+func (r *Router) UpdateAccountValueProtoBuf(accountValueProto *protobuf.AccountValue) {
+	r.byKind(ACCT_VALUE).UpdateAccountValueProtoBuf(accountValueProto)
+}
+
+func (r *Router) UpdatePortfolioProtoBuf(portfolioValueProto *protobuf.PortfolioValue) {
+	r.byKind(PORTFOLIO_VALUE).UpdatePortfolioProtoBuf(portfolioValueProto)
+}
+
+func (r *Router) UpdateAccountTimeProtoBuf(accountUpdateTimeProto *protobuf.AccountUpdateTime) {
+	r.byKind(ACCT_UPDATE_TIME).UpdateAccountTimeProtoBuf(accountUpdateTimeProto)
+}
+
+func (r *Router) AccountDataEndProtoBuf(accountDataEndProto *protobuf.AccountDataEnd) {
+	r.byKind(ACCT_DOWNLOAD_END).AccountDataEndProtoBuf(accountDataEndProto)
+}
+
+func (r *Router) ManagedAccountsProtoBuf(managedAccountsProto *protobuf.ManagedAccounts) {
+	r.byKind(MANAGED_ACCTS).ManagedAccountsProtoBuf(managedAccountsProto)
+}
+
+func (r *Router) PositionProtoBuf(positionProto *protobuf.Position) {
+	r.byKind(POSITION_DATA).PositionProtoBuf(positionProto)
+}
+
+func (r *Router) PositionEndProtoBuf(positionEndProto *protobuf.PositionEnd) {
+	r.byKind(POSITION_END).PositionEndProtoBuf(positionEndProto)
+}
+
+func (r *Router) AccountSummaryProtoBuf(accountSummaryProto *protobuf.AccountSummary) {
+	r.byReq(optionalID(accountSummaryProto.ReqId), ACCOUNT_SUMMARY).AccountSummaryProtoBuf(accountSummaryProto)
+}
+
+func (r *Router) AccountSummaryEndProtoBuf(accountSummaryEndProto *protobuf.AccountSummaryEnd) {
+	r.byReq(optionalID(accountSummaryEndProto.ReqId), ACCOUNT_SUMMARY_END).AccountSummaryEndProtoBuf(accountSummaryEndProto)
+}
+
+func (r *Router) PositionMultiProtoBuf(positionMultiProto *protobuf.PositionMulti) {
+	r.byReq(optionalID(positionMultiProto.ReqId), POSITION_MULTI).PositionMultiProtoBuf(positionMultiProto)
+}
+
+func (r *Router) PositionMultiEndProtoBuf(positionMultiEndProto *protobuf.PositionMultiEnd) {
+	r.byReq(optionalID(positionMultiEndProto.ReqId), POSITION_MULTI_END).PositionMultiEndProtoBuf(positionMultiEndProto)
+}
+
+func (r *Router) AccountUpdateMultiProtoBuf(accountUpdateMultiProto *protobuf.AccountUpdateMulti) {
+	r.byReq(optionalID(accountUpdateMultiProto.ReqId), ACCOUNT_UPDATE_MULTI).AccountUpdateMultiProtoBuf(accountUpdateMultiProto)
+}
+
+func (r *Router) AccountUpdateMultiEndProtoBuf(accountUpdateMultiEndProto *protobuf.AccountUpdateMultiEnd) {
+	r.byReq(optionalID(accountUpdateMultiEndProto.ReqId), ACCOUNT_UPDATE_MULTI_END).AccountUpdateMultiEndProtoBuf(accountUpdateMultiEndProto)
+}
+
+func (r *Router) HistoricalDataProtoBuf(historicalDataProto *protobuf.HistoricalData) {
+	r.byReq(optionalID(historicalDataProto.ReqId), HISTORICAL_DATA).HistoricalDataProtoBuf(historicalDataProto)
+}
+
+func (r *Router) HistoricalDataUpdateProtoBuf(historicalDataUpdateProto *protobuf.HistoricalDataUpdate) {
+	r.byReq(optionalID(historicalDataUpdateProto.ReqId), HISTORICAL_DATA_UPDATE).HistoricalDataUpdateProtoBuf(historicalDataUpdateProto)
+}
+
+func (r *Router) HistoricalDataEndProtoBuf(historicalDataEndProto *protobuf.HistoricalDataEnd) {
+	r.byReq(optionalID(historicalDataEndProto.ReqId), HISTORICAL_DATA_END).HistoricalDataEndProtoBuf(historicalDataEndProto)
+}
+
+func (r *Router) RealTimeBarTickProtoBuf(realTimeBarTickProto *protobuf.RealTimeBarTick) {
+	r.byReq(optionalID(realTimeBarTickProto.ReqId), REAL_TIME_BARS).RealTimeBarTickProtoBuf(realTimeBarTickProto)
+}
+
+func (r *Router) HeadTimestampProtoBuf(headTimestampProto *protobuf.HeadTimestamp) {
+	r.byReq(optionalID(headTimestampProto.ReqId), HEAD_TIMESTAMP).HeadTimestampProtoBuf(headTimestampProto)
+}
+
+func (r *Router) HistogramDataProtoBuf(histogramDataProto *protobuf.HistogramData) {
+	r.byReq(optionalID(histogramDataProto.ReqId), HISTOGRAM_DATA).HistogramDataProtoBuf(histogramDataProto)
+}
+
+func (r *Router) HistoricalTicksProtoBuf(historicalTicksProto *protobuf.HistoricalTicks) {
+	r.byReq(optionalID(historicalTicksProto.ReqId), HISTORICAL_TICKS).HistoricalTicksProtoBuf(historicalTicksProto)
+}
+
+func (r *Router) HistoricalTicksBidAskProtoBuf(historicalTicksBidAskProto *protobuf.HistoricalTicksBidAsk) {
+	r.byReq(optionalID(historicalTicksBidAskProto.ReqId), HISTORICAL_TICKS_BID_ASK).HistoricalTicksBidAskProtoBuf(historicalTicksBidAskProto)
+}
+
+func (r *Router) HistoricalTicksLastProtoBuf(historicalTicksLastProto *protobuf.HistoricalTicksLast) {
+	r.byReq(optionalID(historicalTicksLastProto.ReqId), HISTORICAL_TICKS_LAST).HistoricalTicksLastProtoBuf(historicalTicksLastProto)
+}
+
+func (r *Router) TickByTickDataProtoBuf(tickByTickDataProto *protobuf.TickByTickData) {
+	r.byReq(optionalID(tickByTickDataProto.ReqId), TICK_BY_TICK).TickByTickDataProtoBuf(tickByTickDataProto)
+}
+
+func (r *Router) UpdateNewsBulletinProtoBuf(newsBulletinProto *protobuf.NewsBulletin) {
+	r.byKind(NEWS_BULLETINS).UpdateNewsBulletinProtoBuf(newsBulletinProto)
+}
+
+func (r *Router) NewsArticleProtoBuf(newsArticleProto *protobuf.NewsArticle) {
+	r.byReq(optionalID(newsArticleProto.ReqId), NEWS_ARTICLE).NewsArticleProtoBuf(newsArticleProto)
+}
+
+func (r *Router) NewsProvidersProtoBuf(newsProvidersProto *protobuf.NewsProviders) {
+	r.byKind(NEWS_PROVIDERS).NewsProvidersProtoBuf(newsProvidersProto)
+}
+
+func (r *Router) HistoricalNewsProtoBuf(historicalNewsProto *protobuf.HistoricalNews) {
+	r.byReq(optionalID(historicalNewsProto.ReqId), HISTORICAL_NEWS).HistoricalNewsProtoBuf(historicalNewsProto)
+}
+
+func (r *Router) HistoricalNewsEndProtoBuf(historicalNewsEndProto *protobuf.HistoricalNewsEnd) {
+	r.byReq(optionalID(historicalNewsEndProto.ReqId), HISTORICAL_NEWS_END).HistoricalNewsEndProtoBuf(historicalNewsEndProto)
+}
+
+func (r *Router) WshMetaDataProtoBuf(wshMetaDataProto *protobuf.WshMetaData) {
+	r.byReq(optionalID(wshMetaDataProto.ReqId), WSH_META_DATA).WshMetaDataProtoBuf(wshMetaDataProto)
+}
+
+func (r *Router) WshEventDataProtoBuf(wshEventDataProto *protobuf.WshEventData) {
+	r.byReq(optionalID(wshEventDataProto.ReqId), WSH_EVENT_DATA).WshEventDataProtoBuf(wshEventDataProto)
+}
+
+func (r *Router) TickNewsProtoBuf(tickNewsProto *protobuf.TickNews) {
+	r.byReq(optionalID(tickNewsProto.ReqId), TICK_NEWS).TickNewsProtoBuf(tickNewsProto)
+}
+
+func (r *Router) ScannerParametersProtoBuf(scannerParametersProto *protobuf.ScannerParameters) {
+	r.byKind(SCANNER_PARAMETERS).ScannerParametersProtoBuf(scannerParametersProto)
+}
+
+func (r *Router) ScannerDataProtoBuf(scannerDataProto *protobuf.ScannerData) {
+	r.byReq(optionalID(scannerDataProto.ReqId), SCANNER_DATA).ScannerDataProtoBuf(scannerDataProto)
+}
+
+func (r *Router) PnLProtoBuf(pnlProto *protobuf.PnL) {
+	r.byReq(optionalID(pnlProto.ReqId), PNL).PnLProtoBuf(pnlProto)
+}
+
+func (r *Router) PnLSingleProtoBuf(pnlSingleProto *protobuf.PnLSingle) {
+	r.byReq(optionalID(pnlSingleProto.ReqId), PNL_SINGLE).PnLSingleProtoBuf(pnlSingleProto)
+}
+
+func (r *Router) ReceiveFAProtoBuf(receiveFAProto *protobuf.ReceiveFA) {
+	r.byKind(RECEIVE_FA).ReceiveFAProtoBuf(receiveFAProto)
+}
+
+func (r *Router) ReplaceFAEndProtoBuf(replaceFAEndProto *protobuf.ReplaceFAEnd) {
+	r.byReq(optionalID(replaceFAEndProto.ReqId), REPLACE_FA_END).ReplaceFAEndProtoBuf(replaceFAEndProto)
+}
+
+func (r *Router) HistoricalScheduleProtoBuf(historicalScheduleProto *protobuf.HistoricalSchedule) {
+	r.byReq(optionalID(historicalScheduleProto.ReqId), HISTORICAL_SCHEDULE).HistoricalScheduleProtoBuf(historicalScheduleProto)
+}
+
+func (r *Router) RerouteMarketDataRequestProtoBuf(rerouteMarketDataRequestProto *protobuf.RerouteMarketDataRequest) {
+	r.byReq(optionalID(rerouteMarketDataRequestProto.ReqId), REROUTE_MKT_DATA_REQ).RerouteMarketDataRequestProtoBuf(rerouteMarketDataRequestProto)
+}
+
+func (r *Router) RerouteMarketDepthRequestProtoBuf(rerouteMarketDepthRequestProto *protobuf.RerouteMarketDepthRequest) {
+	r.byReq(optionalID(rerouteMarketDepthRequestProto.ReqId), REROUTE_MKT_DEPTH_REQ).RerouteMarketDepthRequestProtoBuf(rerouteMarketDepthRequestProto)
+}
+
+func (r *Router) SecDefOptParameterProtoBuf(secDefOptParameterProto *protobuf.SecDefOptParameter) {
+	r.byReq(optionalID(secDefOptParameterProto.ReqId), SECURITY_DEFINITION_OPTION_PARAMETER).SecDefOptParameterProtoBuf(secDefOptParameterProto)
+}
+
+func (r *Router) SecDefOptParameterEndProtoBuf(secDefOptParameterEndProto *protobuf.SecDefOptParameterEnd) {
+	r.byReq(optionalID(secDefOptParameterEndProto.ReqId), SECURITY_DEFINITION_OPTION_PARAMETER_END).SecDefOptParameterEndProtoBuf(secDefOptParameterEndProto)
+}
+
+func (r *Router) SoftDollarTiersProtoBuf(softDollarTiersProto *protobuf.SoftDollarTiers) {
+	r.byReq(optionalID(softDollarTiersProto.ReqId), SOFT_DOLLAR_TIERS).SoftDollarTiersProtoBuf(softDollarTiersProto)
+}
+
+func (r *Router) FamilyCodesProtoBuf(familyCodesProto *protobuf.FamilyCodes) {
+	r.byKind(FAMILY_CODES).FamilyCodesProtoBuf(familyCodesProto)
+}
+
+func (r *Router) SymbolSamplesProtoBuf(symbolSamplesProto *protobuf.SymbolSamples) {
+	r.byReq(optionalID(symbolSamplesProto.ReqId), SYMBOL_SAMPLES).SymbolSamplesProtoBuf(symbolSamplesProto)
+}
+
+func (r *Router) SmartComponentsProtoBuf(smartComponentsProto *protobuf.SmartComponents) {
+	r.byReq(optionalID(smartComponentsProto.ReqId), SMART_COMPONENTS).SmartComponentsProtoBuf(smartComponentsProto)
+}
+
+func (r *Router) MarketRuleProtoBuf(marketRuleProto *protobuf.MarketRule) {
+	r.byKind(MARKET_RULE).MarketRuleProtoBuf(marketRuleProto)
+}
+
+func (r *Router) UserInfoProtoBuf(userInfoProto *protobuf.UserInfo) {
+	r.byReq(optionalID(userInfoProto.ReqId), USER_INFO).UserInfoProtoBuf(userInfoProto)
+}
+
+func (r *Router) NextValidIdProtoBuf(nextValidIdProto *protobuf.NextValidId) {
+	r.byKind(NEXT_VALID_ID).NextValidIdProtoBuf(nextValidIdProto)
+}
+
+func (r *Router) CurrentTimeProtoBuf(currentTimeProto *protobuf.CurrentTime) {
+	r.byKind(CURRENT_TIME).CurrentTimeProtoBuf(currentTimeProto)
+}
+
+func (r *Router) CurrentTimeInMillisProtoBuf(currentTimeInMillisProto *protobuf.CurrentTimeInMillis) {
+	r.byKind(CURRENT_TIME_IN_MILLIS).CurrentTimeInMillisProtoBuf(currentTimeInMillisProto)
+}
+
+func (r *Router) VerifyMessageApiProtoBuf(verifyMessageApiProto *protobuf.VerifyMessageApi) {
+	r.byKind(VERIFY_MESSAGE_API).VerifyMessageApiProtoBuf(verifyMessageApiProto)
+}
+
+func (r *Router) VerifyCompletedProtoBuf(verifyCompletedProto *protobuf.VerifyCompleted) {
+	r.byKind(VERIFY_COMPLETED).VerifyCompletedProtoBuf(verifyCompletedProto)
+}
+
+func (r *Router) DisplayGroupListProtoBuf(displayGroupListProto *protobuf.DisplayGroupList) {
+	r.byReq(optionalID(displayGroupListProto.ReqId), DISPLAY_GROUP_LIST).DisplayGroupListProtoBuf(displayGroupListProto)
+}
+
+func (r *Router) DisplayGroupUpdatedProtoBuf(displayGroupUpdatedProto *protobuf.DisplayGroupUpdated) {
+	r.byReq(optionalID(displayGroupUpdatedProto.ReqId), DISPLAY_GROUP_UPDATED).DisplayGroupUpdatedProtoBuf(displayGroupUpdatedProto)
+}
+
+func (r *Router) MarketDepthExchangesProtoBuf(marketDepthExchangesProto *protobuf.MarketDepthExchanges) {
+	r.byKind(MKT_DEPTH_EXCHANGES).MarketDepthExchangesProtoBuf(marketDepthExchangesProto)
+}
+
+func (r *Router) ConfigResponseProtoBuf(configResponseProto *protobuf.ConfigResponse) {
+	reqID := optionalID(configResponseProto.ReqId)
+	r.byReq(reqID, CONFIG_RESPONSE).ConfigResponseProtoBuf(configResponseProto)
+	r.end(reqID)
+}
+
+func (r *Router) UpdateConfigResponseProtoBuf(UpdateConfigResponseProto *protobuf.UpdateConfigResponse) {
+	reqID := optionalID(UpdateConfigResponseProto.ReqId)
+	r.byReq(reqID, UPDATE_CONFIG_RESPONSE).UpdateConfigResponseProtoBuf(UpdateConfigResponseProto)
+	r.end(reqID)
+}
@@ -0,0 +1,217 @@
+package ibapi
+
+import (
+	"errors"
+	"testing"
+)
+
+// routeRecorder records the callbacks it receives.
+type routeRecorder struct {
+	Wrapper
+	calls []string
+}
+
+func (r *routeRecorder) ContractDetails(reqID int64, contractDetails *ContractDetails) {
+	r.calls = append(r.calls, "ContractDetails")
+}
+
+func (r *routeRecorder) ContractDetailsEnd(reqID int64) {
+	r.calls = append(r.calls, "ContractDetailsEnd")
+}
+
+func (r *routeRecorder) HistoricalDataEnd(reqID int64, startDateStr string, endDateStr string) {
+	r.calls = append(r.calls, "HistoricalDataEnd")
+}
+
+func (r *routeRecorder) HistoricalDataUpdate(reqID int64, bar *Bar) {
+	r.calls = append(r.calls, "HistoricalDataUpdate")
+}
+
+func (r *routeRecorder) Error(reqID int64, errTime int64, errCode int64, errString string, advancedOrderRejectJson string) {
+	r.calls = append(r.calls, "Error")
+}
+
+func (r *routeRecorder) OrderStatus(orderID int64, status string, filled Decimal, remaining Decimal, avgFillPrice float64, permID int64, parentID int64, lastFillPrice float64, clientID int64, whyHeld string, mktCapPrice float64) {
+	r.calls = append(r.calls, "OrderStatus "+status)
+}
+
+func (r *routeRecorder) ExecDetails(reqID int64, contract *Contract, execution *Execution) {
+	r.calls = append(r.calls, "ExecDetails")
+}
+
+func (r *routeRecorder) CommissionAndFeesReport(commissionAndFeesReport CommissionAndFeesReport) {
+	r.calls = append(r.calls, "CommissionAndFeesReport")
+}
+
+func (r *routeRecorder) TickPrice(reqID int64, tickType TickType, price float64, attrib TickAttrib) {
+	r.calls = append(r.calls, "TickPrice")
+}
+
+func (r *routeRecorder) CurrentTime(t int64) {
+	r.calls = append(r.calls, "CurrentTime")
+}
+
+func assertCalls(t *testing.T, who string, r *routeRecorder, want ...string) {
+	t.Helper()
+	if len(r.calls) != len(want) {
+		t.Fatalf("%s: got %v, want %v", who, r.calls, want)
+	}
+	for i := range want {
+		if r.calls[i] != want[i] {
+			t.Fatalf("%s: got %v, want %v", who, r.calls, want)
+		}
+	}
+}
+
+func isDone(rt *Route) bool {
+	select {
+	case <-rt.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestRouterReqID(t *testing.T) {
+	fallback, h := &routeRecorder{}, &routeRecorder{}
+	r := NewRouter(fallback)
+	rt := r.Handle(1, h)
+
+	r.ContractDetails(1, &ContractDetails{})
+	r.ContractDetails(2, &ContractDetails{})
+	r.ContractDetailsEnd(1)
+	if !isDone(rt) || rt.Err() != nil {
+		t.Fatalf("route not removed by the end message, err %v", rt.Err())
+	}
+	r.ContractDetails(1, &ContractDetails{})
+
+	assertCalls(t, "handler", h, "ContractDetails", "ContractDetailsEnd")
+	assertCalls(t, "fallback", fallback, "ContractDetails", "ContractDetails")
+}
+
+func TestRouterFatalError(t *testing.T) {
+	fallback, h := &routeRecorder{}, &routeRecorder{}
+	r := NewRouter(fallback)
+	rt := r.Handle(1, h)
+
+	r.Error(1, 0, 2176, "warning", "")
+	if isDone(rt) {
+		t.Fatal("route removed by a warning")
+	}
+	r.Error(1, 0, 200, "No security definition has been found for the request", "")
+	if !isDone(rt) {
+		t.Fatal("route not removed by a request-fatal error")
+	}
+	if !errors.Is(rt.Err(), ErrNoSecurityDefinition) {
+		t.Errorf("unexpected route error %v", rt.Err())
+	}
+	r.Error(1, 0, 200, "No security definition has been found for the request", "")
+
+	assertCalls(t, "handler", h, "Error", "Error")
+	assertCalls(t, "fallback", fallback, "Error")
+}
+
+func TestRouterStream(t *testing.T) {
+	fallback, h := &routeRecorder{}, &routeRecorder{}
+	r := NewRouter(fallback)
+	rt := r.HandleStream(1, h)
+
+	r.HistoricalDataEnd(1, "", "")
+	r.HistoricalDataUpdate(1, &Bar{})
+	if isDone(rt) {
+		t.Fatal("stream route removed by the end message")
+	}
+	rt.Remove()
+	r.HistoricalDataUpdate(1, &Bar{})
+
+	assertCalls(t, "handler", h, "HistoricalDataEnd", "HistoricalDataUpdate")
+	assertCalls(t, "fallback", fallback, "HistoricalDataUpdate")
+}
+
+func TestRouterReplace(t *testing.T) {
+	r := NewRouter(nil)
+	old, h := &routeRecorder{}, &routeRecorder{}
+	oldRoute := r.Handle(1, old)
+	r.Handle(1, h)
+	if !isDone(oldRoute) {
+		t.Fatal("replaced route not removed")
+	}
+	oldRoute.Remove()
+	r.ContractDetails(1, &ContractDetails{})
+	assertCalls(t, "handler", h, "ContractDetails")
+	assertCalls(t, "old handler", old)
+}
+
+func TestRouterOrder(t *testing.T) {
+	fallback, h := &routeRecorder{}, &routeRecorder{}
+	r := NewRouter(fallback)
+	rt := r.HandleOrder(7, h)
+
+	r.OrderStatus(7, "Submitted", ZERO, ZERO, 0, 0, 0, 0, 0, "", 0)
+	r.ExecDetails(-1, &Contract{}, &Execution{OrderID: 7, ExecID: "e1"})
+	r.ExecDetails(-1, &Contract{}, &Execution{OrderID: 8, ExecID: "e2"})
+	r.CommissionAndFeesReport(CommissionAndFeesReport{ExecID: "e1"})
+	r.CommissionAndFeesReport(CommissionAndFeesReport{ExecID: "e2"})
+	r.Error(7, 0, 2109, "outside regular trading hours", "")
+	r.OrderStatus(7, "Cancelled", ZERO, ZERO, 0, 0, 0, 0, 0, "", 0)
+	if !isDone(rt) {
+		t.Fatal("order route not removed by the cancellation")
+	}
+	r.OrderStatus(7, "Cancelled", ZERO, ZERO, 0, 0, 0, 0, 0, "", 0)
+
+	assertCalls(t, "handler", h, "OrderStatus Submitted", "ExecDetails", "CommissionAndFeesReport", "Error", "OrderStatus Cancelled")
+	assertCalls(t, "fallback", fallback, "ExecDetails", "CommissionAndFeesReport", "OrderStatus Cancelled")
+}
+
+func TestRouterOrderDone(t *testing.T) {
+	fallback, h := &routeRecorder{}, &routeRecorder{}
+	r := NewRouter(fallback)
+	rt := r.HandleOrder(7, h)
+
+	// A refused cancellation or modification leaves the order working
+	r.Error(7, 0, 161, "Cancel attempted when order is not in a cancellable state", "")
+	r.Error(7, 0, 10147, "OrderId that needs to be cancelled is not found", "")
+	if isDone(rt) {
+		t.Fatal("order route removed by a refused cancellation")
+	}
+	r.ExecDetails(-1, &Contract{}, &Execution{OrderID: 7, ExecID: "e1"})
+	r.OrderStatus(7, "Filled", ONE, ZERO, 0, 0, 0, 0, 0, "", 0)
+	if !isDone(rt) || rt.Err() != nil {
+		t.Fatal("order route not removed by the fill")
+	}
+	// The commission report follows the fill
+	r.CommissionAndFeesReport(CommissionAndFeesReport{ExecID: "e1"})
+	assertCalls(t, "handler", h, "Error", "Error", "ExecDetails", "OrderStatus Filled", "CommissionAndFeesReport")
+
+	rt = r.HandleOrder(8, h)
+	r.Error(8, 0, 201, "Order rejected", "")
+	if !isDone(rt) || !errors.Is(rt.Err(), ErrOrderRejected) {
+		t.Errorf("order route not removed by the reject: %v", rt.Err())
+	}
+
+	// The executions waiting for a commission report are forgotten with the session
+	r.HandleOrder(9, h)
+	r.ExecDetails(-1, &Contract{}, &Execution{OrderID: 9, ExecID: "e2"})
+	r.ConnectionClosed()
+	r.mu.RLock()
+	pending := len(r.execs)
+	r.mu.RUnlock()
+	if pending != 0 {
+		t.Errorf("expected no pending execution, got %d", pending)
+	}
+}
+
+func TestRouterKind(t *testing.T) {
+	fallback, ticks, h := &routeRecorder{}, &routeRecorder{}, &routeRecorder{}
+	r := NewRouter(fallback)
+	r.HandleKind(TICK_PRICE, ticks)
+	r.HandleStream(1, h)
+
+	r.TickPrice(1, 1, 100, TickAttrib{})
+	r.TickPrice(2, 1, 100, TickAttrib{})
+	r.CurrentTime(0)
+
+	assertCalls(t, "handler", h, "TickPrice")
+	assertCalls(t, "kind handler", ticks, "TickPrice")
+	assertCalls(t, "fallback", fallback, "CurrentTime")
+}
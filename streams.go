@@ -0,0 +1,401 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy tells what a subscription does with an event when its buffer is full.
+// The events are pushed by the decoder goroutine, which never waits for a slow consumer.
+type OverflowPolicy int
+
+const (
+	OverflowDropOldest OverflowPolicy = iota // drop the oldest buffered event to make room
+	OverflowDropNewest                       // drop the incoming event
+	OverflowClose                            // end the subscription with ErrSlowConsumer
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropOldest:
+		return "drop oldest"
+	case OverflowDropNewest:
+		return "drop newest"
+	case OverflowClose:
+		return "close"
+	default:
+		return "unknown overflow policy"
+	}
+}
+
+// StreamConfig configures the channels of the streaming subscriptions.
+type StreamConfig struct {
+	// Buffer is the number of events buffered for the consumer.
+	Buffer int
+	// Overflow is applied when the buffer is full.
+	Overflow OverflowPolicy
+}
+
+// DefaultStreamConfig returns a 1024 events buffer dropping the oldest events on overflow.
+func DefaultStreamConfig() *StreamConfig {
+	return &StreamConfig{
+		Buffer:   1024,
+		Overflow: OverflowDropOldest,
+	}
+}
+
+// SetStreamConfig sets the configuration of the subscriptions started afterwards, nil restores the default.
+func (c *EClient) SetStreamConfig(config *StreamConfig) {
+	if config == nil {
+		config = DefaultStreamConfig()
+	}
+	c.streamConfig = *config
+}
+
+// ErrSlowConsumer ends a subscription with the OverflowClose policy whose buffer is full.
+var ErrSlowConsumer = errors.New("subscription buffer overflow")
+
+// TickEvent is a market data event of SubscribeMarketData:
+// TickPriceEvent, TickSizeEvent, TickGenericEvent, TickStringEvent or TickOptionComputationEvent.
+type TickEvent interface {
+	tickEvent()
+}
+
+// TickPriceEvent is a TickPrice callback.
+type TickPriceEvent struct {
+	TickType TickType
+	Price    float64
+	Attrib   TickAttrib
+}
+
+// TickSizeEvent is a TickSize callback.
+type TickSizeEvent struct {
+	TickType TickType
+	Size     Decimal
+}
+
+// TickGenericEvent is a TickGeneric callback.
+type TickGenericEvent struct {
+	TickType TickType
+	Value    float64
+}
+
+// TickStringEvent is a TickString callback.
+type TickStringEvent struct {
+	TickType TickType
+	Value    string
+}
+
+// TickOptionComputationEvent is a TickOptionComputation callback.
+type TickOptionComputationEvent struct {
+	TickType   TickType
+	TickAttrib int64
+	ImpliedVol float64
+	Delta      float64
+	OptPrice   float64
+	PvDividend float64
+	Gamma      float64
+	Vega       float64
+	Theta      float64
+	UndPrice   float64
+}
+
+func (TickPriceEvent) tickEvent()             {}
+func (TickSizeEvent) tickEvent()              {}
+func (TickGenericEvent) tickEvent()           {}
+func (TickStringEvent) tickEvent()            {}
+func (TickOptionComputationEvent) tickEvent() {}
+
+// TickByTickEvent is a tick of SubscribeTickByTick: TickByTickLastEvent, TickByTickBidAskEvent or TickByTickMidPointEvent.
+type TickByTickEvent interface {
+	tickByTickEvent()
+}
+
+// TickByTickLastEvent is a TickByTickAllLast callback.
+type TickByTickLastEvent struct {
+	TickType int64 // 1 for Last, 2 for AllLast
+	HistoricalTickLast
+}
+
+// TickByTickBidAskEvent is a TickByTickBidAsk callback.
+type TickByTickBidAskEvent struct {
+	HistoricalTickBidAsk
+}
+
+// TickByTickMidPointEvent is a TickByTickMidPoint callback.
+type TickByTickMidPointEvent struct {
+	Time     int64
+	MidPoint float64
+}
+
+func (TickByTickLastEvent) tickByTickEvent()     {}
+func (TickByTickBidAskEvent) tickByTickEvent()   {}
+func (TickByTickMidPointEvent) tickByTickEvent() {}
+
+// DepthEvent is an UpdateMktDepth or UpdateMktDepthL2 callback.
+type DepthEvent struct {
+	Position     int64
+	MarketMaker  string // empty for UpdateMktDepth
+	Operation    int64  // 0 insert, 1 update, 2 delete
+	Side         int64  // 0 ask, 1 bid
+	Price        float64
+	Size         Decimal
+	IsSmartDepth bool
+}
+
+// BarEvent is a bar of SubscribeHistoricalBars.
+// The bars of the initial download come first, then the updates of the last bar.
+type BarEvent struct {
+	Bar    Bar
+	Update bool // HistoricalDataUpdate callback
+}
+
+// streamLifecycle is the lifetime of a streaming request, shared by Subscription, Ticker and OrderBook.
+// The stream ends once: it leaves the registry, records the error and cancels the request server side if asked.
+type streamLifecycle struct {
+	ReqID int64
+
+	streams *streamRegistry
+	cancel  func()
+	stop    func() // run as the stream ends, before Done is closed
+
+	errMu sync.Mutex
+	err   error
+	done  chan struct{}
+	once  sync.Once
+}
+
+// Done is closed when the stream ends.
+func (l *streamLifecycle) Done() <-chan struct{} {
+	return l.done
+}
+
+// Err returns the error that ended the stream, nil when ended by its context or Close.
+func (l *streamLifecycle) Err() error {
+	l.errMu.Lock()
+	defer l.errMu.Unlock()
+	return l.err
+}
+
+// Close cancels the request and ends the stream.
+func (l *streamLifecycle) Close() {
+	l.end(nil, true)
+}
+
+func (l *streamLifecycle) fail(err error) {
+	l.end(err, false)
+}
+
+// end ends the stream once, cancelling the request server side if asked.
+func (l *streamLifecycle) end(err error, cancel bool) {
+	l.once.Do(func() {
+		l.streams.remove(l.ReqID)
+		l.errMu.Lock()
+		l.err = err
+		l.errMu.Unlock()
+		if l.stop != nil {
+			l.stop()
+		}
+		close(l.done)
+		if cancel && l.cancel != nil {
+			// The decoder goroutine may end a stream, it must not wait for the requester
+			go l.cancel()
+		}
+	})
+}
+
+// start registers s, the stream owning l, sends its request and ends it with ctx.
+func (l *streamLifecycle) start(ctx context.Context, s failer, req func(reqID int64) error) error {
+	l.streams.add(l.ReqID, s)
+	if err := req(l.ReqID); err != nil {
+		l.streams.remove(l.ReqID)
+		return err
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			l.end(nil, true)
+		case <-l.done:
+		}
+	}()
+	return nil
+}
+
+// Subscription delivers the events of a streaming request on a channel.
+// It ends when its context is done, on Close, on a request ending error or when the session is lost.
+type Subscription[T any] struct {
+	streamLifecycle
+
+	events   chan T
+	overflow OverflowPolicy
+	dropped  atomic.Int64
+
+	mu     sync.Mutex // guards the sends on events against its closing
+	closed bool
+}
+
+func newSubscription[T any](reqID int64, config StreamConfig, streams *streamRegistry, cancel func()) *Subscription[T] {
+	s := &Subscription[T]{
+		streamLifecycle: streamLifecycle{ReqID: reqID, streams: streams, cancel: cancel, done: make(chan struct{})},
+		events:          make(chan T, max(config.Buffer, 1)),
+		overflow:        config.Overflow,
+	}
+	s.stop = func() {
+		s.mu.Lock()
+		s.closed = true
+		close(s.events)
+		s.mu.Unlock()
+	}
+	return s
+}
+
+// Events returns the channel of the events, closed when the subscription ends.
+func (s *Subscription[T]) Events() <-chan T {
+	return s.events
+}
+
+// Dropped returns the number of events lost to overflows.
+func (s *Subscription[T]) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// push delivers an event without ever blocking the decoder goroutine.
+func (s *Subscription[T]) push(event T) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	select {
+	case s.events <- event:
+		s.mu.Unlock()
+		return
+	default:
+	}
+	switch s.overflow {
+	case OverflowDropNewest:
+		s.dropped.Add(1)
+		s.mu.Unlock()
+	case OverflowClose:
+		s.mu.Unlock()
+		s.end(ErrSlowConsumer, true)
+	default:
+		select {
+		case <-s.events:
+		default:
+		}
+		select {
+		case s.events <- event:
+		default:
+		}
+		s.dropped.Add(1)
+		s.mu.Unlock()
+	}
+}
+
+// streamRegistry routes the streaming callbacks to their subscriptions.
+type streamRegistry struct {
+	mu      sync.RWMutex
+	byReqID map[int64]failer
+}
+
+func newStreamRegistry() *streamRegistry {
+	return &streamRegistry{byReqID: make(map[int64]failer)}
+}
+
+func (r *streamRegistry) add(reqID int64, s failer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byReqID[reqID] = s
+}
+
+func (r *streamRegistry) remove(reqID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.byReqID, reqID)
+}
+
+// stream returns the subscription of type T fed by reqID, nil if there is none.
+func stream[T any](r *streamRegistry, reqID int64) *Subscription[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, _ := r.byReqID[reqID].(*Subscription[T])
+	return s
+}
+
+// fail ends the subscription fed by reqID and tells whether there was one.
+func (r *streamRegistry) fail(reqID int64, err error) bool {
+	r.mu.RLock()
+	s, ok := r.byReqID[reqID]
+	r.mu.RUnlock()
+	if ok {
+		s.fail(err)
+	}
+	return ok
+}
+
+// failAll ends every subscription.
+func (r *streamRegistry) failAll(err error) {
+	if r == nil {
+		return
+	}
+	r.mu.RLock()
+	subs := make([]failer, 0, len(r.byReqID))
+	for _, s := range r.byReqID {
+		subs = append(subs, s)
+	}
+	r.mu.RUnlock()
+	for _, s := range subs {
+		s.fail(err)
+	}
+}
+
+// subscribe starts a streaming request and ends it with ctx.
+func subscribe[T any](ctx context.Context, c *EClient, req func(reqID int64) error, cancel func(reqID int64)) (*Subscription[T], error) {
+	reqID := c.calls.allocReqID()
+	s := newSubscription[T](reqID, c.streamConfig, c.streams, func() { cancel(reqID) })
+	if err := s.start(ctx, s, req); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SubscribeMarketData streams the market data of contract. See ReqMktData for genericTickList.
+func (c *EClient) SubscribeMarketData(ctx context.Context, contract *Contract, genericTickList string) (*Subscription[TickEvent], error) {
+	return subscribe[TickEvent](ctx, c, func(reqID int64) error {
+		return c.ReqMktDataContext(ctx, reqID, contract, genericTickList, false, false, nil)
+	}, c.CancelMktData)
+}
+
+// SubscribeTickByTick streams the tick-by-tick data of contract. See ReqTickByTickData for the parameters.
+func (c *EClient) SubscribeTickByTick(ctx context.Context, contract *Contract, tickType string, numberOfTicks int64, ignoreSize bool) (*Subscription[TickByTickEvent], error) {
+	return subscribe[TickByTickEvent](ctx, c, func(reqID int64) error {
+		return c.ReqTickByTickDataContext(ctx, reqID, contract, tickType, numberOfTicks, ignoreSize)
+	}, c.CancelTickByTickData)
+}
+
+// SubscribeRealTimeBars streams the 5 seconds real time bars of contract.
+func (c *EClient) SubscribeRealTimeBars(ctx context.Context, contract *Contract, whatToShow string, useRTH bool) (*Subscription[RealTimeBar], error) {
+	return subscribe[RealTimeBar](ctx, c, func(reqID int64) error {
+		return c.ReqRealTimeBarsContext(ctx, reqID, contract, 5, whatToShow, useRTH, nil)
+	}, c.CancelRealTimeBars)
+}
+
+// SubscribeMarketDepth streams the market depth of contract.
+func (c *EClient) SubscribeMarketDepth(ctx context.Context, contract *Contract, numRows int, isSmartDepth bool) (*Subscription[DepthEvent], error) {
+	return subscribe[DepthEvent](ctx, c, func(reqID int64) error {
+		return c.ReqMktDepthContext(ctx, reqID, contract, numRows, isSmartDepth, nil)
+	}, func(reqID int64) {
+		c.CancelMktDepth(reqID, isSmartDepth)
+	})
+}
+
+// SubscribeHistoricalBars streams the historical bars of contract up to now, then keeps them up to date.
+// See ReqHistoricalData for the parameters.
+func (c *EClient) SubscribeHistoricalBars(ctx context.Context, contract *Contract, duration string, barSize string, whatToShow string, useRTH bool, formatDate int) (*Subscription[BarEvent], error) {
+	return subscribe[BarEvent](ctx, c, func(reqID int64) error {
+		return c.ReqHistoricalDataContext(ctx, reqID, contract, "", duration, barSize, whatToShow, useRTH, formatDate, true, nil)
+	}, c.CancelHistoricalData)
+}
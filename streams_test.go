@@ -0,0 +1,166 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func nextEvent[T any](t *testing.T, s *Subscription[T]) T {
+	t.Helper()
+	select {
+	case ev, ok := <-s.Events():
+		if !ok {
+			t.Fatalf("subscription ended: %v", s.Err())
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for an event")
+		var zero T
+		return zero
+	}
+}
+
+func awaitDone[T any](t *testing.T, s *Subscription[T]) {
+	t.Helper()
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the subscription to end")
+	}
+}
+
+func TestStreamMarketData(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := ib.SubscribeMarketData(ctx, &Contract{Symbol: "IBM"}, "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	req := gw.nextRequest(t, REQ_MKT_DATA)
+	if reqID, _ := strconv.ParseInt(string(req[2]), 10, 64); reqID != sub.ReqID {
+		t.Fatalf("requested reqID %d, subscription %d", reqID, sub.ReqID)
+	}
+	writeFrame(conn, TICK_PRICE, 6, sub.ReqID, BID, 150.5, 300, 0)
+	writeFrame(conn, TICK_STRING, 6, sub.ReqID, LAST_TIMESTAMP, "1760000000")
+
+	if ev, ok := nextEvent(t, sub).(TickPriceEvent); !ok || ev.TickType != BID || ev.Price != 150.5 {
+		t.Errorf("unexpected price event %+v", ev)
+	}
+	if ev, ok := nextEvent(t, sub).(TickSizeEvent); !ok || ev.TickType != BID_SIZE || ev.Size != StringToDecimal("300") {
+		t.Errorf("unexpected size event %+v", ev)
+	}
+	if ev, ok := nextEvent(t, sub).(TickStringEvent); !ok || ev.Value != "1760000000" {
+		t.Errorf("unexpected string event %+v", ev)
+	}
+
+	cancel()
+	awaitDone(t, sub)
+	if sub.Err() != nil {
+		t.Errorf("unexpected error %v", sub.Err())
+	}
+	if cancelReq := gw.nextRequest(t, CANCEL_MKT_DATA); string(cancelReq[2]) != string(req[2]) {
+		t.Errorf("cancelled reqID %s, requested %s", cancelReq[2], req[2])
+	}
+}
+
+func TestStreamHistoricalBars(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	sub, err := ib.SubscribeHistoricalBars(context.Background(), &Contract{Symbol: "IBM"}, "1 D", "1 min", "TRADES", true, 1)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	gw.nextRequest(t, REQ_HISTORICAL_DATA)
+	writeFrame(conn, HISTORICAL_DATA, sub.ReqID, 1, "20261016 10:00:00", 1, 2, 0.5, 1.5, 100, 1.2, 10)
+	writeFrame(conn, HISTORICAL_DATA_END, sub.ReqID, "20261016 09:00:00", "20261016 10:01:00")
+	writeFrame(conn, HISTORICAL_DATA_UPDATE, sub.ReqID, 11, "20261016 10:01:00", 1.5, 1.6, 1.7, 1.4, 1.55, 20)
+
+	if ev := nextEvent(t, sub); ev.Update || ev.Bar.Close != 1.5 {
+		t.Errorf("unexpected bar %+v", ev)
+	}
+	if ev := nextEvent(t, sub); !ev.Update || ev.Bar.Close != 1.6 || ev.Bar.BarCount != 11 {
+		t.Errorf("unexpected update %+v", ev)
+	}
+
+	sub.Close()
+	gw.nextRequest(t, CANCEL_HISTORICAL_DATA)
+}
+
+func TestStreamError(t *testing.T) {
+	ib, gw, conn, w := connectSync(t)
+
+	sub, err := ib.SubscribeMarketData(context.Background(), &Contract{Symbol: "NOPE"}, "")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	gw.nextRequest(t, REQ_MKT_DATA)
+	writeFrame(conn, ERR_MSG, sub.ReqID, 200, "No security definition has been found for the request", "", 0)
+
+	awaitDone(t, sub)
+	if !errors.Is(sub.Err(), ErrNoSecurityDefinition) {
+		t.Errorf("expected ErrNoSecurityDefinition, got %v", sub.Err())
+	}
+	if codes := w.recorded(); len(codes) != 0 {
+		t.Errorf("unexpected errors forwarded to EWrapper %v", codes)
+	}
+}
+
+func TestStreamSessionLost(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	sub, err := ib.SubscribeRealTimeBars(context.Background(), &Contract{Symbol: "IBM"}, "TRADES", true)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	gw.nextRequest(t, REQ_REAL_TIME_BARS)
+	conn.Close()
+
+	awaitDone(t, sub)
+	if !errors.Is(sub.Err(), NOT_CONNECTED) {
+		t.Errorf("expected NOT_CONNECTED, got %v", sub.Err())
+	}
+}
+
+func TestStreamOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		policy  OverflowPolicy
+		want    []int
+		dropped int64
+		err     error
+	}{
+		{OverflowDropOldest, []int{2, 3}, 2, nil},
+		{OverflowDropNewest, []int{0, 1}, 2, nil},
+		{OverflowClose, []int{0, 1}, 0, ErrSlowConsumer},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			cancelled := make(chan struct{})
+			s := newSubscription[int](1, StreamConfig{Buffer: 2, Overflow: tc.policy}, newStreamRegistry(), func() { close(cancelled) })
+			for i := range 4 {
+				s.push(i)
+			}
+			if tc.err != nil {
+				awaitDone(t, s)
+				<-cancelled
+			}
+			var got []int
+			for range len(tc.want) {
+				got = append(got, <-s.Events())
+			}
+			for i := range tc.want {
+				if got[i] != tc.want[i] {
+					t.Fatalf("got %v, want %v", got, tc.want)
+				}
+			}
+			if s.Dropped() != tc.dropped {
+				t.Errorf("dropped %d, want %d", s.Dropped(), tc.dropped)
+			}
+			if !errors.Is(s.Err(), tc.err) {
+				t.Errorf("got error %v, want %v", s.Err(), tc.err)
+			}
+		})
+	}
+}
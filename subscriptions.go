@@ -0,0 +1,177 @@
+package ibapi
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SubscriptionKind identifies the streaming request recorded by the subscription registry.
+type SubscriptionKind int
+
+const (
+	SubscriptionMktData SubscriptionKind = iota
+	SubscriptionMktDepth
+	SubscriptionRealTimeBars
+	SubscriptionTickByTick
+	SubscriptionPnL
+	SubscriptionPnLSingle
+	SubscriptionAccountSummary
+	SubscriptionPositionsMulti
+	SubscriptionHistoricalData
+)
+
+func (sk SubscriptionKind) String() string {
+	switch sk {
+	case SubscriptionMktData:
+		return "MktData"
+	case SubscriptionMktDepth:
+		return "MktDepth"
+	case SubscriptionRealTimeBars:
+		return "RealTimeBars"
+	case SubscriptionTickByTick:
+		return "TickByTick"
+	case SubscriptionPnL:
+		return "PnL"
+	case SubscriptionPnLSingle:
+		return "PnLSingle"
+	case SubscriptionAccountSummary:
+		return "AccountSummary"
+	case SubscriptionPositionsMulti:
+		return "PositionsMulti"
+	case SubscriptionHistoricalData:
+		return "HistoricalData"
+	default:
+		return "unknown subscription kind"
+	}
+}
+
+// ActiveSubscription is a live streaming request recorded by the subscription registry.
+type ActiveSubscription struct {
+	Kind     SubscriptionKind
+	ReqID    int64
+	Contract *Contract // nil for account level subscriptions
+	Args     []any     // the other request arguments, in call order
+	Since    time.Time // last time the request was issued
+	replay   func(c *EClient)
+}
+
+// subscriptionRegistry records the active streaming requests so they can be replayed on the same reqIDs
+// after a session rebuild or a 1101 "connectivity restored, data lost" error.
+type subscriptionRegistry struct {
+	mu   sync.Mutex
+	subs map[int64]*ActiveSubscription
+}
+
+func newSubscriptionRegistry() *subscriptionRegistry {
+	return &subscriptionRegistry{subs: make(map[int64]*ActiveSubscription)}
+}
+
+// record adds or replaces the subscription for reqID. It is a no-op on a nil registry.
+// The request methods call it once the request is queued: a request refused client side is never replayed.
+// contract must not be shared with the caller, who may modify it afterwards.
+func (r *subscriptionRegistry) record(kind SubscriptionKind, reqID int64, contract *Contract, replay func(c *EClient), args ...any) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subs[reqID] = &ActiveSubscription{
+		Kind:     kind,
+		ReqID:    reqID,
+		Contract: contract,
+		Args:     args,
+		Since:    time.Now(),
+		replay:   replay,
+	}
+}
+
+// remove drops the subscription of the given kind for reqID. It is a no-op on a nil registry.
+func (r *subscriptionRegistry) remove(kind SubscriptionKind, reqID int64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sub, ok := r.subs[reqID]; ok && sub.Kind == kind {
+		delete(r.subs, reqID)
+	}
+}
+
+// onError drops the subscription TWS stopped serving.
+func (r *subscriptionRegistry) onError(reqID int64, errCode int64) {
+	if r == nil || reqID == NO_VALID_ID {
+		return
+	}
+	if info, _ := LookupError(errCode); info.Severity < SeverityRequestFatal {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subs[reqID]; ok {
+		log.Debug().Int64("reqID", reqID).Int64("errCode", errCode).Msg("subscription ended by error")
+		delete(r.subs, reqID)
+	}
+}
+
+// list returns a copy of the active subscriptions ordered by reqID.
+func (r *subscriptionRegistry) list() []ActiveSubscription {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subs := make([]ActiveSubscription, 0, len(r.subs))
+	for _, reqID := range slices.Sorted(maps.Keys(r.subs)) {
+		subs = append(subs, *r.subs[reqID])
+	}
+	return subs
+}
+
+func (r *subscriptionRegistry) clear() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.subs)
+}
+
+// replay re-issues every active subscription on its original reqID.
+func (r *subscriptionRegistry) replay(c *EClient) {
+	subs := r.list()
+	if len(subs) == 0 {
+		return
+	}
+	log.Info().Int("count", len(subs)).Msg("replaying subscriptions")
+	for _, sub := range subs {
+		log.Debug().Stringer("kind", sub.Kind).Int64("reqID", sub.ReqID).Msg("replaying subscription")
+		sub.replay(c)
+	}
+}
+
+// SetSubscriptionTracking enables or disables the subscription registry.
+// When enabled, EClient records every active streaming request with its arguments, forgets it on the matching Cancel
+// call or on a request ending error, and replays the whole set on the same reqIDs after the session is rebuilt
+// or after TWS reports 1101 "Connectivity between IB and TWS has been restored - data lost".
+// It must be called before Connect.
+func (c *EClient) SetSubscriptionTracking(enabled bool) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	switch {
+	case enabled && c.subscriptions == nil:
+		c.subscriptions = newSubscriptionRegistry()
+	case !enabled:
+		c.subscriptions = nil
+	}
+}
+
+// Subscriptions returns the active streaming requests recorded by the subscription registry, ordered by reqID.
+func (c *EClient) Subscriptions() []ActiveSubscription {
+	return c.subscriptions.list()
+}
@@ -0,0 +1,130 @@
+package ibapi
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionRegistry(t *testing.T) {
+	ib := NewEClient(nil)
+	ib.SetSubscriptionTracking(true)
+	r := ib.subscriptions
+
+	contract := &Contract{Symbol: "AAPL", SecType: "STK", Exchange: "SMART", Currency: "USD"}
+	r.record(SubscriptionMktData, 2, contract, nil, "233", nil)
+	r.record(SubscriptionPnL, 1, nil, nil, "DU123", "")
+	r.record(SubscriptionRealTimeBars, 3, contract, nil, 5, "TRADES", true, nil)
+
+	subs := ib.Subscriptions()
+	if len(subs) != 3 {
+		t.Fatalf("expected 3 subscriptions, got %d", len(subs))
+	}
+	for i, sub := range subs {
+		if sub.ReqID != int64(i+1) {
+			t.Errorf("subscriptions not ordered by reqID: %v", subs)
+		}
+	}
+
+	// A cancel of another kind must not drop the subscription
+	r.remove(SubscriptionMktDepth, 2)
+	r.remove(SubscriptionPnL, 1)
+	if len(ib.Subscriptions()) != 2 {
+		t.Errorf("expected 2 subscriptions after cancel, got %d", len(ib.Subscriptions()))
+	}
+
+	// No security definition has been found ends the request
+	r.onError(3, 200)
+	// Market data farm connection is OK is only informational
+	r.onError(2, 2104)
+	subs = ib.Subscriptions()
+	if len(subs) != 1 || subs[0].Kind != SubscriptionMktData {
+		t.Errorf("expected only the market data subscription, got %v", subs)
+	}
+
+	ib.SetSubscriptionTracking(false)
+	if ib.Subscriptions() != nil {
+		t.Error("expected no subscriptions once tracking is disabled")
+	}
+}
+
+func TestSubscriptionRecordedOnceQueued(t *testing.T) {
+	ib := NewEClient(nil)
+	ib.SetSubscriptionTracking(true)
+
+	// Refused client side
+	contract := &Contract{Symbol: "AAPL", SecType: "STK", Exchange: "SMART", Currency: "USD"}
+	if err := ib.ReqMktDataContext(context.Background(), 1, contract, "", false, false, nil); err == nil {
+		t.Fatal("expected a not connected error")
+	}
+	if subs := ib.Subscriptions(); len(subs) != 0 {
+		t.Fatalf("expected no subscription, got %v", subs)
+	}
+
+	// The caller may reuse its contract
+	ib, gw, _, rec := connectSync(t, func(ib *EClient) { ib.SetSubscriptionTracking(true) })
+	contract.ComboLegs = []ComboLeg{{ConID: 1, Ratio: 1}}
+	contract.DeltaNeutralContract = &DeltaNeutralContract{ConID: 1}
+	if err := ib.ReqMktDataContext(context.Background(), 2, contract, "", false, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	gw.nextRequest(t, REQ_MKT_DATA)
+	contract.Symbol = "IBM"
+	contract.ComboLegs[0].ConID = 2
+	contract.DeltaNeutralContract.ConID = 2
+	subs := ib.Subscriptions()
+	if len(subs) != 1 || subs[0].Contract.Symbol != "AAPL" || subs[0].Contract.ComboLegs[0].ConID != 1 || subs[0].Contract.DeltaNeutralContract.ConID != 1 {
+		t.Errorf("unexpected subscriptions %v", subs)
+	}
+
+	// Tracking cannot be toggled while connected
+	ib.SetSubscriptionTracking(false)
+	if codes := rec.recorded(); len(codes) != 1 || codes[0] != ALREADY_CONNECTED.Code {
+		t.Errorf("expected ALREADY_CONNECTED, got %v", codes)
+	}
+	if len(ib.Subscriptions()) != 1 {
+		t.Error("tracking disabled while connected")
+	}
+}
+
+func TestSubscriptionReplay(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	ib := NewEClient(&sessionWrapper{})
+	ib.SetReconnectPolicy(&ReconnectPolicy{InitialDelay: 10 * time.Millisecond, HandshakeTimeout: time.Second})
+	ib.SetSubscriptionTracking(true)
+
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+	first := gw.nextSession(t)
+
+	contract := &Contract{Symbol: "AAPL", SecType: "STK", Exchange: "SMART", Currency: "USD"}
+	ib.ReqMktData(7, contract, "", false, false, nil)
+	ib.ReqMktData(8, contract, "", true, false, nil) // snapshots are not recorded
+	ib.ReqPnL(9, "DU123", "")
+	ib.CancelPnL(9)
+	gw.nextRequest(t, CANCEL_PNL)
+
+	if subs := ib.Subscriptions(); len(subs) != 1 || subs[0].ReqID != 7 {
+		t.Fatalf("expected the market data subscription only, got %v", subs)
+	}
+
+	// Data farm loss
+	writeFrame(first, ERR_MSG, NO_VALID_ID, 1101, "Connectivity between IB and TWS has been restored - data lost.", "", currentTimeMillis())
+	if reqID := string(gw.nextRequest(t, REQ_MKT_DATA)[2]); reqID != "7" {
+		t.Errorf("expected replay on reqID 7, got %s", reqID)
+	}
+
+	// Session loss
+	first.Close()
+	second := gw.nextSession(t)
+	defer second.Close()
+	fields := gw.nextRequest(t, REQ_MKT_DATA)
+	if reqID, _ := strconv.ParseInt(string(fields[2]), 10, 64); reqID != 7 {
+		t.Errorf("expected replay on reqID 7, got %d", reqID)
+	}
+}
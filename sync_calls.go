@@ -0,0 +1,318 @@
+package ibapi
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// SYNC_REQ_ID_BASE is the first reqID allocated to the blocking calls and the streaming subscriptions.
+// Keep the reqIDs of your own requests below it.
+const SYNC_REQ_ID_BASE int64 = 1 << 30
+
+// Position is a position returned by Positions.
+type Position struct {
+	Account  string
+	Contract *Contract
+	Position Decimal
+	AvgCost  float64
+}
+
+// CompletedOrder is an order returned by CompletedOrders.
+type CompletedOrder struct {
+	Contract   *Contract
+	Order      *Order
+	OrderState *OrderState
+}
+
+// SecDefOptParams is an option chain returned by SecDefOptParams, one per exchange.
+type SecDefOptParams struct {
+	Exchange        string
+	UnderlyingConID int64
+	TradingClass    string
+	Multiplier      string
+	Expirations     []string
+	Strikes         []float64
+}
+
+// pendingCall collects the answers to a blocking call until its end or its failure.
+type pendingCall[T any] struct {
+	mu    sync.Mutex
+	items []T
+	err   error
+	done  chan struct{}
+	once  sync.Once
+}
+
+func newPendingCall[T any]() *pendingCall[T] {
+	return &pendingCall[T]{done: make(chan struct{})}
+}
+
+func (p *pendingCall[T]) add(item T) {
+	p.mu.Lock()
+	p.items = append(p.items, item)
+	p.mu.Unlock()
+}
+
+func (p *pendingCall[T]) end() {
+	p.once.Do(func() { close(p.done) })
+}
+
+func (p *pendingCall[T]) fail(err error) {
+	p.once.Do(func() {
+		p.mu.Lock()
+		p.err = err
+		p.mu.Unlock()
+		close(p.done)
+	})
+}
+
+func (p *pendingCall[T]) result() ([]T, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.items, p.err
+}
+
+// failer is the part of a pendingCall independent of its answers.
+type failer interface {
+	fail(err error)
+}
+
+// pendingCalls routes the callbacks to the blocking calls waiting for them.
+type pendingCalls struct {
+	mu              sync.Mutex
+	nextReqID       int64
+	byReqID         map[int64]failer
+	positions       *pendingCall[Position]
+	completedOrders *pendingCall[CompletedOrder]
+	positionsSem    chan struct{}
+	completedSem    chan struct{}
+	// positionsSubscribed is set while a ReqPositions subscription of the user is active, Positions must leave it running.
+	positionsSubscribed atomic.Bool
+}
+
+func newPendingCalls() *pendingCalls {
+	return &pendingCalls{
+		nextReqID:    SYNC_REQ_ID_BASE,
+		byReqID:      make(map[int64]failer),
+		positionsSem: make(chan struct{}, 1),
+		completedSem: make(chan struct{}, 1),
+	}
+}
+
+// register allocates a reqID to the call.
+func (p *pendingCalls) register(call failer) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reqID := p.nextReqID
+	p.nextReqID++
+	p.byReqID[reqID] = call
+	return reqID
+}
+
+// allocReqID allocates a reqID above SYNC_REQ_ID_BASE without registering a call.
+func (p *pendingCalls) allocReqID() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	reqID := p.nextReqID
+	p.nextReqID++
+	return reqID
+}
+
+func (p *pendingCalls) remove(reqID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.byReqID, reqID)
+}
+
+// pending returns the call of type T waiting for reqID, nil if there is none.
+func pending[T any](p *pendingCalls, reqID int64) *pendingCall[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call, _ := p.byReqID[reqID].(*pendingCall[T])
+	return call
+}
+
+// finish removes the call of type T waiting for reqID and returns it, nil if there is none.
+func finish[T any](p *pendingCalls, reqID int64) *pendingCall[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	call, ok := p.byReqID[reqID].(*pendingCall[T])
+	if ok {
+		delete(p.byReqID, reqID)
+	}
+	return call
+}
+
+// fail fails the call waiting for reqID and tells whether there was one.
+func (p *pendingCalls) fail(reqID int64, err error) bool {
+	p.mu.Lock()
+	call, ok := p.byReqID[reqID]
+	delete(p.byReqID, reqID)
+	p.mu.Unlock()
+	if ok {
+		call.fail(err)
+	}
+	return ok
+}
+
+// failAll fails every pending call, the session carrying them is gone.
+func (p *pendingCalls) failAll(err error) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	calls := make([]failer, 0, len(p.byReqID)+2)
+	for reqID, call := range p.byReqID {
+		calls = append(calls, call)
+		delete(p.byReqID, reqID)
+	}
+	if p.positions != nil {
+		calls = append(calls, p.positions)
+		p.positions = nil
+	}
+	if p.completedOrders != nil {
+		calls = append(calls, p.completedOrders)
+		p.completedOrders = nil
+	}
+	p.mu.Unlock()
+	for _, call := range calls {
+		call.fail(err)
+	}
+}
+
+func (p *pendingCalls) positionsCall() *pendingCall[Position] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.positions
+}
+
+func (p *pendingCalls) completedOrdersCall() *pendingCall[CompletedOrder] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.completedOrders
+}
+
+// call runs a blocking request identified by a reqID and waits for its answers.
+// cancel, if not nil, cancels the request server side when ctx is done first.
+func call[T any](ctx context.Context, c *EClient, req func(reqID int64) error, cancel func(reqID int64)) ([]T, error) {
+	pc := newPendingCall[T]()
+	reqID := c.calls.register(pc)
+	if err := req(reqID); err != nil {
+		c.calls.remove(reqID)
+		return nil, err
+	}
+	select {
+	case <-pc.done:
+		return pc.result()
+	case <-ctx.Done():
+		c.calls.remove(reqID)
+		if cancel != nil {
+			cancel(reqID)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// single returns the only answer of a blocking call.
+func single[T any](items []T, err error) (T, error) {
+	var zero T
+	if err != nil || len(items) == 0 {
+		return zero, err
+	}
+	return items[0], nil
+}
+
+// ContractDetails returns the details of the contracts matching contract.
+func (c *EClient) ContractDetails(ctx context.Context, contract *Contract) ([]ContractDetails, error) {
+	return call[ContractDetails](ctx, c, func(reqID int64) error {
+		return c.ReqContractDetailsContext(ctx, reqID, contract)
+	}, func(reqID int64) {
+		if c.ServerVersion() >= MIN_SERVER_VER_CANCEL_CONTRACT_DATA {
+			c.CancelContractData(reqID)
+		}
+	})
+}
+
+// HistoricalBars returns the historical bars of contract. See ReqHistoricalData for the parameters.
+func (c *EClient) HistoricalBars(ctx context.Context, contract *Contract, endDateTime string, duration string, barSize string, whatToShow string, useRTH bool, formatDate int) ([]Bar, error) {
+	return call[Bar](ctx, c, func(reqID int64) error {
+		return c.ReqHistoricalDataContext(ctx, reqID, contract, endDateTime, duration, barSize, whatToShow, useRTH, formatDate, false, nil)
+	}, c.CancelHistoricalData)
+}
+
+// HeadTimestamp returns the timestamp of the earliest data available for contract.
+func (c *EClient) HeadTimestamp(ctx context.Context, contract *Contract, whatToShow string, useRTH bool, formatDate int) (string, error) {
+	return single(call[string](ctx, c, func(reqID int64) error {
+		return c.ReqHeadTimeStampContext(ctx, reqID, contract, whatToShow, useRTH, formatDate)
+	}, c.CancelHeadTimeStamp))
+}
+
+// MatchingSymbols returns the contracts matching pattern.
+func (c *EClient) MatchingSymbols(ctx context.Context, pattern string) ([]ContractDescription, error) {
+	return single(call[[]ContractDescription](ctx, c, func(reqID int64) error {
+		return c.ReqMatchingSymbolsContext(ctx, reqID, pattern)
+	}, nil))
+}
+
+// SecDefOptParams returns the option chains of an underlying, one per exchange.
+func (c *EClient) SecDefOptParams(ctx context.Context, underlyingSymbol string, futFopExchange string, underlyingSecurityType string, underlyingContractID int64) ([]SecDefOptParams, error) {
+	return call[SecDefOptParams](ctx, c, func(reqID int64) error {
+		return c.ReqSecDefOptParamsContext(ctx, reqID, underlyingSymbol, futFopExchange, underlyingSecurityType, underlyingContractID)
+	}, nil)
+}
+
+// Positions returns the positions of all accessible accounts, only one call runs at a time.
+// The positions subscription is cancelled once they are received, unless ReqPositions started one that is still active.
+func (c *EClient) Positions(ctx context.Context) ([]Position, error) {
+	return callWithoutReqID(ctx, c, c.calls.positionsSem, &c.calls.positions, c.reqPositions, func() {
+		if !c.calls.positionsSubscribed.Load() {
+			c.reportError(c.cancelPositions(context.Background()))
+		}
+	})
+}
+
+// CompletedOrders returns the completed orders, only one call runs at a time.
+func (c *EClient) CompletedOrders(ctx context.Context, apiOnly bool) ([]CompletedOrder, error) {
+	return callWithoutReqID(ctx, c, c.calls.completedSem, &c.calls.completedOrders, func(ctx context.Context) error {
+		return c.ReqCompletedOrdersContext(ctx, apiOnly)
+	}, nil)
+}
+
+// callWithoutReqID runs a blocking request without reqID, the semaphore keeps the answers of concurrent calls apart.
+func callWithoutReqID[T any](ctx context.Context, c *EClient, sem chan struct{}, slot **pendingCall[T], req func(context.Context) error, cancel func()) ([]T, error) {
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-sem }()
+
+	pc := newPendingCall[T]()
+	c.calls.mu.Lock()
+	*slot = pc
+	c.calls.mu.Unlock()
+	defer func() {
+		c.calls.mu.Lock()
+		if *slot == pc {
+			*slot = nil
+		}
+		c.calls.mu.Unlock()
+	}()
+
+	if err := req(ctx); err != nil {
+		return nil, err
+	}
+	select {
+	case <-pc.done:
+		if cancel != nil {
+			cancel()
+		}
+		return pc.result()
+	case <-ctx.Done():
+		if cancel != nil {
+			cancel()
+		}
+		return nil, ctx.Err()
+	}
+}
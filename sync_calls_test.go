@@ -0,0 +1,198 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type syncResult[T any] struct {
+	value T
+	err   error
+}
+
+// async runs a blocking call in the background.
+func async[T any](fn func() (T, error)) chan syncResult[T] {
+	ch := make(chan syncResult[T], 1)
+	go func() {
+		v, err := fn()
+		ch <- syncResult[T]{v, err}
+	}()
+	return ch
+}
+
+func awaitResult[T any](t *testing.T, ch chan syncResult[T]) (T, error) {
+	t.Helper()
+	select {
+	case r := <-ch:
+		return r.value, r.err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the call")
+		var zero T
+		return zero, nil
+	}
+}
+
+// connectSync connects a client to a fake gateway, after applying the configuration setters to it.
+func connectSync(t *testing.T, setters ...func(*EClient)) (*EClient, *fakeGateway, net.Conn, *errorRecorder) {
+	t.Helper()
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+	w := &errorRecorder{}
+	ib := NewEClient(w)
+	for _, set := range setters {
+		set(ib)
+	}
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { ib.Disconnect() })
+	conn := gw.nextSession(t)
+	t.Cleanup(func() { conn.Close() })
+	gw.nextRequest(t, START_API)
+	return ib, gw, conn, w
+}
+
+func TestSyncHistoricalBars(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ch := async(func() ([]Bar, error) {
+		return ib.HistoricalBars(context.Background(), &Contract{Symbol: "IBM"}, "", "1 D", "1 hour", "TRADES", true, 1)
+	})
+	req := gw.nextRequest(t, REQ_HISTORICAL_DATA)
+	reqID, _ := strconv.ParseInt(string(req[1]), 10, 64)
+	if reqID < SYNC_REQ_ID_BASE {
+		t.Errorf("unexpected reqID %d", reqID)
+	}
+	writeFrame(conn, HISTORICAL_DATA, reqID, 2,
+		"20261016 10:00:00", 1, 2, 0.5, 1.5, 100, 1.2, 10,
+		"20261016 11:00:00", 1.5, 2.5, 1, 2, 200, 1.7, 20)
+	writeFrame(conn, HISTORICAL_DATA_END, reqID, "20261016 10:00:00", "20261016 12:00:00")
+
+	bars, err := awaitResult(t, ch)
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if len(bars) != 2 || bars[1].Close != 2 || bars[1].BarCount != 20 {
+		t.Errorf("unexpected bars %+v", bars)
+	}
+}
+
+func TestSyncHeadTimestampCancel(t *testing.T) {
+	ib, gw, _, _ := connectSync(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := async(func() (string, error) {
+		return ib.HeadTimestamp(ctx, &Contract{Symbol: "IBM"}, "TRADES", true, 1)
+	})
+	req := gw.nextRequest(t, REQ_HEAD_TIMESTAMP)
+	cancel()
+
+	if _, err := awaitResult(t, ch); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if cancelReq := gw.nextRequest(t, CANCEL_HEAD_TIMESTAMP); string(cancelReq[1]) != string(req[1]) {
+		t.Errorf("cancelled reqID %s, requested %s", cancelReq[1], req[1])
+	}
+}
+
+func TestSyncError(t *testing.T) {
+	ib, gw, conn, w := connectSync(t)
+
+	ch := async(func() ([]ContractDetails, error) {
+		return ib.ContractDetails(context.Background(), &Contract{Symbol: "NOPE"})
+	})
+	req := gw.nextRequest(t, REQ_CONTRACT_DATA)
+	// A warning does not end the call
+	writeFrame(conn, ERR_MSG, string(req[2]), 2176, "Warning", "", 0)
+	writeFrame(conn, ERR_MSG, string(req[2]), 200, "No security definition has been found for the request", "", 0)
+
+	_, err := awaitResult(t, ch)
+	if !errors.Is(err, ErrNoSecurityDefinition) {
+		t.Errorf("expected ErrNoSecurityDefinition, got %v", err)
+	}
+	waitFor(t, "warning forwarded", func() bool { return len(w.recorded()) == 1 })
+	if codes := w.recorded(); codes[0] != 2176 {
+		t.Errorf("unexpected errors forwarded to EWrapper %v", codes)
+	}
+}
+
+func TestSyncPositions(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ch := async(func() ([]Position, error) { return ib.Positions(context.Background()) })
+	gw.nextRequest(t, REQ_POSITIONS)
+	writeFrame(conn, POSITION_DATA, 3, "DU1", 8314, "IBM", "STK", "", 0, "", "", "NYSE", "USD", "IBM", "IBM", 100, 150.5)
+	writeFrame(conn, POSITION_END, 1)
+
+	positions, err := awaitResult(t, ch)
+	if err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if len(positions) != 1 || positions[0].Account != "DU1" || positions[0].Contract.Symbol != "IBM" || positions[0].AvgCost != 150.5 {
+		t.Errorf("unexpected positions %+v", positions)
+	}
+	gw.nextRequest(t, CANCEL_POSITIONS)
+}
+
+func TestSyncPositionsKeepsSubscription(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ib.ReqPositions()
+	gw.nextRequest(t, REQ_POSITIONS)
+	writeFrame(conn, POSITION_END, 1)
+
+	ch := async(func() ([]Position, error) { return ib.Positions(context.Background()) })
+	gw.nextRequest(t, REQ_POSITIONS)
+	writeFrame(conn, POSITION_END, 1)
+	if _, err := awaitResult(t, ch); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+
+	// The subscription of the user is left running
+	ib.ReqCurrentTime()
+	if fields := gw.nextFrame(t); string(fields[0]) != strconv.Itoa(int(REQ_CURRENT_TIME)) {
+		t.Fatalf("unexpected request %s after Positions", fields[0])
+	}
+	ib.CancelPositions()
+	gw.nextRequest(t, CANCEL_POSITIONS)
+	ch = async(func() ([]Position, error) { return ib.Positions(context.Background()) })
+	gw.nextRequest(t, REQ_POSITIONS)
+	writeFrame(conn, POSITION_END, 1)
+	if _, err := awaitResult(t, ch); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	gw.nextRequest(t, CANCEL_POSITIONS)
+}
+
+func TestSyncUnknownError(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ch := async(func() ([]ContractDetails, error) {
+		return ib.ContractDetails(context.Background(), &Contract{Symbol: "IBM"})
+	})
+	req := gw.nextRequest(t, REQ_CONTRACT_DATA)
+	writeFrame(conn, ERR_MSG, string(req[2]), 99123, "A rejection missing from the catalog", "", 0)
+
+	var ibErr *IBError
+	if _, err := awaitResult(t, ch); !errors.As(err, &ibErr) || ibErr.Code != 99123 {
+		t.Errorf("expected the uncatalogued error, got %v", err)
+	}
+}
+
+func TestSyncSessionLost(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	ch := async(func() ([]SecDefOptParams, error) {
+		return ib.SecDefOptParams(context.Background(), "IBM", "", "STK", 8314)
+	})
+	gw.nextRequest(t, REQ_SEC_DEF_OPT_PARAMS)
+	conn.Close()
+
+	if _, err := awaitResult(t, ch); !errors.Is(err, NOT_CONNECTED) {
+		t.Errorf("expected NOT_CONNECTED, got %v", err)
+	}
+}
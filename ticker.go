@@ -0,0 +1,368 @@
+package ibapi
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Market data types reported by MarketDataType.
+const (
+	MARKET_DATA_TYPE_REALTIME       int64 = 1
+	MARKET_DATA_TYPE_FROZEN         int64 = 2
+	MARKET_DATA_TYPE_DELAYED        int64 = 3
+	MARKET_DATA_TYPE_DELAYED_FROZEN int64 = 4
+)
+
+// OptionGreeks is an option computation of TickOptionComputation.
+// TWS sends -1, -2 or math.MaxFloat64 for the values it could not compute.
+type OptionGreeks struct {
+	TickAttrib int64 // 0 return based, 1 price based
+	ImpliedVol float64
+	Delta      float64
+	OptPrice   float64
+	PvDividend float64
+	Gamma      float64
+	Vega       float64
+	Theta      float64
+	UndPrice   float64
+}
+
+// RTVolume is a parsed RT_VOLUME or RT_TRD_VOLUME tick.
+type RTVolume struct {
+	Price       float64 // 0 for a volume only update
+	Size        Decimal
+	Time        time.Time
+	TotalVolume Decimal
+	VWAP        float64
+	SingleTrade bool // filled by a single market maker
+}
+
+// ParseRTVolume parses the "price;size;time;totalVolume;VWAP;singleTrade" string of the RT_VOLUME ticks.
+func ParseRTVolume(s string) (RTVolume, bool) {
+	fields := strings.Split(s, ";")
+	if len(fields) < 6 {
+		return RTVolume{}, false
+	}
+	var v RTVolume
+	v.Price, _ = strconv.ParseFloat(fields[0], 64)
+	v.Size = StringToDecimal(fields[1])
+	ms, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return RTVolume{}, false
+	}
+	v.Time = time.UnixMilli(ms)
+	v.TotalVolume = StringToDecimal(fields[3])
+	v.VWAP, _ = strconv.ParseFloat(fields[4], 64)
+	v.SingleTrade = fields[5] == "true"
+	return v, true
+}
+
+// TickerSnapshot is the state of a Ticker at a point in time.
+// Delayed ticks update the same fields as the real time ones, MarketDataType tells which is which.
+type TickerSnapshot struct {
+	ReqID               int64
+	Contract            *Contract
+	MarketDataType      int64 // 0 until reported
+	MinTick             float64
+	BboExchange         string
+	SnapshotPermissions int64
+
+	Bid           float64
+	BidSize       Decimal
+	BidExchange   string
+	Ask           float64
+	AskSize       Decimal
+	AskExchange   string
+	Last          float64
+	LastSize      Decimal
+	LastExchange  string
+	LastTimestamp time.Time
+
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    Decimal
+	AvgVolume Decimal
+	MarkPrice float64
+
+	Halted          int64   // -1 unknown, 0 not halted, 1 general halt, 2 volatility halt
+	Shortable       float64 // above 2.5 at least 1000 shares can be shorted, above 1.5 a locate is needed
+	ShortableShares Decimal
+	RTVolume        RTVolume
+	RTTradeVolume   RTVolume
+
+	BidGreeks   *OptionGreeks
+	AskGreeks   *OptionGreeks
+	LastGreeks  *OptionGreeks
+	ModelGreeks *OptionGreeks
+
+	Generic map[TickType]float64   // other prices and generic ticks
+	Sizes   map[TickType]Decimal   // other sizes
+	Strings map[TickType]string    // other string ticks
+	Times   map[TickType]time.Time // last update of every tick type, delayed ticks under their real time type
+
+	Updated     time.Time
+	SnapshotEnd bool
+}
+
+// Delayed reports whether the data is delayed.
+func (s TickerSnapshot) Delayed() bool {
+	return s.MarketDataType == MARKET_DATA_TYPE_DELAYED || s.MarketDataType == MARKET_DATA_TYPE_DELAYED_FROZEN
+}
+
+// Frozen reports whether the data is the last recorded one, market closed.
+func (s TickerSnapshot) Frozen() bool {
+	return s.MarketDataType == MARKET_DATA_TYPE_FROZEN || s.MarketDataType == MARKET_DATA_TYPE_DELAYED_FROZEN
+}
+
+// Midpoint returns the middle of bid and ask, 0 without both.
+func (s TickerSnapshot) Midpoint() float64 {
+	if s.Bid <= 0 || s.Ask <= 0 {
+		return 0
+	}
+	return (s.Bid + s.Ask) / 2
+}
+
+// realTimeTicks maps the delayed tick types to their real time counterparts.
+var realTimeTicks = map[TickType]TickType{
+	DELAYED_BID:            BID,
+	DELAYED_ASK:            ASK,
+	DELAYED_LAST:           LAST,
+	DELAYED_BID_SIZE:       BID_SIZE,
+	DELAYED_ASK_SIZE:       ASK_SIZE,
+	DELAYED_LAST_SIZE:      LAST_SIZE,
+	DELAYED_HIGH:           HIGH,
+	DELAYED_LOW:            LOW,
+	DELAYED_VOLUME:         VOLUME,
+	DELAYED_CLOSE:          CLOSE,
+	DELAYED_OPEN:           OPEN,
+	DELAYED_BID_OPTION:     BID_OPTION_COMPUTATION,
+	DELAYED_ASK_OPTION:     ASK_OPTION_COMPUTATION,
+	DELAYED_LAST_OPTION:    LAST_OPTION_COMPUTATION,
+	DELAYED_MODEL_OPTION:   MODEL_OPTION,
+	DELAYED_LAST_TIMESTAMP: LAST_TIMESTAMP,
+	DELAYED_HALTED:         HALTED,
+}
+
+func realTimeTick(tickType TickType) TickType {
+	if rt, ok := realTimeTicks[tickType]; ok {
+		return rt
+	}
+	return tickType
+}
+
+// Ticker maintains the market data of a contract requested with ReqTicker.
+type Ticker struct {
+	streamLifecycle
+
+	mu      sync.Mutex
+	snap    TickerSnapshot
+	updates chan struct{}
+}
+
+func newTicker(reqID int64, contract *Contract, streams *streamRegistry, cancel func()) *Ticker {
+	return &Ticker{
+		streamLifecycle: streamLifecycle{ReqID: reqID, streams: streams, cancel: cancel, done: make(chan struct{})},
+		snap: TickerSnapshot{
+			ReqID:           reqID,
+			Contract:        contract,
+			BidSize:         UNSET_DECIMAL,
+			AskSize:         UNSET_DECIMAL,
+			LastSize:        UNSET_DECIMAL,
+			Volume:          UNSET_DECIMAL,
+			AvgVolume:       UNSET_DECIMAL,
+			Halted:          -1,
+			ShortableShares: UNSET_DECIMAL,
+			Generic:         make(map[TickType]float64),
+			Sizes:           make(map[TickType]Decimal),
+			Strings:         make(map[TickType]string),
+			Times:           make(map[TickType]time.Time),
+		},
+		updates: make(chan struct{}, 1),
+	}
+}
+
+// Snapshot returns a copy of the current state.
+func (t *Ticker) Snapshot() TickerSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := t.snap
+	s.Generic = maps.Clone(t.snap.Generic)
+	s.Sizes = maps.Clone(t.snap.Sizes)
+	s.Strings = maps.Clone(t.snap.Strings)
+	s.Times = maps.Clone(t.snap.Times)
+	return s
+}
+
+// Updates returns a channel signalled after changes, several changes may be signalled once.
+func (t *Ticker) Updates() <-chan struct{} {
+	return t.updates
+}
+
+// update applies fn to the state under lock and signals the change.
+func (t *Ticker) update(tickType TickType, fn func(s *TickerSnapshot)) {
+	now := time.Now()
+	t.mu.Lock()
+	fn(&t.snap)
+	if tickType != NOT_SET {
+		t.snap.Times[tickType] = now
+	}
+	t.snap.Updated = now
+	t.mu.Unlock()
+	select {
+	case t.updates <- struct{}{}:
+	default:
+	}
+}
+
+func (t *Ticker) tickPrice(tickType TickType, price float64) {
+	tickType = realTimeTick(tickType)
+	t.update(tickType, func(s *TickerSnapshot) {
+		switch tickType {
+		case BID:
+			s.Bid = price
+		case ASK:
+			s.Ask = price
+		case LAST:
+			s.Last = price
+		case OPEN:
+			s.Open = price
+		case HIGH:
+			s.High = price
+		case LOW:
+			s.Low = price
+		case CLOSE:
+			s.Close = price
+		case MARK_PRICE:
+			s.MarkPrice = price
+		default:
+			s.Generic[tickType] = price
+		}
+	})
+}
+
+func (t *Ticker) tickSize(tickType TickType, size Decimal) {
+	tickType = realTimeTick(tickType)
+	t.update(tickType, func(s *TickerSnapshot) {
+		switch tickType {
+		case BID_SIZE:
+			s.BidSize = size
+		case ASK_SIZE:
+			s.AskSize = size
+		case LAST_SIZE:
+			s.LastSize = size
+		case VOLUME:
+			s.Volume = size
+		case AVG_VOLUME:
+			s.AvgVolume = size
+		case SHORTABLE_SHARES:
+			s.ShortableShares = size
+		default:
+			s.Sizes[tickType] = size
+		}
+	})
+}
+
+func (t *Ticker) tickGeneric(tickType TickType, value float64) {
+	tickType = realTimeTick(tickType)
+	t.update(tickType, func(s *TickerSnapshot) {
+		switch tickType {
+		case HALTED:
+			s.Halted = int64(value)
+		case SHORTABLE:
+			s.Shortable = value
+		default:
+			s.Generic[tickType] = value
+		}
+	})
+}
+
+func (t *Ticker) tickString(tickType TickType, value string) {
+	tickType = realTimeTick(tickType)
+	t.update(tickType, func(s *TickerSnapshot) {
+		switch tickType {
+		case BID_EXCH:
+			s.BidExchange = value
+		case ASK_EXCH:
+			s.AskExchange = value
+		case LAST_EXCH:
+			s.LastExchange = value
+		case LAST_TIMESTAMP:
+			if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+				s.LastTimestamp = time.Unix(sec, 0)
+			}
+		case RT_VOLUME:
+			if v, ok := ParseRTVolume(value); ok {
+				s.RTVolume = v
+			}
+		case RT_TRD_VOLUME:
+			if v, ok := ParseRTVolume(value); ok {
+				s.RTTradeVolume = v
+			}
+		default:
+			s.Strings[tickType] = value
+		}
+	})
+}
+
+func (t *Ticker) tickOptionComputation(tickType TickType, greeks OptionGreeks) {
+	tickType = realTimeTick(tickType)
+	t.update(tickType, func(s *TickerSnapshot) {
+		switch tickType {
+		case BID_OPTION_COMPUTATION:
+			s.BidGreeks = &greeks
+		case ASK_OPTION_COMPUTATION:
+			s.AskGreeks = &greeks
+		case LAST_OPTION_COMPUTATION:
+			s.LastGreeks = &greeks
+		default:
+			s.ModelGreeks = &greeks
+		}
+	})
+}
+
+func (t *Ticker) tickReqParams(minTick float64, bboExchange string, snapshotPermissions int64) {
+	t.update(NOT_SET, func(s *TickerSnapshot) {
+		s.MinTick, s.BboExchange, s.SnapshotPermissions = minTick, bboExchange, snapshotPermissions
+	})
+}
+
+func (t *Ticker) marketDataType(marketDataType int64) {
+	t.update(NOT_SET, func(s *TickerSnapshot) {
+		s.MarketDataType = marketDataType
+	})
+}
+
+func (t *Ticker) snapshotEnd() {
+	t.update(NOT_SET, func(s *TickerSnapshot) {
+		s.SnapshotEnd = true
+	})
+	t.end(nil, false)
+}
+
+// ticker returns the ticker fed by reqID, nil if there is none.
+func ticker(r *streamRegistry, reqID int64) *Ticker {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, _ := r.byReqID[reqID].(*Ticker)
+	return t
+}
+
+// ReqTicker requests the market data of contract and maintains it in a Ticker. See ReqMktData for the parameters.
+// The market data is cancelled when ctx is done. A snapshot ticker stops by itself once complete.
+func (c *EClient) ReqTicker(ctx context.Context, contract *Contract, genericTickList string, snapshot bool) (*Ticker, error) {
+	reqID := c.calls.allocReqID()
+	t := newTicker(reqID, contract, c.streams, func() { c.CancelMktData(reqID) })
+	err := t.start(ctx, t, func(reqID int64) error {
+		return c.ReqMktDataContext(ctx, reqID, contract, genericTickList, snapshot, false, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
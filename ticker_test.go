@@ -0,0 +1,130 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func awaitTicker(t *testing.T, tk *Ticker) {
+	t.Helper()
+	select {
+	case <-tk.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the ticker to end")
+	}
+}
+
+func TestTickerUpdates(t *testing.T) {
+	tk := newTicker(1, &Contract{Symbol: "IBM"}, newStreamRegistry(), nil)
+
+	tk.marketDataType(MARKET_DATA_TYPE_DELAYED)
+	tk.tickPrice(DELAYED_BID, 150.5)
+	tk.tickSize(DELAYED_BID_SIZE, StringToDecimal("300"))
+	tk.tickPrice(ASK, 150.7)
+	tk.tickString(LAST_EXCH, "NYSE")
+	tk.tickString(DELAYED_LAST_TIMESTAMP, "1760000000")
+	tk.tickString(RT_VOLUME, "150.6;100;1760000000123;5000;150.55;true")
+	tk.tickGeneric(HALTED, 0)
+	tk.tickGeneric(SHORTABLE, 3)
+	tk.tickSize(SHORTABLE_SHARES, StringToDecimal("12000"))
+	tk.tickGeneric(OPTION_IMPLIED_VOL, 0.25)
+	tk.tickOptionComputation(DELAYED_MODEL_OPTION, OptionGreeks{ImpliedVol: 0.3, Delta: 0.5})
+
+	select {
+	case <-tk.Updates():
+	default:
+		t.Fatal("no update signalled")
+	}
+
+	s := tk.Snapshot()
+	if !s.Delayed() || s.Frozen() {
+		t.Errorf("unexpected market data type %d", s.MarketDataType)
+	}
+	if s.Bid != 150.5 || s.BidSize != StringToDecimal("300") || s.Ask != 150.7 || s.Midpoint() != (150.5+150.7)/2 {
+		t.Errorf("unexpected top of book %+v", s)
+	}
+	if s.LastExchange != "NYSE" || !s.LastTimestamp.Equal(time.Unix(1760000000, 0)) {
+		t.Errorf("unexpected last %q %v", s.LastExchange, s.LastTimestamp)
+	}
+	if s.RTVolume.Price != 150.6 || s.RTVolume.TotalVolume != StringToDecimal("5000") || !s.RTVolume.SingleTrade {
+		t.Errorf("unexpected RT volume %+v", s.RTVolume)
+	}
+	if s.Halted != 0 || s.Shortable != 3 || s.ShortableShares != StringToDecimal("12000") {
+		t.Errorf("unexpected stats %+v", s)
+	}
+	if s.Generic[OPTION_IMPLIED_VOL] != 0.25 || s.ModelGreeks == nil || s.ModelGreeks.Delta != 0.5 {
+		t.Errorf("unexpected option data %+v %+v", s.Generic, s.ModelGreeks)
+	}
+	if _, ok := s.Times[BID]; !ok {
+		t.Error("delayed bid not timestamped as bid")
+	}
+
+	// Snapshots are isolated from later updates
+	tk.tickGeneric(OPTION_IMPLIED_VOL, 0.5)
+	if s.Generic[OPTION_IMPLIED_VOL] != 0.25 {
+		t.Error("snapshot changed")
+	}
+}
+
+func TestTickerSession(t *testing.T) {
+	ib, gw, conn, w := connectSync(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tk, err := ib.ReqTicker(ctx, &Contract{Symbol: "IBM"}, "", false)
+	if err != nil {
+		t.Fatalf("ReqTicker: %v", err)
+	}
+	gw.nextRequest(t, REQ_MKT_DATA)
+	writeFrame(conn, TICK_PRICE, 6, tk.ReqID, LAST, 151.25, 200, 0)
+	writeFrame(conn, MARKET_DATA_TYPE, 1, tk.ReqID, MARKET_DATA_TYPE_FROZEN)
+
+	waitFor(t, "ticker updated", func() bool {
+		s := tk.Snapshot()
+		return s.Last == 151.25 && s.LastSize == StringToDecimal("200") && s.Frozen()
+	})
+
+	cancel()
+	awaitTicker(t, tk)
+	if tk.Err() != nil {
+		t.Errorf("unexpected error %v", tk.Err())
+	}
+	gw.nextRequest(t, CANCEL_MKT_DATA)
+	if codes := w.recorded(); len(codes) != 0 {
+		t.Errorf("unexpected errors forwarded to EWrapper %v", codes)
+	}
+}
+
+func TestTickerSnapshot(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	tk, err := ib.ReqTicker(context.Background(), &Contract{Symbol: "IBM"}, "", true)
+	if err != nil {
+		t.Fatalf("ReqTicker: %v", err)
+	}
+	gw.nextRequest(t, REQ_MKT_DATA)
+	writeFrame(conn, TICK_PRICE, 6, tk.ReqID, CLOSE, 149, 0, 0)
+	writeFrame(conn, TICK_SNAPSHOT_END, 1, tk.ReqID)
+
+	awaitTicker(t, tk)
+	if s := tk.Snapshot(); !s.SnapshotEnd || s.Close != 149 {
+		t.Errorf("unexpected snapshot %+v", s)
+	}
+}
+
+func TestTickerError(t *testing.T) {
+	ib, gw, conn, _ := connectSync(t)
+
+	tk, err := ib.ReqTicker(context.Background(), &Contract{Symbol: "NOPE"}, "", false)
+	if err != nil {
+		t.Fatalf("ReqTicker: %v", err)
+	}
+	gw.nextRequest(t, REQ_MKT_DATA)
+	writeFrame(conn, ERR_MSG, tk.ReqID, 200, "No security definition has been found for the request", "", 0)
+
+	awaitTicker(t, tk)
+	if !errors.Is(tk.Err(), ErrNoSecurityDefinition) {
+		t.Errorf("expected ErrNoSecurityDefinition, got %v", tk.Err())
+	}
+}
@@ -0,0 +1,533 @@
+package ibapi
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/robaho/fixed"
+)
+
+// Fill is an execution of an order with its commission report.
+type Fill struct {
+	Contract                *Contract
+	Execution               Execution
+	CommissionAndFeesReport *CommissionAndFeesReport // nil until received
+	Time                    time.Time                // local reception time
+}
+
+// TradeLogEntry is a status transition or an error of a trade.
+type TradeLogEntry struct {
+	Time      time.Time
+	Status    OrderStatus
+	Message   string
+	ErrorCode int64 // 0 for status transitions
+}
+
+// Trade is the state of an order assembled from the open orders, order statuses, executions, commission reports and errors.
+type Trade struct {
+	OrderID       int64
+	PermID        int64
+	ClientID      int64
+	Contract      *Contract
+	Order         *Order
+	OrderState    *OrderState
+	Status        OrderStatus
+	Filled        Decimal
+	Remaining     Decimal
+	AvgFillPrice  float64
+	LastFillPrice float64
+	WhyHeld       string
+	Fills         []Fill
+	Log           []TradeLogEntry
+}
+
+// IsActive reports whether the order is working or about to.
+func (t Trade) IsActive() bool {
+	return t.Status.IsActive()
+}
+
+// IsDone reports whether the order reached a terminal state.
+func (t Trade) IsDone() bool {
+	return t.Status.IsTerminal()
+}
+
+// Commission returns the sum of the commissions and fees received for the fills.
+func (t Trade) Commission() float64 {
+	var total float64
+	for _, f := range t.Fills {
+		if f.CommissionAndFeesReport != nil {
+			total += f.CommissionAndFeesReport.CommissionAndFees
+		}
+	}
+	return total
+}
+
+// FilledQuantity returns the quantity of the received fills.
+func (t Trade) FilledQuantity() Decimal {
+	total := fixed.ZERO
+	for _, f := range t.Fills {
+		if f.Execution.Shares != UNSET_DECIMAL {
+			total = total.Add(fixed.Fixed(f.Execution.Shares))
+		}
+	}
+	return Decimal(total)
+}
+
+// AvgPrice returns the average price of the received fills, 0 without fill.
+func (t Trade) AvgPrice() float64 {
+	var qty, value float64
+	for _, f := range t.Fills {
+		if f.Execution.Shares != UNSET_DECIMAL {
+			shares := f.Execution.Shares.Float()
+			qty += shares
+			value += shares * f.Execution.Price
+		}
+	}
+	if qty == 0 {
+		return 0
+	}
+	return value / qty
+}
+
+// clone returns a copy of the trade sharing nothing mutable with it.
+func (t *Trade) clone() Trade {
+	c := *t
+	if t.Contract != nil {
+		c.Contract = t.Contract.clone()
+	}
+	if t.Order != nil {
+		c.Order = t.Order.clone()
+	}
+	c.Fills = slices.Clone(t.Fills)
+	c.Log = slices.Clone(t.Log)
+	return c
+}
+
+// TradeEventKind tells what changed in a trade.
+type TradeEventKind int
+
+const (
+	TradeStatusChanged TradeEventKind = iota // new status
+	TradeOrderUpdated                        // new Contract, Order or OrderState from OpenOrder or CompletedOrder
+	TradeFilled                              // new fill
+	TradeCommission                          // commission report attached to a fill
+	TradeError                               // error about the order
+)
+
+func (k TradeEventKind) String() string {
+	switch k {
+	case TradeStatusChanged:
+		return "StatusChanged"
+	case TradeOrderUpdated:
+		return "OrderUpdated"
+	case TradeFilled:
+		return "Filled"
+	case TradeCommission:
+		return "Commission"
+	case TradeError:
+		return "Error"
+	default:
+		return "unknown trade event kind"
+	}
+}
+
+// TradeEvent is a change of a trade.
+type TradeEvent struct {
+	Kind  TradeEventKind
+	Trade Trade    // snapshot after the change
+	Fill  *Fill    // for TradeFilled and TradeCommission
+	Err   *IBError // for TradeError
+}
+
+type tradeKey struct {
+	clientID int64
+	orderID  int64
+}
+
+// TradeTracker follows the orders seen by the client.
+// The protobuf callbacks are followed through the regular ones the decoder calls after them.
+type TradeTracker struct {
+	c *EClient
+
+	mu          sync.Mutex
+	trades      map[tradeKey]*Trade
+	byPermID    map[int64]*Trade
+	byExecID    map[string]*Trade
+	commissions map[string]CommissionAndFeesReport // reports received before their execution
+	cancels     map[*Trade]OrderStatus             // status of the trades before their cancel request, restored if TWS refuses it
+
+	observers observerList[TradeEvent]
+}
+
+func newTradeTracker(c *EClient) *TradeTracker {
+	return &TradeTracker{
+		c:           c,
+		trades:      make(map[tradeKey]*Trade),
+		byPermID:    make(map[int64]*Trade),
+		byExecID:    make(map[string]*Trade),
+		commissions: make(map[string]CommissionAndFeesReport),
+		cancels:     make(map[*Trade]OrderStatus),
+	}
+}
+
+// SetTradeTracking enables or disables the trade tracker.
+// When enabled, the client assembles a Trade for every order it places or hears of.
+// It must be called before Connect.
+func (c *EClient) SetTradeTracking(enabled bool) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	switch {
+	case enabled && c.trades == nil:
+		c.trades = newTradeTracker(c)
+	case !enabled:
+		c.trades = nil
+	}
+}
+
+// Trades returns the trade tracker, nil when disabled.
+func (c *EClient) Trades() *TradeTracker {
+	return c.trades
+}
+
+// AddObserver registers fn to be called on every change of a trade, in order.
+// fn runs synchronously in the decoder goroutine, or the one placing or cancelling the order: it must return quickly.
+// The returned function unregisters fn.
+func (tt *TradeTracker) AddObserver(fn func(TradeEvent)) (remove func()) {
+	return tt.observers.add(fn)
+}
+
+// Trade returns the trade of an order placed by this client.
+func (tt *TradeTracker) Trade(orderID int64) (Trade, bool) {
+	if tt == nil {
+		return Trade{}, false
+	}
+	return tt.TradeOfClient(tt.c.Endpoint().ClientID, orderID)
+}
+
+// TradeOfClient returns the trade of an order placed by clientID.
+func (tt *TradeTracker) TradeOfClient(clientID int64, orderID int64) (Trade, bool) {
+	if tt == nil {
+		return Trade{}, false
+	}
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	if t := tt.trades[tradeKey{clientID, orderID}]; t != nil {
+		return t.clone(), true
+	}
+	return Trade{}, false
+}
+
+// TradeByPermID returns the trade of the order with the given permanent id.
+func (tt *TradeTracker) TradeByPermID(permID int64) (Trade, bool) {
+	if tt == nil {
+		return Trade{}, false
+	}
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	if t := tt.byPermID[permID]; t != nil {
+		return t.clone(), true
+	}
+	return Trade{}, false
+}
+
+// TradesByOrderRef returns the trades of the orders with the given OrderRef, ordered by orderID.
+func (tt *TradeTracker) TradesByOrderRef(orderRef string) []Trade {
+	return tt.filter(func(t *Trade) bool { return t.Order != nil && t.Order.OrderRef == orderRef })
+}
+
+// AllTrades returns every trade, ordered by orderID.
+func (tt *TradeTracker) AllTrades() []Trade {
+	return tt.filter(func(*Trade) bool { return true })
+}
+
+// OpenTrades returns the active trades, ordered by orderID.
+func (tt *TradeTracker) OpenTrades() []Trade {
+	return tt.filter(func(t *Trade) bool { return t.IsActive() })
+}
+
+func (tt *TradeTracker) filter(keep func(*Trade) bool) []Trade {
+	if tt == nil {
+		return nil
+	}
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	var trades []Trade
+	for _, t := range tt.trades {
+		if keep(t) {
+			trades = append(trades, t.clone())
+		}
+	}
+	slices.SortFunc(trades, func(a, b Trade) int {
+		if a.OrderID != b.OrderID {
+			return int(a.OrderID - b.OrderID)
+		}
+		return int(a.ClientID - b.ClientID)
+	})
+	return trades
+}
+
+// notify calls the observers with the events, tt.mu must not be held.
+func (tt *TradeTracker) notify(events []TradeEvent) {
+	tt.observers.notify(events...)
+}
+
+// lookup finds a trade by permID first, then by clientID and orderID. tt.mu must be held.
+func (tt *TradeTracker) lookup(clientID int64, orderID int64, permID int64) *Trade {
+	if permID != 0 {
+		if t := tt.byPermID[permID]; t != nil {
+			return t
+		}
+	}
+	return tt.trades[tradeKey{clientID, orderID}]
+}
+
+// get finds or creates a trade and indexes its permID. tt.mu must be held.
+func (tt *TradeTracker) get(clientID int64, orderID int64, permID int64) *Trade {
+	t := tt.lookup(clientID, orderID, permID)
+	if t == nil {
+		t = &Trade{OrderID: orderID, ClientID: clientID, Status: OrderStatusUnknown, Filled: UNSET_DECIMAL, Remaining: UNSET_DECIMAL}
+		tt.trades[tradeKey{clientID, orderID}] = t
+	}
+	if permID != 0 && t.PermID != permID {
+		t.PermID = permID
+		tt.byPermID[permID] = t
+	}
+	return t
+}
+
+// setStatus records a status transition. tt.mu must be held.
+// A terminal status is final: a late or duplicate OrderStatus does not bring the trade back.
+func (tt *TradeTracker) setStatus(t *Trade, status OrderStatus, message string, events []TradeEvent) []TradeEvent {
+	if t.Status == status || t.Status.IsTerminal() {
+		return events
+	}
+	delete(tt.cancels, t)
+	t.Status = status
+	t.Log = append(t.Log, TradeLogEntry{Time: time.Now(), Status: status, Message: message})
+	return append(events, TradeEvent{Kind: TradeStatusChanged, Trade: t.clone()})
+}
+
+// placing records an order about to be placed: a new trade is PendingSubmit, a known one is modified.
+func (tt *TradeTracker) placing(orderID int64, contract *Contract, order *Order) {
+	if tt == nil || order.WhatIf {
+		return
+	}
+	tt.mu.Lock()
+	var events []TradeEvent
+	t := tt.get(tt.c.Endpoint().ClientID, orderID, 0)
+	// The caller may reuse its contract and order for the next one
+	t.Contract, t.Order = contract.clone(), order.clone()
+	if t.Status == OrderStatusUnknown {
+		events = tt.setStatus(t, OrderStatusPendingSubmit, "", events)
+	} else {
+		t.Log = append(t.Log, TradeLogEntry{Time: time.Now(), Status: t.Status, Message: "Modify"})
+		events = append(events, TradeEvent{Kind: TradeOrderUpdated, Trade: t.clone()})
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+// cancelling records a cancel request of an active order.
+func (tt *TradeTracker) cancelling(orderID int64) {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	var events []TradeEvent
+	if t := tt.trades[tradeKey{tt.c.Endpoint().ClientID, orderID}]; t != nil && t.IsActive() {
+		events = tt.setCancelling(t, "", events)
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+// setCancelling moves an active trade to PendingCancel until TWS answers the cancel request. tt.mu must be held.
+func (tt *TradeTracker) setCancelling(t *Trade, message string, events []TradeEvent) []TradeEvent {
+	status := t.Status
+	events = tt.setStatus(t, OrderStatusPendingCancel, message, events)
+	if _, ok := tt.cancels[t]; !ok && status != OrderStatusPendingCancel {
+		tt.cancels[t] = status
+	}
+	return events
+}
+
+// cancellingAll records a global cancel.
+func (tt *TradeTracker) cancellingAll() {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	var events []TradeEvent
+	for _, t := range tt.trades {
+		if t.IsActive() {
+			events = tt.setCancelling(t, "Global cancel", events)
+		}
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+// cancelRefusedCodes are the errors refusing a cancel request, the order keeps working.
+var cancelRefusedCodes = []int64{136, ErrOrderNotCancellable.Code, 10147, 10148}
+
+// orderError records an error about an order of this client.
+// An order rejected before reaching TWS, or with error 201, is Inactive, error 202 means Cancelled.
+// A refused cancel request brings the order back to its status before the request.
+func (tt *TradeTracker) orderError(err *IBError) {
+	if tt == nil || err.ReqID == NO_VALID_ID {
+		return
+	}
+	tt.mu.Lock()
+	t := tt.trades[tradeKey{tt.c.Endpoint().ClientID, err.ReqID}]
+	if t == nil {
+		tt.mu.Unlock()
+		return
+	}
+	t.Log = append(t.Log, TradeLogEntry{Time: time.Now(), Status: t.Status, Message: err.Msg, ErrorCode: err.Code})
+	events := []TradeEvent{{Kind: TradeError, Trade: t.clone(), Err: err}}
+	switch {
+	case t.IsDone():
+	case err.Code == ErrOrderCancelled.Code:
+		events = tt.setStatus(t, OrderStatusCancelled, err.Msg, events)
+	case slices.Contains(cancelRefusedCodes, err.Code):
+		if status, ok := tt.cancels[t]; ok && t.Status == OrderStatusPendingCancel {
+			events = tt.setStatus(t, status, err.Msg, events)
+		}
+	case err.Code == ErrOrderRejected.Code, err.Severity >= SeverityRequestFatal && t.Status == OrderStatusPendingSubmit:
+		events = tt.setStatus(t, OrderStatusInactive, err.Msg, events)
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+// placeFailed records an order that could not be sent.
+func (tt *TradeTracker) placeFailed(orderID int64, err error) {
+	if tt == nil {
+		return
+	}
+	var ibErr *IBError
+	if !errors.As(err, &ibErr) {
+		ibErr = NewIBError(orderID, currentTimeMillis(), 512, "Order Sending Error - "+err.Error(), "")
+	} else if ibErr.ReqID != orderID {
+		e := *ibErr
+		e.ReqID = orderID
+		ibErr = &e
+	}
+	tt.orderError(ibErr)
+}
+
+func (tt *TradeTracker) openOrder(orderID int64, contract *Contract, order *Order, orderState *OrderState) {
+	if tt == nil || order.WhatIf {
+		return
+	}
+	tt.mu.Lock()
+	t := tt.get(order.ClientID, orderID, order.PermID)
+	t.Contract, t.Order, t.OrderState = contract, order, orderState
+	events := []TradeEvent{{Kind: TradeOrderUpdated, Trade: t.clone()}}
+	if orderState.Status != "" {
+		events = tt.setStatus(t, OrderStatusFromString(orderState.Status), "", events)
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+func (tt *TradeTracker) orderStatus(orderID int64, status string, filled Decimal, remaining Decimal, avgFillPrice float64, permID int64, lastFillPrice float64, clientID int64, whyHeld string) {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	t := tt.get(clientID, orderID, permID)
+	t.Filled, t.Remaining = filled, remaining
+	t.AvgFillPrice, t.LastFillPrice = avgFillPrice, lastFillPrice
+	t.WhyHeld = whyHeld
+	// TWS repeats the statuses, only the transitions are reported
+	events := tt.setStatus(t, OrderStatusFromString(status), whyHeld, nil)
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+func (tt *TradeTracker) execDetails(contract *Contract, execution *Execution) {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	if tt.byExecID[execution.ExecID] != nil {
+		tt.mu.Unlock()
+		return
+	}
+	t := tt.get(execution.ClientID, execution.OrderID, execution.PermID)
+	if t.Contract == nil {
+		t.Contract = contract
+	}
+	fill := Fill{Contract: contract, Execution: *execution, Time: time.Now()}
+	if report, ok := tt.commissions[execution.ExecID]; ok {
+		fill.CommissionAndFeesReport = &report
+		delete(tt.commissions, execution.ExecID)
+	}
+	t.Fills = append(t.Fills, fill)
+	tt.byExecID[execution.ExecID] = t
+	events := []TradeEvent{{Kind: TradeFilled, Trade: t.clone(), Fill: &fill}}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+func (tt *TradeTracker) commissionAndFeesReport(report CommissionAndFeesReport) {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	t := tt.byExecID[report.ExecID]
+	if t == nil {
+		tt.commissions[report.ExecID] = report
+		tt.mu.Unlock()
+		return
+	}
+	var events []TradeEvent
+	for i := range t.Fills {
+		if f := &t.Fills[i]; f.Execution.ExecID == report.ExecID {
+			f.CommissionAndFeesReport = &report
+			fill := *f
+			events = append(events, TradeEvent{Kind: TradeCommission, Trade: t.clone(), Fill: &fill})
+			break
+		}
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
+
+// orderBound rekeys the trade of a permID on the API ids bound to it.
+func (tt *TradeTracker) orderBound(permID int64, clientID int64, orderID int64) {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	t := tt.byPermID[permID]
+	if t == nil {
+		return
+	}
+	delete(tt.trades, tradeKey{t.ClientID, t.OrderID})
+	t.ClientID, t.OrderID = clientID, orderID
+	tt.trades[tradeKey{clientID, orderID}] = t
+}
+
+func (tt *TradeTracker) completedOrder(contract *Contract, order *Order, orderState *OrderState) {
+	if tt == nil {
+		return
+	}
+	tt.mu.Lock()
+	t := tt.get(order.ClientID, order.OrderID, order.PermID)
+	t.Contract, t.Order, t.OrderState = contract, order, orderState
+	events := []TradeEvent{{Kind: TradeOrderUpdated, Trade: t.clone()}}
+	if orderState.Status != "" {
+		events = tt.setStatus(t, OrderStatusFromString(orderState.Status), orderState.CompletedStatus, events)
+	}
+	tt.mu.Unlock()
+	tt.notify(events)
+}
@@ -0,0 +1,222 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestTradeLifecycle(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 1})
+	var events []TradeEventKind
+	tt.AddObserver(func(ev TradeEvent) { events = append(events, ev.Kind) })
+
+	contract := &Contract{Symbol: "IBM"}
+	order := &Order{OrderID: 7, ClientID: 1, Action: "BUY", TotalQuantity: StringToDecimal("100"), OrderRef: "strat-a"}
+	tt.placing(7, contract, order)
+
+	order = &Order{OrderID: 7, ClientID: 1, PermID: 900, Action: "BUY", TotalQuantity: StringToDecimal("100"), OrderRef: "strat-a"}
+	tt.openOrder(7, contract, order, &OrderState{Status: "PreSubmitted"})
+	tt.orderStatus(7, "Submitted", ZERO, StringToDecimal("100"), 0, 900, 0, 1, "")
+	tt.orderStatus(7, "Submitted", ZERO, StringToDecimal("100"), 0, 900, 0, 1, "")
+
+	// The commission of the first fill comes before its execution
+	tt.commissionAndFeesReport(CommissionAndFeesReport{ExecID: "e1", CommissionAndFees: 1})
+	tt.execDetails(contract, &Execution{ExecID: "e1", OrderID: 7, ClientID: 1, PermID: 900, Shares: StringToDecimal("40"), Price: 10})
+	tt.execDetails(contract, &Execution{ExecID: "e2", OrderID: 7, ClientID: 1, PermID: 900, Shares: StringToDecimal("60"), Price: 20})
+	tt.execDetails(contract, &Execution{ExecID: "e2", OrderID: 7, ClientID: 1, PermID: 900, Shares: StringToDecimal("60"), Price: 20})
+	tt.commissionAndFeesReport(CommissionAndFeesReport{ExecID: "e2", CommissionAndFees: 1.5})
+	tt.orderStatus(7, "Filled", StringToDecimal("100"), ZERO, 16, 900, 20, 1, "")
+
+	trade, ok := tt.Trade(7)
+	if !ok {
+		t.Fatal("trade not found")
+	}
+	if trade.Status != OrderStatusFilled || !trade.IsDone() || trade.PermID != 900 {
+		t.Errorf("unexpected trade %+v", trade)
+	}
+	if len(trade.Fills) != 2 || trade.Commission() != 2.5 || trade.AvgPrice() != 16 || trade.FilledQuantity() != StringToDecimal("100") {
+		t.Errorf("unexpected fills %+v", trade.Fills)
+	}
+	var statuses []OrderStatus
+	for _, entry := range trade.Log {
+		statuses = append(statuses, entry.Status)
+	}
+	want := []OrderStatus{OrderStatusPendingSubmit, OrderStatusPreSubmitted, OrderStatusSubmitted, OrderStatusFilled}
+	if len(statuses) != len(want) {
+		t.Fatalf("got log %v, want %v", statuses, want)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Fatalf("got log %v, want %v", statuses, want)
+		}
+	}
+	wantEvents := []TradeEventKind{
+		TradeStatusChanged, TradeOrderUpdated, TradeStatusChanged, TradeStatusChanged,
+		TradeFilled, TradeFilled, TradeCommission, TradeStatusChanged,
+	}
+	if len(events) != len(wantEvents) {
+		t.Fatalf("got events %v, want %v", events, wantEvents)
+	}
+	for i := range wantEvents {
+		if events[i] != wantEvents[i] {
+			t.Fatalf("got events %v, want %v", events, wantEvents)
+		}
+	}
+
+	if byPerm, ok := tt.TradeByPermID(900); !ok || byPerm.OrderID != 7 {
+		t.Errorf("unexpected trade by permID %+v", byPerm)
+	}
+	if byRef := tt.TradesByOrderRef("strat-a"); len(byRef) != 1 || byRef[0].OrderID != 7 {
+		t.Errorf("unexpected trades by order ref %+v", byRef)
+	}
+	if open := tt.OpenTrades(); len(open) != 0 {
+		t.Errorf("unexpected open trades %+v", open)
+	}
+}
+
+func TestTradeCancel(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 1})
+	tt.placing(8, &Contract{}, &Order{})
+	tt.orderStatus(8, "Submitted", ZERO, ONE, 0, 901, 0, 1, "")
+	tt.cancelling(8)
+	if trade, _ := tt.Trade(8); trade.Status != OrderStatusPendingCancel {
+		t.Errorf("got %s, want PendingCancel", trade.Status)
+	}
+	tt.orderError(NewIBError(8, 0, 202, "Order Canceled - reason:", ""))
+	trade, _ := tt.Trade(8)
+	if trade.Status != OrderStatusCancelled {
+		t.Errorf("got %s, want Cancelled", trade.Status)
+	}
+	// A late cancel request does not revive the order
+	tt.cancelling(8)
+	if trade, _ := tt.Trade(8); trade.Status != OrderStatusCancelled {
+		t.Errorf("got %s, want Cancelled", trade.Status)
+	}
+	if last := trade.Log[len(trade.Log)-1]; last.Status != OrderStatusCancelled {
+		t.Errorf("unexpected log %+v", trade.Log)
+	}
+}
+
+func TestTradeTerminalStatus(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 1})
+	tt.placing(8, &Contract{}, &Order{})
+	tt.orderStatus(8, "Submitted", ZERO, ONE, 0, 901, 0, 1, "")
+	tt.orderStatus(8, "Filled", ONE, ZERO, 10, 901, 0, 10, "")
+	// Late and duplicate statuses leave the order filled
+	tt.orderStatus(8, "Submitted", ZERO, ONE, 0, 901, 0, 1, "")
+	tt.orderStatus(8, "Filled", ONE, ZERO, 10, 901, 0, 10, "")
+	trade, _ := tt.Trade(8)
+	if trade.Status != OrderStatusFilled {
+		t.Errorf("got %s, want Filled", trade.Status)
+	}
+	if n := len(trade.Log); n != 3 {
+		t.Errorf("expected 3 transitions, got %+v", trade.Log)
+	}
+}
+
+func TestTradeOwnsOrder(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 1})
+	contract := &Contract{Symbol: "SPREAD", ComboLegs: []ComboLeg{{ConID: 1, Ratio: 1}}}
+	order := &Order{TotalQuantity: ONE, AlgoParams: []TagValue{{Tag: "maxPctVol", Value: "0.1"}}}
+	tt.placing(8, contract, order)
+
+	// The caller reuses its contract and order
+	contract.ComboLegs[0].ConID = 2
+	order.TotalQuantity = ZERO
+	order.AlgoParams[0].Value = "0.5"
+	trade, _ := tt.Trade(8)
+	if trade.Contract.ComboLegs[0].ConID != 1 || trade.Order.TotalQuantity != ONE || trade.Order.AlgoParams[0].Value != "0.1" {
+		t.Errorf("trade shares the caller's contract or order: %+v %+v", trade.Contract, trade.Order)
+	}
+
+	// Neither do snapshots share them with the tracker
+	trade.Order.AlgoParams[0].Value = "0.9"
+	if again, _ := tt.Trade(8); again.Order.AlgoParams[0].Value != "0.1" {
+		t.Error("snapshot shares the order of the tracker")
+	}
+}
+
+func TestTradeCancelRefused(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 1})
+	tt.placing(8, &Contract{}, &Order{})
+	tt.orderStatus(8, "PreSubmitted", ZERO, ONE, 0, 901, 0, 1, "")
+	tt.cancelling(8)
+	tt.cancelling(8)
+	tt.orderError(NewIBError(8, 0, 161, "Cancel attempted when order is not in a cancellable state", ""))
+	trade, _ := tt.Trade(8)
+	if trade.Status != OrderStatusPreSubmitted {
+		t.Errorf("got %s, want PreSubmitted", trade.Status)
+	}
+	if last := trade.Log[len(trade.Log)-1]; last.Status != OrderStatusPreSubmitted || last.Message == "" {
+		t.Errorf("unexpected log %+v", trade.Log)
+	}
+
+	// Once TWS reported a status, a late refusal leaves it
+	tt.cancellingAll()
+	tt.orderStatus(8, "Submitted", ZERO, ONE, 0, 901, 0, 1, "")
+	tt.orderError(NewIBError(8, 0, 10148, "OrderId that needs to be cancelled cannot be cancelled", ""))
+	if trade, _ := tt.Trade(8); trade.Status != OrderStatusSubmitted {
+		t.Errorf("got %s, want Submitted", trade.Status)
+	}
+}
+
+func TestTradeRejected(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 1})
+	tt.placing(9, &Contract{}, &Order{})
+	tt.placeFailed(9, context.Canceled)
+	trade, _ := tt.Trade(9)
+	if trade.Status != OrderStatusInactive || trade.Log[len(trade.Log)-2].ErrorCode != 512 {
+		t.Errorf("unexpected trade %+v", trade)
+	}
+
+	var errs []*IBError
+	tt.AddObserver(func(ev TradeEvent) {
+		if ev.Kind == TradeError {
+			errs = append(errs, ev.Err)
+		}
+	})
+	tt.placing(10, &Contract{}, &Order{})
+	tt.orderError(NewIBError(10, 0, 201, "Order rejected - reason:", ""))
+	if trade, _ := tt.Trade(10); trade.Status != OrderStatusInactive {
+		t.Errorf("got %s, want Inactive", trade.Status)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrOrderRejected) {
+		t.Errorf("unexpected errors %v", errs)
+	}
+}
+
+func TestTradeOrderBound(t *testing.T) {
+	tt := newTradeTracker(&EClient{clientID: 0})
+	// An order placed in TWS
+	tt.openOrder(0, &Contract{}, &Order{PermID: 950}, &OrderState{Status: "Submitted"})
+	tt.orderBound(950, 0, -5)
+	if trade, ok := tt.Trade(-5); !ok || trade.PermID != 950 {
+		t.Errorf("unexpected trade %+v", trade)
+	}
+	if _, ok := tt.Trade(0); ok {
+		t.Error("trade still under its old id")
+	}
+}
+
+func TestTradeSession(t *testing.T) {
+	ib, gw, conn, w := connectSync(t, func(ib *EClient) { ib.SetTradeTracking(true) })
+
+	ib.PlaceOrder(42, &Contract{Symbol: "IBM", SecType: "STK", Exchange: "SMART", Currency: "USD"}, &Order{Action: "BUY", OrderType: "MKT", TotalQuantity: ONE})
+	gw.nextRequest(t, PLACE_ORDER)
+	if trade, ok := ib.Trades().Trade(42); !ok || trade.Status != OrderStatusPendingSubmit {
+		t.Fatalf("unexpected trade %+v", trade)
+	}
+	writeFrame(conn, ORDER_STATUS, 42, "Submitted", 0, 1, 0, 1000, 0, 0, 1, "", 0)
+	waitFor(t, "order submitted", func() bool {
+		trade, _ := ib.Trades().Trade(42)
+		return trade.Status == OrderStatusSubmitted
+	})
+
+	// The tracker cannot be swapped under the decoder
+	ib.SetTradeTracking(false)
+	if ib.Trades() == nil || !slices.Contains(w.recorded(), ALREADY_CONNECTED.Code) {
+		t.Error("trade tracking disabled while connected")
+	}
+}
@@ -0,0 +1,66 @@
+package ibapi
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConnectConnPipe(t *testing.T) {
+	client, server := net.Pipe()
+	gw := newFakeGatewayOn(nil)
+	go gw.handle(server)
+	defer server.Close()
+
+	ib := NewEClient(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ib.ConnectConn(ctx, client, 7); err != nil {
+		t.Fatalf("connect over pipe: %v", err)
+	}
+	defer ib.Disconnect()
+
+	fields := gw.nextRequest(t, START_API)
+	if string(fields[2]) != "7" {
+		t.Errorf("expected clientID 7, got %s", fields[2])
+	}
+
+	ib.ReqCurrentTime()
+	gw.nextRequest(t, REQ_CURRENT_TIME)
+
+	bytesSent, msgSent, bytesRecv, _ := ib.conn.GetStatistics()
+	if bytesSent == 0 || msgSent == 0 || bytesRecv == 0 {
+		t.Errorf("statistics not counted over the pipe: sent %d bytes / %d msgs, received %d bytes", bytesSent, msgSent, bytesRecv)
+	}
+}
+
+func TestDialerUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ibgw.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets not available: %v", err)
+	}
+	gw := newFakeGatewayOn(listener)
+	go gw.serve()
+	defer listener.Close()
+
+	var network, address string
+	ib := NewEClient(nil)
+	ib.SetDialer(DialerFunc(func(ctx context.Context, n, a string) (net.Conn, error) {
+		network, address = n, a
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", path)
+	}))
+
+	if err := ib.Connect("::1", 4002, 1); err != nil {
+		t.Fatalf("connect through dialer: %v", err)
+	}
+	defer ib.Disconnect()
+	gw.nextRequest(t, START_API)
+
+	if network != "tcp" || address != "[::1]:4002" {
+		t.Errorf("dialer called with %s %s", network, address)
+	}
+}
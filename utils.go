@@ -38,6 +38,20 @@ func scanFields(data []byte, atEOF bool) (advance int, token []byte, err error)
 	return totalSize, data[4:totalSize], nil
 }
 
+// decodeFault is the panic value of the decoding of a malformed message.
+// It is the only panic processMsg recovers: any other one is a bug, in the client or in the application.
+type decodeFault struct {
+	msg string
+	err error
+}
+
+func (f decodeFault) Error() string {
+	if f.err == nil {
+		return f.msg
+	}
+	return f.msg + ": " + f.err.Error()
+}
+
 // MsgBuffer is the buffer that contains a whole msg.
 type MsgBuffer struct {
 	bytes.Buffer
@@ -53,7 +67,7 @@ func NewMsgBuffer(bs []byte) *MsgBuffer {
 func (m *MsgBuffer) decode() {
 	_, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode read error")
+		panic(decodeFault{msg: "decode read error", err: m.err})
 	}
 }
 
@@ -61,7 +75,7 @@ func (m *MsgBuffer) decodeInt64() int64 {
 	var i int64
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode int64 read error")
+		panic(decodeFault{msg: "decode int64 read error", err: m.err})
 	}
 
 	m.bs = m.bs[:len(m.bs)-1]
@@ -72,7 +86,7 @@ func (m *MsgBuffer) decodeInt64() int64 {
 	i, m.err = strconv.ParseInt(string(m.bs), 10, 64)
 	if m.err != nil {
 		fmt.Println(string(m.bs))
-		log.Panic().Err(m.err).Msg("decode int64 parse error")
+		panic(decodeFault{msg: "decode int64 parse error", err: m.err})
 	}
 
 	return i
@@ -81,14 +95,14 @@ func (m *MsgBuffer) decodeInt64() int64 {
 func (m *MsgBuffer) decodeRawInt64() int64 {
 	// Ensure there's enough data in the buffer
 	if m.Len() < RAW_INT_LEN {
-		log.Panic().Err(m.err).Msg("decode raw int64 read error")
+		panic(decodeFault{msg: "decode raw int64 read error", err: m.err})
 	}
 
 	// Read 4 bytes
 	buf := make([]byte, RAW_INT_LEN)
 	n, err := m.Read(buf)
 	if err != nil || n != RAW_INT_LEN {
-		log.Panic().Err(err).Msg("decode raw int64 read error")
+		panic(decodeFault{msg: "decode raw int64 read error", err: err})
 	}
 
 	// Update m.bs to contain the remaining buffer
@@ -101,7 +115,7 @@ func (m *MsgBuffer) decodeRawInt64() int64 {
 func (m *MsgBuffer) decodeDecimal() Decimal {
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode decimal read error")
+		panic(decodeFault{msg: "decode decimal read error", err: m.err})
 	}
 
 	d := StringToDecimal(string(m.bs[:len(m.bs)-1]))
@@ -112,7 +126,7 @@ func (m *MsgBuffer) decodeInt64ShowUnset() int64 {
 	var i int64
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode int64ShowUnset read error")
+		panic(decodeFault{msg: "decode int64ShowUnset read error", err: m.err})
 	}
 
 	m.bs = m.bs[:len(m.bs)-1]
@@ -122,7 +136,7 @@ func (m *MsgBuffer) decodeInt64ShowUnset() int64 {
 
 	i, m.err = strconv.ParseInt(string(m.bs), 10, 64)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode int64ShowUnset parse error")
+		panic(decodeFault{msg: "decode int64ShowUnset parse error", err: m.err})
 	}
 
 	return i
@@ -132,7 +146,7 @@ func (m *MsgBuffer) decodeFloat64() float64 {
 	var f float64
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode float64 read error")
+		panic(decodeFault{msg: "decode float64 read error", err: m.err})
 	}
 
 	m.bs = m.bs[:len(m.bs)-1]
@@ -142,7 +156,7 @@ func (m *MsgBuffer) decodeFloat64() float64 {
 
 	f, m.err = strconv.ParseFloat(string(m.bs), 64)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode float64 parse error")
+		panic(decodeFault{msg: "decode float64 parse error", err: m.err})
 	}
 
 	return f
@@ -152,7 +166,7 @@ func (m *MsgBuffer) decodeFloat64ShowUnset() float64 {
 	var f float64
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode float64ShowUnset read error")
+		panic(decodeFault{msg: "decode float64ShowUnset read error", err: m.err})
 	}
 
 	m.bs = m.bs[:len(m.bs)-1]
@@ -162,7 +176,7 @@ func (m *MsgBuffer) decodeFloat64ShowUnset() float64 {
 
 	f, m.err = strconv.ParseFloat(string(m.bs), 64)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode float64ShowUnset parse error")
+		panic(decodeFault{msg: "decode float64ShowUnset parse error", err: m.err})
 	}
 
 	return f
@@ -171,7 +185,7 @@ func (m *MsgBuffer) decodeFloat64ShowUnset() float64 {
 func (m *MsgBuffer) decodeBool() bool {
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode bool read error")
+		panic(decodeFault{msg: "decode bool read error", err: m.err})
 	}
 
 	m.bs = m.bs[:len(m.bs)-1]
@@ -185,7 +199,7 @@ func (m *MsgBuffer) decodeBool() bool {
 func (m *MsgBuffer) decodeThreeStateBool() ThreeStateBoolean {
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode bool read error")
+		panic(decodeFault{msg: "decode bool read error", err: m.err})
 	}
 
 	m.bs = m.bs[:len(m.bs)-1]
@@ -204,7 +218,7 @@ func (m *MsgBuffer) decodeThreeStateBool() ThreeStateBoolean {
 func (m *MsgBuffer) decodeString() string {
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode string read error")
+		panic(decodeFault{msg: "decode string read error", err: m.err})
 	}
 
 	return string(m.bs[:len(m.bs)-1])
@@ -213,12 +227,12 @@ func (m *MsgBuffer) decodeString() string {
 func (m *MsgBuffer) decodeStringUnescaped() string {
 	m.bs, m.err = m.ReadBytes(delim)
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode string read error")
+		panic(decodeFault{msg: "decode string read error", err: m.err})
 	}
 	var s string
 	s, m.err = strconv.Unquote(fmt.Sprint("\"", m.bs[:len(m.bs)-1], "\""))
 	if m.err != nil {
-		log.Panic().Err(m.err).Msg("decode string unmarshal error")
+		panic(decodeFault{msg: "decode string unmarshal error", err: m.err})
 	}
 	return s
 }
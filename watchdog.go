@@ -0,0 +1,245 @@
+package ibapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// WatchdogConfig configures the liveness watchdog of an EClient.
+// When nothing was received for Interval, the watchdog asks the server time with ReqCurrentTimeInMillis.
+// A probe without answer after Timeout is missed, after MaxMissed consecutive misses the session is declared dead
+// and ended: the client is then disconnected or, with a reconnect policy, the session is rebuilt.
+type WatchdogConfig struct {
+	// Interval is the idle time before a probe is sent.
+	Interval time.Duration
+	// Timeout is the time given to the server to answer a probe.
+	Timeout time.Duration
+	// MaxMissed is the number of consecutive missed probes declaring the session dead.
+	MaxMissed int
+}
+
+// DefaultWatchdogConfig returns a watchdog probing after 30s of silence and giving up after 3 probes missed by 10s.
+func DefaultWatchdogConfig() *WatchdogConfig {
+	return &WatchdogConfig{
+		Interval:  30 * time.Second,
+		Timeout:   10 * time.Second,
+		MaxMissed: 3,
+	}
+}
+
+// ErrWatchdogTimeout is the reason of a session ended by the watchdog.
+var ErrWatchdogTimeout = errors.New("server not answering the watchdog probes")
+
+// WatchdogStats holds the liveness watchdog measurements.
+type WatchdogStats struct {
+	Probes            int64         // probes sent
+	Missed            int64         // probes not answered within Timeout
+	ConsecutiveMissed int           // probes missed since the last answer
+	RTT               time.Duration // round trip of the last answered probe
+	SmoothedRTT       time.Duration // exponentially weighted round trip, as TCP does
+	ClockOffset       time.Duration // server clock minus local clock, estimated at the last answer
+	LastAnswer        time.Time
+}
+
+// watchdog probes the session liveness.
+type watchdog struct {
+	config WatchdogConfig
+
+	mu       sync.Mutex
+	sentAt   time.Time // zero when no probe is pending
+	inFlight int       // probes sent and not answered yet, missed ones included
+	asked    int       // server time requests of the user not answered yet
+	millis   bool      // probes ask the server time in milliseconds
+	stats    WatchdogStats
+}
+
+// SetWatchdog enables the liveness watchdog with the given configuration, nil disables it.
+// It must be called before Connect.
+func (c *EClient) SetWatchdog(config *WatchdogConfig) {
+
+	if c.IsConnected() {
+		c.wrapper.Error(NO_VALID_ID, currentTimeMillis(), ALREADY_CONNECTED.Code, ALREADY_CONNECTED.Msg, "")
+		return
+	}
+
+	if config == nil {
+		c.watchdog = nil
+		return
+	}
+	c.watchdog = &watchdog{config: *config}
+}
+
+// WatchdogStats returns the liveness watchdog measurements, zero values when disabled.
+// Traffic counters are available from Connection.GetStatistics.
+func (c *EClient) WatchdogStats() WatchdogStats {
+	if c.watchdog == nil {
+		return WatchdogStats{}
+	}
+	c.watchdog.mu.Lock()
+	defer c.watchdog.mu.Unlock()
+	return c.watchdog.stats
+}
+
+// run probes the session bound to ctx until it ends.
+func (w *watchdog) run(ctx context.Context, c *EClient) {
+	log.Debug().Msg("watchdog started")
+	defer log.Debug().Msg("watchdog ended")
+	defer c.wg.Done()
+
+	w.mu.Lock()
+	w.sentAt = time.Time{}
+	w.inFlight, w.asked = 0, 0
+	w.millis = c.ServerVersion() >= MIN_SERVER_VER_CURRENT_TIME_IN_MILLIS
+	w.stats.ConsecutiveMissed = 0
+	w.mu.Unlock()
+
+	step := min(w.config.Interval, w.config.Timeout) / 2
+	if step <= 0 {
+		step = max(w.config.Interval, w.config.Timeout, time.Second)
+	}
+	ticker := time.NewTicker(step)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if w.missed(now) {
+				log.Error().Int("maxMissed", w.config.MaxMissed).Msg("watchdog: server not answering, ending session")
+				c.endSession(ErrWatchdogTimeout)
+				return
+			}
+			if !w.shouldProbe(now, c.conn.lastRecvTime()) || !c.IsConnected() {
+				continue
+			}
+			// A requester stuck in Write on a half-open socket leaves reqChan full: the probe is then missed
+			select {
+			case c.reqChan <- c.watchdogProbeMsg():
+			default:
+				if w.unsent() {
+					log.Error().Int("maxMissed", w.config.MaxMissed).Msg("watchdog: requester stuck, ending session")
+					c.endSession(ErrWatchdogTimeout)
+					return
+				}
+			}
+		}
+	}
+}
+
+// unsent accounts for a probe that could not be queued as a missed one, it returns true when the session is to be declared dead.
+func (w *watchdog) unsent() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.sentAt = time.Time{}
+	w.inFlight--
+	w.stats.Missed++
+	w.stats.ConsecutiveMissed++
+	log.Warn().Int("missed", w.stats.ConsecutiveMissed).Msg("watchdog: probe not sent, request queue full")
+	return w.config.MaxMissed > 0 && w.stats.ConsecutiveMissed >= w.config.MaxMissed
+}
+
+// missed accounts for a pending probe without answer, it returns true when the session is to be declared dead.
+func (w *watchdog) missed(now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.sentAt.IsZero() || now.Sub(w.sentAt) < w.config.Timeout {
+		return false
+	}
+	w.sentAt = time.Time{}
+	w.stats.Missed++
+	w.stats.ConsecutiveMissed++
+	log.Warn().Int("missed", w.stats.ConsecutiveMissed).Msg("watchdog: probe missed")
+	return w.config.MaxMissed > 0 && w.stats.ConsecutiveMissed >= w.config.MaxMissed
+}
+
+// shouldProbe tells whether a probe is due and records it as sent.
+func (w *watchdog) shouldProbe(now, lastRecv time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.sentAt.IsZero() {
+		return false
+	}
+	// A missed probe is retried right away, otherwise only after Interval of silence
+	if w.stats.ConsecutiveMissed == 0 && now.Sub(lastRecv) < w.config.Interval {
+		return false
+	}
+	w.sentAt = now
+	w.inFlight++
+	w.stats.Probes++
+	return true
+}
+
+// ask records a server time request of the user, in milliseconds or not, so that its answer is not taken for a probe one.
+// The returned func withdraws the request when it is not sent. It is a no-op on a nil watchdog.
+func (w *watchdog) ask(millis bool) (withdraw func()) {
+	if w == nil {
+		return func() {}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if millis != w.millis {
+		return func() {}
+	}
+	w.asked++
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.asked = max(w.asked-1, 0)
+	}
+}
+
+// answer records the server time received, in milliseconds or not, for a pending probe.
+// It returns false when the answer is the one of a probe, that the user did not ask for.
+func (w *watchdog) answer(millis bool, serverTime, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if millis != w.millis {
+		return true
+	}
+	// Both answers are alike: the user gets the first ones, as many as asked
+	forward := w.asked > 0 || w.inFlight == 0
+	if w.asked > 0 {
+		w.asked--
+	} else if w.inFlight > 0 {
+		w.inFlight--
+	}
+	if w.sentAt.IsZero() {
+		return forward
+	}
+	rtt := now.Sub(w.sentAt)
+	w.sentAt = time.Time{}
+
+	w.stats.RTT = rtt
+	if w.stats.SmoothedRTT == 0 {
+		w.stats.SmoothedRTT = rtt
+	} else {
+		w.stats.SmoothedRTT += (rtt - w.stats.SmoothedRTT) / 8
+	}
+	// The server time is assumed to be taken halfway through the round trip
+	w.stats.ClockOffset = serverTime.Sub(now.Add(-rtt / 2))
+	w.stats.ConsecutiveMissed = 0
+	w.stats.LastAnswer = now
+	return forward
+}
+
+// watchdogProbeMsg encodes the server time request best supported by the server.
+func (c *EClient) watchdogProbeMsg() []byte {
+	me := NewMsgEncoder(2, c)
+	switch {
+	case c.useProtoBuf(REQ_CURRENT_TIME_IN_MILLIS):
+		me.encodeMsgID(REQ_CURRENT_TIME_IN_MILLIS + PROTOBUF_MSG_ID)
+		msg, _ := proto.Marshal(createCurrentTimeInMillisRequestProto())
+		me.encodeProto(msg)
+	case c.ServerVersion() >= MIN_SERVER_VER_CURRENT_TIME_IN_MILLIS:
+		me.encodeMsgID(REQ_CURRENT_TIME_IN_MILLIS)
+	default:
+		const VERSION = 1
+		me.encodeMsgID(REQ_CURRENT_TIME).encodeInt(VERSION)
+	}
+	return me.Bytes()
+}
@@ -0,0 +1,131 @@
+package ibapi
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchdogMeasuresRoundTrip(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	ib := NewEClient(nil)
+	ib.SetWatchdog(&WatchdogConfig{Interval: 50 * time.Millisecond, Timeout: time.Second, MaxMissed: 3})
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+	session := gw.nextSession(t)
+
+	// The server clock is one hour ahead
+	gw.nextRequest(t, REQ_CURRENT_TIME_IN_MILLIS)
+	writeFrame(session, CURRENT_TIME_IN_MILLIS, time.Now().Add(time.Hour).UnixMilli())
+
+	waitFor(t, "probe answered", func() bool { return !ib.WatchdogStats().LastAnswer.IsZero() })
+	stats := ib.WatchdogStats()
+	if stats.Probes < 1 || stats.Missed != 0 {
+		t.Errorf("unexpected probes %d / missed %d", stats.Probes, stats.Missed)
+	}
+	if stats.RTT <= 0 || stats.SmoothedRTT != stats.RTT {
+		t.Errorf("unexpected RTT %v / smoothed %v", stats.RTT, stats.SmoothedRTT)
+	}
+	if offset := stats.ClockOffset - time.Hour; offset < -time.Second || offset > time.Second {
+		t.Errorf("expected a one hour clock offset, got %v", stats.ClockOffset)
+	}
+}
+
+func TestWatchdogDeclaresDeadSession(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	// The gateway accepts requests but never answers
+	w := &sessionWrapper{}
+	ib := NewEClient(w)
+	ib.SetWatchdog(&WatchdogConfig{Interval: 20 * time.Millisecond, Timeout: 50 * time.Millisecond, MaxMissed: 2})
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+
+	gw.nextRequest(t, REQ_CURRENT_TIME_IN_MILLIS)
+	gw.nextRequest(t, REQ_CURRENT_TIME_IN_MILLIS)
+
+	waitFor(t, "dead session disconnected", func() bool { return w.closed.Load() == 1 && !ib.IsConnected() })
+	if stats := ib.WatchdogStats(); stats.Missed != 2 {
+		t.Errorf("expected 2 missed probes, got %d", stats.Missed)
+	}
+}
+
+func TestWatchdogStuckRequester(t *testing.T) {
+	ib := NewEClient(nil)
+	client, server := net.Pipe()
+	defer server.Close()
+	ib.conn.attach(client)
+	atomic.StoreInt32(&ib.connState, int32(CONNECTED))
+	// No requester: reqChan stays full as behind a Write blocked on a half-open socket
+	for range cap(ib.reqChan) {
+		ib.reqChan <- nil
+	}
+
+	w := &watchdog{config: WatchdogConfig{Interval: 10 * time.Millisecond, Timeout: time.Hour, MaxMissed: 2}}
+	ib.watchdog = w
+	ib.wg.Add(1)
+	go w.run(ib.ctx, ib)
+
+	select {
+	case <-ib.ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("session not ended")
+	}
+	ib.wg.Wait()
+	if err := ib.sessionEndError(); err != ErrWatchdogTimeout {
+		t.Errorf("expected ErrWatchdogTimeout, got %v", err)
+	}
+	if stats := ib.WatchdogStats(); stats.Missed != 2 {
+		t.Errorf("expected 2 missed probes, got %d", stats.Missed)
+	}
+}
+
+// timeWrapper counts the server times reaching the user.
+type timeWrapper struct {
+	Wrapper
+	times atomic.Int32
+}
+
+func (w *timeWrapper) CurrentTimeInMillis(int64) { w.times.Add(1) }
+
+func TestWatchdogProbesNotForwarded(t *testing.T) {
+	gw := newFakeGateway(t)
+	host, port := gw.address()
+
+	w := &timeWrapper{}
+	ib := NewEClient(w)
+	ib.SetWatchdog(&WatchdogConfig{Interval: 20 * time.Millisecond, Timeout: time.Second, MaxMissed: 3})
+	if err := ib.Connect(host, port, 1); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer ib.Disconnect()
+	session := gw.nextSession(t)
+
+	// An idle session is probed without the user knowing
+	gw.nextRequest(t, REQ_CURRENT_TIME_IN_MILLIS)
+	writeFrame(session, CURRENT_TIME_IN_MILLIS, time.Now().UnixMilli())
+	waitFor(t, "probe answered", func() bool { return !ib.WatchdogStats().LastAnswer.IsZero() })
+	if n := w.times.Load(); n != 0 {
+		t.Errorf("expected no user callback, got %d", n)
+	}
+
+	// The user asks while a probe is pending: one answer each
+	gw.nextRequest(t, REQ_CURRENT_TIME_IN_MILLIS)
+	ib.ReqCurrentTimeInMillis()
+	gw.nextRequest(t, REQ_CURRENT_TIME_IN_MILLIS)
+	writeFrame(session, CURRENT_TIME_IN_MILLIS, time.Now().UnixMilli())
+	writeFrame(session, CURRENT_TIME_IN_MILLIS, time.Now().UnixMilli())
+	waitFor(t, "user answered", func() bool { return w.times.Load() == 1 })
+	time.Sleep(50 * time.Millisecond)
+	if n := w.times.Load(); n != 1 {
+		t.Errorf("expected a single user callback, got %d", n)
+	}
+}
@@ -71,7 +71,7 @@ type EWrapper interface {
 	UpdateAccountTime(timeStamp string)
 	// AccountDownloadEnd is called after a batch updateAccountValue() and updatePortfolio() is sent.
 	AccountDownloadEnd(accountName string)
-	// NextValidID Receives next valid order id. NOT THREAD-SAFE.
+	// NextValidID Receives next valid order id. NOT THREAD-SAFE, use EClient.OrderIDs to allocate ids.
 	NextValidID(reqID int64)
 	// ContractDetails Receives the full contract's definitions. This method will return all contracts matching the requested via reqContractDetails().
 	// For example, one can obtain the whole option chain with it.